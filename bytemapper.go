@@ -151,6 +151,21 @@ func (m *ByteMapper) ByteMap() (uint64, uint64) {
 	return m.bits[0], m.bits[1]
 }
 
+// AcceptLen returns the length of leading bytes in b which are enabled in
+// this mapper. Bytes above 0x7F are never accepted.
+func (m *ByteMapper) AcceptLen(b []byte) int {
+	for idx, ch := range b {
+		if ch > 127 {
+			return idx
+		}
+		bIndex, offset := computeBitMapParam(ch)
+		if (m.bits[bIndex] & (1 << offset)) == 0 {
+			return idx
+		}
+	}
+	return len(b)
+}
+
 func (m *ByteMapper) Empty() bool {
 	return (m.bits[0] == 0) && (m.bits[1] == 0)
 }
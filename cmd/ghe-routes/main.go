@@ -0,0 +1,239 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	protocgenghe "github.com/yinyin/protoc-gen-go-grpc-http-endpoint"
+)
+
+type captureEntry struct {
+	Name         string `json:"name"`
+	Pattern      string `json:"pattern"`
+	Field        string `json:"field,omitempty"`
+	Setter       string `json:"setter,omitempty"`
+	HandlerParam string `json:"handler_param,omitempty"`
+	Type         string `json:"type"`
+}
+
+func (c *captureEntry) String() string {
+	dest := c.Field
+	if c.Setter != "" {
+		dest = c.Setter + "()"
+	} else if c.HandlerParam != "" {
+		dest = "param " + c.HandlerParam
+	}
+	return dest + ":" + c.Type
+}
+
+type routeEntry struct {
+	Service       string          `json:"service"`
+	HTTPMethod    string          `json:"http_method"`
+	Path          string          `json:"path"`
+	CanonicalPath string          `json:"canonical_path"`
+	RouteIdent    string          `json:"route_ident"`
+	RPCMethod     string          `json:"rpc_method,omitempty"`
+	Captures      []*captureEntry `json:"captures,omitempty"`
+}
+
+func newCaptureEntry(part *protocgenghe.URLPathPart, captureIndex int) *captureEntry {
+	c := &captureEntry{
+		Name:    part.ParamName(),
+		Pattern: part.PatternByteMapper.String(),
+	}
+	if c.Name == "" {
+		c.Name = fmt.Sprintf("#%d", captureIndex)
+	}
+	switch {
+	case part.DestFieldRef != nil:
+		c.Field = part.DestFieldName
+		if enumRef := part.DestFieldRef.DescRef.Enum; enumRef != nil {
+			c.Type = string(enumRef.Desc.FullName())
+		} else {
+			c.Type = part.DestFieldRef.GoType
+		}
+	case part.DestSetterFuncName != "":
+		c.Setter = part.DestSetterFuncName
+		c.Type = part.DestSetterArg0Type
+	default:
+		c.HandlerParam = part.DestHandlerParamName
+		c.Type = part.DestHandlerParamType
+	}
+	return c
+}
+
+func newRouteEntry(es *protocgenghe.EndpointService, ref *protocgenghe.EndpointURLPathMethod) *routeEntry {
+	r := &routeEntry{
+		Service:       string(es.DescRef.Desc.FullName()),
+		HTTPMethod:    ref.HTTPMethod,
+		Path:          "/" + string(ref.URLPath.RawPath),
+		CanonicalPath: ref.URLPath.CanonicalPath(),
+		RouteIdent:    ref.MethodRef.RouteIdentTail,
+	}
+	if ref.MethodRef.DescRef != nil {
+		r.RPCMethod = string(ref.MethodRef.DescRef.Desc.FullName())
+	}
+	for _, part := range ref.URLPath.Parts {
+		if part.PartType == protocgenghe.URLPathPartCapture {
+			r.Captures = append(r.Captures, newCaptureEntry(part, len(r.Captures)))
+		}
+	}
+	return r
+}
+
+type loadedService struct {
+	es            *protocgenghe.EndpointService
+	pathContainer *protocgenghe.EndpointPathContainer
+}
+
+// loadServices exports endpoint paths of services in files to be generated
+// the same way as the plugin does. Services which fail to export endpoint
+// paths are reported into errs.
+func loadServices(descriptorSetPath string, fileNames []string) (services []*loadedService, errs []error, err error) {
+	fds, err := protocgenghe.ReadFileDescriptorSet(descriptorSetPath)
+	if err != nil {
+		return
+	}
+	gen, err := protocgenghe.NewPluginWithFileDescriptorSet(fds, fileNames)
+	if err != nil {
+		return
+	}
+	for _, file := range gen.Files {
+		if !file.Generate {
+			continue
+		}
+		ef := protocgenghe.LoadEndpointFile(file)
+		for _, es := range ef.Services {
+			pathContainer := protocgenghe.NewEndpointPathContainer()
+			es.ExportEndpointPaths(pathContainer)
+			if err1 := pathContainer.Err(); err1 != nil {
+				errs = append(errs, fmt.Errorf("%s: service %s: %w", file.Desc.Path(), es.DescRef.GoName, err1))
+				continue
+			}
+			services = append(services, &loadedService{
+				es:            es,
+				pathContainer: pathContainer,
+			})
+		}
+	}
+	return
+}
+
+func collectRoutes(services []*loadedService) (routes []*routeEntry) {
+	for _, svc := range services {
+		for _, endpointPath := range svc.pathContainer.SortedEndpointPaths() {
+			for _, ref := range endpointPath.URLPathMethods() {
+				routes = append(routes, newRouteEntry(svc.es, ref))
+			}
+		}
+	}
+	return
+}
+
+func writeTable(w io.Writer, routes []*routeEntry) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "METHOD\tPATH\tCANONICAL PATH\tROUTE IDENT\tCAPTURES")
+	for _, r := range routes {
+		captureTexts := make([]string, len(r.Captures))
+		for idx, c := range r.Captures {
+			captureTexts[idx] = c.String()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", r.HTTPMethod, r.Path, r.CanonicalPath, r.RouteIdent, strings.Join(captureTexts, ", "))
+	}
+	return tw.Flush()
+}
+
+func writeJSON(w io.Writer, routes []*routeEntry) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if routes == nil {
+		routes = []*routeEntry{}
+	}
+	return enc.Encode(routes)
+}
+
+func runList(args []string) int {
+	flags := flag.NewFlagSet("list", flag.ExitOnError)
+	format := flags.String("format", "table", "output format: table or json")
+	var fileNames stringsFlag
+	flags.Var(&fileNames, "file", "proto file in descriptor set to list routes of (repeatable, default: all files)")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: ghe-routes list [flags] DESCRIPTOR_SET_FILE")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+	var writeRoutes func(io.Writer, []*routeEntry) error
+	switch *format {
+	case "table":
+		writeRoutes = writeTable
+	case "json":
+		writeRoutes = writeJSON
+	default:
+		fmt.Fprintln(os.Stderr, "ERROR: unknown format:", *format)
+		return 2
+	}
+	services, errs, err := loadServices(flags.Arg(0), fileNames)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ERROR: load descriptor set failed:", err)
+		return 1
+	}
+	for _, err := range errs {
+		fmt.Fprintln(os.Stderr, "ERROR:", err)
+	}
+	if err = writeRoutes(os.Stdout, collectRoutes(services)); err != nil {
+		fmt.Fprintln(os.Stderr, "ERROR: write routes failed:", err)
+		return 1
+	}
+	if len(errs) != 0 {
+		return 1
+	}
+	return 0
+}
+
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(v string) error {
+	*f = append(*f, v)
+	return nil
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: ghe-routes COMMAND [flags] DESCRIPTOR_SET_FILE [ARGS]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	fmt.Fprintln(os.Stderr, "  list    print routes of HTTP endpoints")
+	fmt.Fprintln(os.Stderr, "  match   find the route which serves given method and path")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Descriptor set is generated with `protoc --include_imports --descriptor_set_out=FILE`.")
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	switch os.Args[1] {
+	case "list":
+		os.Exit(runList(os.Args[2:]))
+	case "match":
+		os.Exit(runMatch(os.Args[2:]))
+	case "version", "-version", "--version":
+		fmt.Printf("ghe-routes %v\n", protocgenghe.CodeFullVersion)
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
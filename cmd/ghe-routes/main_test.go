@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/yinyin/protoc-gen-go-grpc-http-endpoint/internal/testfixture"
+)
+
+func writeFixtureDescriptorSet(t *testing.T) string {
+	fds := &descriptorpb.FileDescriptorSet{}
+	added := make(map[string]struct{})
+	var addFile func(fileDesc protoreflect.FileDescriptor)
+	addFile = func(fileDesc protoreflect.FileDescriptor) {
+		if _, ok := added[fileDesc.Path()]; ok {
+			return
+		}
+		added[fileDesc.Path()] = struct{}{}
+		imports := fileDesc.Imports()
+		for idx := 0; idx < imports.Len(); idx++ {
+			addFile(imports.Get(idx).FileDescriptor)
+		}
+		fds.File = append(fds.File, protodesc.ToFileDescriptorProto(fileDesc))
+	}
+	addFile(testfixture.File_internal_testfixture_route_proto)
+	buf, err := proto.Marshal(fds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	descriptorSetPath := filepath.Join(t.TempDir(), "fixture.pb")
+	if err = os.WriteFile(descriptorSetPath, buf, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return descriptorSetPath
+}
+
+func loadFixtureRoutes(t *testing.T) []*routeEntry {
+	services, errs, err := loadServices(writeFixtureDescriptorSet(t), []string{"internal/testfixture/route.proto"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, err := range errs {
+		t.Error(err)
+	}
+	return collectRoutes(services)
+}
+
+func TestWriteTable(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeTable(&buf, loadFixtureRoutes(t)); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if header := strings.Fields(lines[0]); !reflect.DeepEqual(header, []string{
+		"METHOD", "PATH", "CANONICAL", "PATH", "ROUTE", "IDENT", "CAPTURES"}) {
+		t.Errorf("unexpected header: %q", lines[0])
+	}
+	rows := make(map[string]string)
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		rows[fields[0]+" "+fields[1]] = line
+	}
+	tests := []struct {
+		route      string
+		canonical  string
+		routeIdent string
+		captures   string
+	}{
+		{"GET /fixture/items/latest", "fixture/items/latest", "RouteServiceGetLatestItem", ""},
+		{"DELETE /fixture/items/{id}", "fixture/items/{{capture: 0xFFFF7FFF00000000 0x7FFFFFFFFFFFFFFF}}", "RouteServiceDeleteItem", "id:string"},
+		{"GET /fixture/items/{id}/tags/{tag}",
+			"fixture/items/{{capture: 0xFFFF7FFF00000000 0x7FFFFFFFFFFFFFFF}}/tags/{{capture: 0xFFFF7FFF00000000 0x7FFFFFFFFFFFFFFF}}",
+			"RouteServiceGetItemTag", "id:string, tag:string"},
+	}
+	for _, tt := range tests {
+		line, ok := rows[tt.route]
+		if !ok {
+			t.Errorf("route %s not found in table", tt.route)
+			continue
+		}
+		cells := strings.Fields(line)
+		if got := strings.Join(cells[2:], " "); got != strings.TrimSpace(tt.canonical+" "+tt.routeIdent+" "+tt.captures) {
+			t.Errorf("%s: got %q", tt.route, got)
+		}
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	routes := loadFixtureRoutes(t)
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, routes); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []*routeEntry
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, routes) {
+		t.Errorf("decoded routes differ from listed routes:\n%s", buf.String())
+	}
+	var shelfBinding *routeEntry
+	for _, r := range decoded {
+		if r.RouteIdent == "RouteServiceGetShelfBinding1" {
+			shelfBinding = r
+		}
+	}
+	if shelfBinding == nil {
+		t.Fatal("route of additional binding not found")
+	}
+	want := &routeEntry{
+		Service:       "ghe.fixture.RouteService",
+		HTTPMethod:    "GET",
+		Path:          "/v2/{!-9;-~, name}/{!-.0-9;-~, tag}",
+		CanonicalPath: "v2/{{capture: 0xFBFFFFFE00000000 0x7FFFFFFFFFFFFFFF}}/{{capture: 0xFBFF7FFE00000000 0x7FFFFFFFFFFFFFFF}}",
+		RouteIdent:    "RouteServiceGetShelfBinding1",
+		RPCMethod:     "ghe.fixture.RouteService.GetShelf",
+		Captures: []*captureEntry{
+			{Name: "name", Pattern: "0xFBFFFFFE00000000 0x7FFFFFFFFFFFFFFF", Field: "name", Type: "string"},
+			{Name: "tag", Pattern: "0xFBFF7FFE00000000 0x7FFFFFFFFFFFFFFF", Field: "tag", Type: "string"},
+		},
+	}
+	if !reflect.DeepEqual(shelfBinding, want) {
+		got, _ := json.Marshal(shelfBinding)
+		t.Errorf("unexpected route entry: %s", got)
+	}
+}
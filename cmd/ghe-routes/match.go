@@ -0,0 +1,190 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	protocgenghe "github.com/yinyin/protoc-gen-go-grpc-http-endpoint"
+)
+
+type matchCapture struct {
+	Name    string `json:"name"`
+	Start   int    `json:"start"`
+	End     int    `json:"end"`
+	Value   string `json:"value"`
+	Pattern string `json:"pattern"`
+}
+
+type matchResult struct {
+	HTTPMethod string `json:"http_method"`
+	Path       string `json:"path"`
+	Matched    bool   `json:"matched"`
+
+	Service        string          `json:"service,omitempty"`
+	EndpointPath   string          `json:"endpoint_path,omitempty"`
+	Route          *routeEntry     `json:"route,omitempty"`
+	AllowedMethods []string        `json:"allowed_methods,omitempty"`
+	Captures       []*matchCapture `json:"captures,omitempty"`
+
+	NearestNode   string `json:"nearest_node,omitempty"`
+	MatchedPrefix string `json:"matched_prefix,omitempty"`
+	RemainingPath string `json:"remaining_path,omitempty"`
+}
+
+func newMatchResult(es *protocgenghe.EndpointService, t *protocgenghe.URLRouteTrace) *matchResult {
+	r := &matchResult{
+		HTTPMethod: t.Method,
+		Path:       "/" + string(t.Path),
+		Service:    string(es.DescRef.Desc.FullName()),
+	}
+	if t.EndpointPath == nil {
+		if t.NearestNode.Depth == 0 {
+			r.NearestNode = "(root)"
+		} else {
+			r.NearestNode = t.NearestNode.Part.CanonicalText()
+		}
+		if t.NearestNode.Leaf != nil {
+			r.NearestNode += " (leaf: " + t.NearestNode.Leaf.URLBarePath.CanonicalPath() + ")"
+		}
+		r.MatchedPrefix = string(t.Path[:t.NearestOffset])
+		r.RemainingPath = string(t.Path[t.NearestOffset:])
+		return r
+	}
+	r.EndpointPath = t.EndpointPath.URLBarePath.CanonicalPath()
+	for _, ref := range t.EndpointPath.URLPathMethods() {
+		r.AllowedMethods = append(r.AllowedMethods, ref.HTTPMethod)
+	}
+	if t.URLPathMethod != nil {
+		r.Matched = true
+		r.Route = newRouteEntry(es, t.URLPathMethod)
+	}
+	for idx, capture := range t.Captures {
+		c := &matchCapture{
+			Name:    "#" + strconv.FormatInt(int64(idx), 10),
+			Start:   capture.Start,
+			End:     capture.End,
+			Value:   string(t.Path[capture.Start:capture.End]),
+			Pattern: capture.Node.Part.PatternByteMapper.String(),
+		}
+		if capture.Part != nil {
+			if paramName := capture.Part.ParamName(); paramName != "" {
+				c.Name = paramName
+			}
+		}
+		r.Captures = append(r.Captures, c)
+	}
+	return r
+}
+
+func writeMatchText(w io.Writer, r *matchResult) error {
+	var b strings.Builder
+	switch {
+	case r.Matched:
+		fmt.Fprintf(&b, "matched:  %s %s\n", r.HTTPMethod, r.Path)
+		fmt.Fprintf(&b, "service:  %s\n", r.Service)
+		fmt.Fprintf(&b, "route:    %s %s (%s)\n", r.Route.HTTPMethod, r.Route.Path, r.Route.RouteIdent)
+	case r.EndpointPath != "":
+		fmt.Fprintf(&b, "method not allowed:  %s %s\n", r.HTTPMethod, r.Path)
+		fmt.Fprintf(&b, "service:  %s\n", r.Service)
+		fmt.Fprintf(&b, "allowed:  %s\n", strings.Join(r.AllowedMethods, ", "))
+	default:
+		fmt.Fprintf(&b, "not found:  %s %s\n", r.HTTPMethod, r.Path)
+		if r.Service != "" {
+			fmt.Fprintf(&b, "service:  %s\n", r.Service)
+			fmt.Fprintf(&b, "nearest:  %s\n", r.NearestNode)
+			fmt.Fprintf(&b, "matched prefix:  %q\n", r.MatchedPrefix)
+			fmt.Fprintf(&b, "remaining path:  %q\n", r.RemainingPath)
+		}
+	}
+	if r.EndpointPath != "" {
+		fmt.Fprintf(&b, "endpoint: %s\n", r.EndpointPath)
+	}
+	for _, c := range r.Captures {
+		fmt.Fprintf(&b, "capture:  %s [%d:%d] %q\n", c.Name, c.Start, c.End, c.Value)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func runMatch(args []string) int {
+	flags := flag.NewFlagSet("match", flag.ExitOnError)
+	format := flags.String("format", "text", "output format: text or json")
+	var fileNames stringsFlag
+	flags.Var(&fileNames, "file", "proto file in descriptor set to match routes of (repeatable, default: all files)")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: ghe-routes match [flags] DESCRIPTOR_SET_FILE METHOD PATH")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 3 {
+		flags.Usage()
+		return 2
+	}
+	switch *format {
+	case "text", "json":
+	default:
+		fmt.Fprintln(os.Stderr, "ERROR: unknown format:", *format)
+		return 2
+	}
+	method := strings.ToUpper(flags.Arg(1))
+	u, err := url.Parse(flags.Arg(2))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ERROR: invalid path:", err)
+		return 2
+	}
+	services, errs, err := loadServices(flags.Arg(0), fileNames)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ERROR: load descriptor set failed:", err)
+		return 1
+	}
+	for _, err := range errs {
+		fmt.Fprintln(os.Stderr, "ERROR:", err)
+	}
+	// Each service is served by its own handler. Report the service which
+	// has a leaf for the path, or the one walked deepest into the path.
+	var result *matchResult
+	nearestOffset := -1
+	for _, svc := range services {
+		routeRoot := protocgenghe.NewURLRouteRadixRoot()
+		if err = routeRoot.ImportEndpointPaths(svc.pathContainer.SortedEndpointPaths()); err != nil {
+			fmt.Fprintln(os.Stderr, "ERROR: service", svc.es.DescRef.GoName, ":", err)
+			continue
+		}
+		t := routeRoot.TraceRoute(method, []byte(u.EscapedPath()))
+		if t.EndpointPath != nil {
+			result = newMatchResult(svc.es, t)
+			break
+		}
+		if t.NearestOffset > nearestOffset {
+			result = newMatchResult(svc.es, t)
+			nearestOffset = t.NearestOffset
+		}
+	}
+	if result == nil {
+		result = &matchResult{
+			HTTPMethod: method,
+			Path:       u.EscapedPath(),
+		}
+	}
+	if *format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(result)
+	} else {
+		err = writeMatchText(os.Stdout, result)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ERROR: write result failed:", err)
+		return 1
+	}
+	if !result.Matched {
+		return 1
+	}
+	return 0
+}
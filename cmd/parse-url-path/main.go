@@ -13,6 +13,7 @@ type parseResult struct {
 	ParsedPath       *protocgenghe.URLPath
 	RawPathText      string
 	RawPathPartsText []string
+	ParamNames       []string
 }
 
 func main() {
@@ -30,6 +31,7 @@ func main() {
 			for _, part := range urlPath.Parts {
 				r.RawPathPartsText = append(r.RawPathPartsText, string(part.RawPathPart))
 			}
+			r.ParamNames = urlPath.ParamNames()
 		}
 		w.Encode(r)
 	}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	protocgenghe "github.com/yinyin/protoc-gen-go-grpc-http-endpoint"
+)
+
+func main() {
+	showVersion := flag.Bool("version", false, "print the version and exit")
+	flag.Parse()
+	if *showVersion {
+		fmt.Printf("protoc-gen-go-grpc-http-endpoint %v\n", protocgenghe.CodeFullVersion)
+		return
+	}
+
+	var flags flag.FlagSet
+	var genOpts protocgenghe.GenerateOptions
+	flags.BoolVar(&genOpts.Debug, "debug", false, "emit debug file with path traces")
+	flags.StringVar(&genOpts.HandlerMode, "handler_mode", protocgenghe.HandlerModeServer,
+		"invoke RPC methods with server implementation (server) or with client over gRPC connection (client)")
+	flags.BoolVar(&genOpts.OpenAPI, "openapi", false, "emit "+protocgenghe.OpenAPIFileName+" for each Go package")
+	protogen.Options{
+		ParamFunc: flags.Set,
+	}.Run(func(gen *protogen.Plugin) error {
+		gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+		for _, f := range gen.Files {
+			if !f.Generate {
+				continue
+			}
+			if _, err := protocgenghe.GenerateFile(gen, f, &genOpts); err != nil {
+				return err
+			}
+		}
+		if genOpts.OpenAPI {
+			return protocgenghe.GenerateOpenAPIFiles(gen)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,53 @@
+package protocgenghe
+
+import (
+	"os"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// ReadFileDescriptorSet loads file written by `protoc --descriptor_set_out`.
+// The set should be generated with `--include_imports` so all dependencies
+// can be resolved.
+func ReadFileDescriptorSet(filePath string) (*descriptorpb.FileDescriptorSet, error) {
+	buf, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	fds := &descriptorpb.FileDescriptorSet{}
+	if err = proto.Unmarshal(buf, fds); err != nil {
+		return nil, err
+	}
+	return fds, nil
+}
+
+// NewPluginWithFileDescriptorSet creates protogen.Plugin as if protoc invokes
+// the plugin with files in fds. Files named in fileNames are marked to be
+// generated, all files in fds are marked when fileNames is empty.
+// Files without `go_package` option are mapped to import path derived from
+// their names.
+func NewPluginWithFileDescriptorSet(fds *descriptorpb.FileDescriptorSet, fileNames []string) (*protogen.Plugin, error) {
+	req := &pluginpb.CodeGeneratorRequest{
+		ProtoFile: fds.File,
+	}
+	var params []string
+	for _, fileDesc := range fds.File {
+		if len(fileNames) == 0 {
+			req.FileToGenerate = append(req.FileToGenerate, fileDesc.GetName())
+		}
+		if fileDesc.GetOptions().GetGoPackage() == "" {
+			params = append(params, "M"+fileDesc.GetName()+"="+strings.TrimSuffix(fileDesc.GetName(), ".proto"))
+		}
+	}
+	if len(fileNames) != 0 {
+		req.FileToGenerate = fileNames
+	}
+	if len(params) != 0 {
+		req.Parameter = proto.String(strings.Join(params, ","))
+	}
+	return protogen.Options{}.New(req)
+}
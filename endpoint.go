@@ -6,8 +6,10 @@ import (
 	"net/http"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
+	"google.golang.org/genproto/googleapis/api/annotations"
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -26,6 +28,9 @@ type EndpointService struct {
 	Methods        []*EndpointMethod
 	ExtraEndpoints []*EndpointMethod
 
+	// JSONOptions merged from file and service options.
+	JSONOptions ghegen.GHEJSONOptions
+
 	DescRef *protogen.Service
 	Options ghegen.GHEServiceOptions
 }
@@ -73,16 +78,31 @@ func (es *EndpointService) mergeStrictPrefixMatchLenOption() {
 func (es *EndpointService) mergeExtraEndpointsOptions() {
 	for _, extraEndpointOpts := range es.Options.ExtraEndpoints {
 		em := NewEndpointMethodWithNormalizedOptions(extraEndpointOpts, es.RouteIdentMiddle)
+		em.ParentService = es
 		es.ExtraEndpoints = append(es.ExtraEndpoints, em)
 	}
 }
 
+// haveJSONOptions checks if any JSON option is enabled.
+func haveJSONOptions(opts *ghegen.GHEJSONOptions) bool {
+	return opts.GetUseProtoNames() || opts.GetEmitUnpopulated() || opts.GetUseEnumNumbers() || opts.GetDiscardUnknown()
+}
+
+// MergeJSONOptions merges JSON options of wider scope into the service.
+// Must be invoked before SetOptions.
+func (es *EndpointService) MergeJSONOptions(opts *ghegen.GHEJSONOptions) {
+	if opts != nil {
+		proto.Merge(&es.JSONOptions, opts)
+	}
+}
+
 func (es *EndpointService) SetOptions(optionsMessageRef protoreflect.ProtoMessage) {
 	proto.Merge(&es.Options, optionsMessageRef)
 	es.Options.NormalizeValues()
 	es.mergeURLPathOption()
 	es.mergeStrictPrefixMatchLenOption()
 	es.mergeExtraEndpointsOptions()
+	es.MergeJSONOptions(es.Options.Json)
 }
 
 func (es *EndpointService) ExportEndpointPaths(c *EndpointPathContainer) {
@@ -108,12 +128,43 @@ type EndpointMethod struct {
 
 	IsExtraEndpoint bool
 
+	// IsAbsoluteURLPath is set for paths translated from google.api.http
+	// rule which are not under URL path of parent service.
+	IsAbsoluteURLPath bool
+
+	// CaptureTemplates maps field paths to templates which captured
+	// values must match. See HTTPRuleMethodOptions.
+	CaptureTemplates map[string]string
+
 	ParentService *EndpointService
 
+	// JSONOptions merged from file, service and method options.
+	JSONOptions ghegen.GHEJSONOptions
+
 	DescRef *protogen.Method
 	Options ghegen.GHEMethodOptions
 
 	CachedInputFieldRef map[string]*CaptureDestFieldRef
+
+	// BodyFieldRef is the input field to be filled with request body.
+	// Nil when request body is not mapped or mapped to the whole input message.
+	BodyFieldRef *CaptureDestFieldRef
+
+	// ResponseBodyFieldRef is the output field to be serialized as response body.
+	// Nil when the whole output message is serialized.
+	ResponseBodyFieldRef *CaptureDestFieldRef
+
+	// ParamBindings are header bindings followed by cookie bindings.
+	ParamBindings []*RequestParamBinding
+
+	// FormFileBindings maps file parts of multipart form.
+	FormFileBindings []*RequestParamBinding
+
+	cachedQueryFieldNames []string
+
+	// httpRuleErr keeps error of translating google.api.http rule to be
+	// reported on exporting endpoint paths.
+	httpRuleErr error
 }
 
 func NewEndpointMethod(
@@ -121,7 +172,7 @@ func NewEndpointMethod(
 	pathNamingConv NamingConventionConverter,
 	parentService *EndpointService) *EndpointMethod {
 	routeIdentMiddle := parentService.RouteIdentMiddle
-	return &EndpointMethod{
+	em := &EndpointMethod{
 		RouteIdentSuffix:   descRef.GoName,
 		RouteIdentMiddle:   routeIdentMiddle,
 		RouteIdentTail:     routeIdentMiddle + descRef.GoName,
@@ -129,6 +180,8 @@ func NewEndpointMethod(
 		ParentService:      parentService,
 		DescRef:            descRef,
 	}
+	proto.Merge(&em.JSONOptions, &parentService.JSONOptions)
+	return em
 }
 
 func NewEndpointMethodWithNormalizedOptions(opts *ghegen.GHEMethodOptions, routeIdentMiddle string) *EndpointMethod {
@@ -210,9 +263,16 @@ func (em *EndpointMethod) mergeURLPathPartsOptions() {
 	}
 }
 
+func (em *EndpointMethod) mergeJSONOptions() {
+	if em.Options.Json != nil {
+		proto.Merge(&em.JSONOptions, em.Options.Json)
+	}
+}
+
 func (em *EndpointMethod) mergeOptions() {
 	em.mergeRouteIdentSuffixOption()
 	em.mergeURLPathPartsOptions()
+	em.mergeJSONOptions()
 }
 
 func (em *EndpointMethod) SetOptions(optionsMessageRef protoreflect.ProtoMessage) {
@@ -221,32 +281,158 @@ func (em *EndpointMethod) SetOptions(optionsMessageRef protoreflect.ProtoMessage
 	em.mergeOptions()
 }
 
+// SetHTTPRule applies google.api.http rule as method options.
+// Bindings which cannot be merged into one set of options are returned as
+// additional EndpointMethod instances identified with `Binding<N>` suffix.
+func (em *EndpointMethod) SetHTTPRule(rule *annotations.HttpRule, pathNamingConv NamingConventionConverter) (additionalMethods []*EndpointMethod) {
+	optsList, err := HTTPRuleToMethodOptions(rule)
+	if err != nil {
+		em.httpRuleErr = err
+		return
+	}
+	em.IsAbsoluteURLPath = true
+	em.CaptureTemplates = optsList[0].CaptureTemplates
+	em.SetOptions(optsList[0].Options)
+	for idx, ruleOpts := range optsList[1:] {
+		ruleOpts.Options.Ident = em.DescRef.GoName + "Binding" + strconv.FormatInt(int64(idx+1), 10)
+		bindingMethod := NewEndpointMethod(em.DescRef, pathNamingConv, em.ParentService)
+		bindingMethod.IsAbsoluteURLPath = true
+		bindingMethod.CaptureTemplates = ruleOpts.CaptureTemplates
+		bindingMethod.SetOptions(ruleOpts.Options)
+		additionalMethods = append(additionalMethods, bindingMethod)
+	}
+	return
+}
+
+// unsupportedExtraEndpointOption returns name of the first option in opts
+// which is not supported for extra endpoint, or empty string if none.
+func unsupportedExtraEndpointOption(opts *ghegen.GHEMethodOptions) string {
+	switch {
+	case opts.Body != "":
+		return "body"
+	case opts.ResponseBody != "":
+		return "response_body"
+	case opts.BindQuery:
+		return "bind_query"
+	case opts.RejectUnknownQuery:
+		return "reject_unknown_query"
+	case opts.GoExtractHttpStatusCode != "":
+		return "go_extract_http_status_code"
+	case opts.SuccessStatus != 0:
+		return "success_status"
+	case len(opts.Encodings) != 0:
+		return "encodings"
+	case opts.Json != nil:
+		return "json"
+	case opts.AcceptForm:
+		return "accept_form"
+	case len(opts.FormFiles) != 0:
+		return "form_files"
+	case opts.MaxUploadSize != 0:
+		return "max_upload_size"
+	case opts.StreamFormat != "":
+		return "stream_format"
+	case opts.CapturesFirstMessageOnly:
+		return "captures_first_message_only"
+	}
+	return ""
+}
+
+// methodURLPath returns URL path of urlPathPart under serviceURLPath.
+// Absolute paths are returned as is.
+func (em *EndpointMethod) methodURLPath(serviceURLPath, urlPathPart string) string {
+	if em.IsAbsoluteURLPath {
+		return urlPathPart
+	}
+	return serviceURLPath + "/" + urlPathPart
+}
+
 func (em *EndpointMethod) exportEndpointPaths(c *EndpointPathContainer, serviceURLPath string) {
+	if em.httpRuleErr != nil {
+		c.AppendError("?", "?", em, "translate google.api.http rule failed: ", em.httpRuleErr)
+		return
+	}
+	if em.IsExtraEndpoint {
+		if em.Options.Ident == "" {
+			c.AppendError("?", "?", em, "ident is required for extra endpoint")
+			return
+		}
+		if em.Options.GoHandlerFunc == "" {
+			c.AppendError("?", "?", em, "GoHandlerFunc is required for extra endpoint: [", em.Options.Ident, "]")
+			return
+		}
+		if optName := unsupportedExtraEndpointOption(&em.Options); optName != "" {
+			c.AppendError("?", "?", em, optName, " option is not supported for extra endpoint: [", em.Options.Ident, "]")
+			return
+		}
+	}
+	for _, encoding := range em.Options.Encodings {
+		if _, ok := EncodingRuntimeNames[encoding]; !ok {
+			c.AppendError("?", "?", em, "unknown encoding: [", encoding, "]")
+			return
+		}
+	}
+	if _, ok := StreamFormatRuntimeNames[em.Options.StreamFormat]; (em.Options.StreamFormat != "") && !ok {
+		c.AppendError("?", "?", em, "unknown stream format: [", em.Options.StreamFormat, "]")
+		return
+	}
+	if em.Options.MaxUploadSize < 0 {
+		c.AppendError("?", "?", em, "max_upload_size must not be negative: [", em.Options.MaxUploadSize, "]")
+		return
+	}
+	if (em.Options.SuccessStatus != 0) && ((em.Options.SuccessStatus < 200) || (em.Options.SuccessStatus > 299)) {
+		c.AppendError("?", "?", em, "success_status must be 2xx: [", em.Options.SuccessStatus, "]")
+		return
+	}
+	if err := em.validateStreamingOptions(); err != nil {
+		c.AppendError("?", "?", em, "invalid streaming method options: ", err)
+		return
+	}
+	if err := em.resolveBodyFieldRef(); err != nil {
+		c.AppendError("?", "?", em, "resolve body field failed: ", err)
+		return
+	}
+	if err := em.resolveResponseBodyFieldRef(); err != nil {
+		c.AppendError("?", "?", em, "resolve response body field failed: ", err)
+		return
+	}
+	if err := em.resolveParamBindings(); err != nil {
+		c.AppendError("?", "?", em, "resolve header or cookie binding failed: ", err)
+		return
+	}
+	if em.Options.AcceptForm && (em.Options.Body == "") {
+		c.AppendError("?", "?", em, "accept_form requires body")
+		return
+	}
+	if err := em.resolveFormFileBindings(); err != nil {
+		c.AppendError("?", "?", em, "resolve form file binding failed: ", err)
+		return
+	}
 	exportedURLPaths := make(map[string]struct{})
 	var exportedGetURLPath string
 	if em.GetURLPathPart != "" {
-		methodURLPath := serviceURLPath + "/" + em.GetURLPathPart
+		methodURLPath := em.methodURLPath(serviceURLPath, em.GetURLPathPart)
 		c.AddEndpointPath(methodURLPath, http.MethodGet, em)
 		exportedURLPaths[methodURLPath] = struct{}{}
 		exportedGetURLPath = methodURLPath
 	}
 	if em.PostURLPathPart != "" {
-		methodURLPath := serviceURLPath + "/" + em.PostURLPathPart
+		methodURLPath := em.methodURLPath(serviceURLPath, em.PostURLPathPart)
 		c.AddEndpointPath(methodURLPath, http.MethodPost, em)
 		exportedURLPaths[methodURLPath] = struct{}{}
 	}
 	if em.PutURLPathPart != "" {
-		methodURLPath := serviceURLPath + "/" + em.PutURLPathPart
+		methodURLPath := em.methodURLPath(serviceURLPath, em.PutURLPathPart)
 		c.AddEndpointPath(methodURLPath, http.MethodPut, em)
 		exportedURLPaths[methodURLPath] = struct{}{}
 	}
 	if em.DeleteURLPathPart != "" {
-		methodURLPath := serviceURLPath + "/" + em.DeleteURLPathPart
+		methodURLPath := em.methodURLPath(serviceURLPath, em.DeleteURLPathPart)
 		c.AddEndpointPath(methodURLPath, http.MethodDelete, em)
 		exportedURLPaths[methodURLPath] = struct{}{}
 	}
 	if em.PatchURLPathPart != "" {
-		methodURLPath := serviceURLPath + "/" + em.PatchURLPathPart
+		methodURLPath := em.methodURLPath(serviceURLPath, em.PatchURLPathPart)
 		c.AddEndpointPath(methodURLPath, http.MethodPatch, em)
 		exportedURLPaths[methodURLPath] = struct{}{}
 	}
@@ -267,15 +453,12 @@ func (em *EndpointMethod) exportEndpointPaths(c *EndpointPathContainer, serviceU
 	}
 }
 
-func (em *EndpointMethod) FindInputFieldRef(fieldName string) (fieldRef *CaptureDestFieldRef, err error) {
-	if em.CachedInputFieldRef == nil {
-		em.CachedInputFieldRef = make(map[string]*CaptureDestFieldRef)
-	} else if fieldRef = em.CachedInputFieldRef[fieldName]; fieldRef != nil {
-		return
-	}
+// resolveFieldRef resolves dot separated fieldName against given message.
+func resolveFieldRef(message *protogen.Message, fieldName string) (fieldRef *CaptureDestFieldRef, err error) {
 	fieldPathNames := strings.Split(fieldName, ".")
 	goNameRef := make([]string, 0, len(fieldPathNames))
-	currentMessage := em.DescRef.Input
+	pathDescRefs := make([]*protogen.Field, 0, len(fieldPathNames))
+	currentMessage := message
 	for idx := 0; idx < (len(fieldPathNames) - 1); idx++ {
 		fieldN := fieldPathNames[idx]
 		fieldDescRef := FindFieldInMessageByName(currentMessage, fieldN)
@@ -290,6 +473,7 @@ func (em *EndpointMethod) FindInputFieldRef(fieldName string) (fieldRef *Capture
 			return
 		}
 		goNameRef = append(goNameRef, fieldDescRef.GoName)
+		pathDescRefs = append(pathDescRefs, fieldDescRef)
 		currentMessage = fieldDescRef.Message
 	}
 	fieldDescRef := FindFieldInMessageByName(currentMessage, fieldPathNames[len(fieldPathNames)-1])
@@ -299,27 +483,190 @@ func (em *EndpointMethod) FindInputFieldRef(fieldName string) (fieldRef *Capture
 		return
 	}
 	goNameRef = append(goNameRef, fieldDescRef.GoName)
+	pathDescRefs = append(pathDescRefs, fieldDescRef)
 	goType, isPresencePointer := fieldGoType(fieldDescRef)
 	fieldRef = &CaptureDestFieldRef{
 		GoNameRef:         goNameRef,
 		GoType:            goType,
 		IsPresencePointer: isPresencePointer,
 		DescRef:           fieldDescRef,
+		PathDescRefs:      pathDescRefs,
+	}
+	return
+}
+
+func (em *EndpointMethod) FindInputFieldRef(fieldName string) (fieldRef *CaptureDestFieldRef, err error) {
+	if em.CachedInputFieldRef == nil {
+		em.CachedInputFieldRef = make(map[string]*CaptureDestFieldRef)
+	} else if fieldRef = em.CachedInputFieldRef[fieldName]; fieldRef != nil {
+		return
+	}
+	if em.DescRef == nil {
+		err = fmt.Errorf("cannot resolve %s: input message not available", fieldName)
+		return
+	}
+	if fieldRef, err = resolveFieldRef(em.DescRef.Input, fieldName); err != nil {
+		return
 	}
 	em.CachedInputFieldRef[fieldName] = fieldRef
 	return
 }
 
+func (em *EndpointMethod) FindOutputFieldRef(fieldName string) (fieldRef *CaptureDestFieldRef, err error) {
+	if em.DescRef == nil {
+		err = fmt.Errorf("cannot resolve %s: output message not available", fieldName)
+		return
+	}
+	return resolveFieldRef(em.DescRef.Output, fieldName)
+}
+
+// collectQueryFieldNames appends paths of fields in message which can be
+// bound from query parameters. Messages already on the path are skipped to
+// stop recursion.
+func collectQueryFieldNames(result []string, message *protogen.Message, prefix string, visited map[*protogen.Message]struct{}) []string {
+	if _, ok := visited[message]; ok {
+		return result
+	}
+	visited[message] = struct{}{}
+	defer delete(visited, message)
+	for _, field := range message.Fields {
+		if field.Desc.IsMap() {
+			continue
+		}
+		fieldName := prefix + string(field.Desc.Name())
+		if field.Message == nil {
+			result = append(result, fieldName)
+			continue
+		}
+		if field.Desc.IsList() || ((field.Oneof != nil) && !field.Oneof.Desc.IsSynthetic()) {
+			continue
+		}
+		result = collectQueryFieldNames(result, field.Message, fieldName+".", visited)
+	}
+	return result
+}
+
+// QueryFieldNames returns paths of scalar fields of input message which can
+// be bound from query parameters.
+func (em *EndpointMethod) QueryFieldNames() []string {
+	if (em.cachedQueryFieldNames == nil) && (em.DescRef != nil) {
+		em.cachedQueryFieldNames = collectQueryFieldNames(
+			make([]string, 0, len(em.DescRef.Input.Fields)), em.DescRef.Input, "", make(map[*protogen.Message]struct{}))
+	}
+	return em.cachedQueryFieldNames
+}
+
+// HaveRequestBody checks if request body should be mapped into input message
+// for requests of given HTTP method.
+func (em *EndpointMethod) HaveRequestBody(method string) bool {
+	if em.Options.Body == "" {
+		return false
+	}
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return true
+	}
+	return false
+}
+
+// IsStreamingClient checks if the RPC method is client-streaming or
+// bidirectional streaming.
+func (em *EndpointMethod) IsStreamingClient() bool {
+	return (em.DescRef != nil) && em.DescRef.Desc.IsStreamingClient()
+}
+
+// IsStreamingServer checks if the RPC method is server-streaming or
+// bidirectional streaming.
+func (em *EndpointMethod) IsStreamingServer() bool {
+	return (em.DescRef != nil) && em.DescRef.Desc.IsStreamingServer()
+}
+
+func (em *EndpointMethod) validateStreamingOptions() error {
+	if (em.Options.StreamFormat != "") && (!em.IsStreamingServer() || em.IsStreamingClient()) {
+		return errors.New("stream_format requires server-streaming method")
+	}
+	if em.Options.CapturesFirstMessageOnly && !em.IsStreamingClient() {
+		return errors.New("captures_first_message_only requires client-streaming method")
+	}
+	if em.IsStreamingServer() && ((em.Options.ResponseBody != "") || (em.Options.GoExtractHttpStatusCode != "")) {
+		return errors.New("response_body and go_extract_http_status_code are not supported for server-streaming method")
+	}
+	if em.IsStreamingClient() && em.IsStreamingServer() {
+		if (em.Options.Body != "") || em.Options.AcceptForm || (em.Options.SuccessStatus != 0) {
+			return errors.New("body, accept_form and success_status are not supported for bidirectional streaming method")
+		}
+		if (em.PostURLPathPart != "") || (em.PutURLPathPart != "") || (em.DeleteURLPathPart != "") || (em.PatchURLPathPart != "") {
+			return errors.New("bidirectional streaming method must be exported with GET for WebSocket upgrade")
+		}
+	} else if em.IsStreamingClient() {
+		if em.Options.Body != "*" {
+			return errors.New("client-streaming method requires body: \"*\"")
+		}
+		if em.Options.AcceptForm {
+			return errors.New("accept_form is not supported for client-streaming method")
+		}
+		if (em.GetURLPathPart != "") || (em.DeleteURLPathPart != "") {
+			return errors.New("client-streaming method must be exported with POST, PUT or PATCH")
+		}
+	}
+	return nil
+}
+
+func (em *EndpointMethod) resolveBodyFieldRef() (err error) {
+	if (em.Options.Body == "") || (em.Options.Body == "*") {
+		return
+	}
+	fieldRef, err := em.FindInputFieldRef(em.Options.Body)
+	if err != nil {
+		return
+	}
+	if (fieldRef.DescRef.Message == nil) || fieldRef.DescRef.Desc.IsList() || fieldRef.DescRef.Desc.IsMap() {
+		return fmt.Errorf("body field %s must be a singular message field", em.Options.Body)
+	}
+	em.BodyFieldRef = fieldRef
+	return
+}
+
+func (em *EndpointMethod) resolveResponseBodyFieldRef() (err error) {
+	if em.Options.ResponseBody == "" {
+		return
+	}
+	fieldRef, err := em.FindOutputFieldRef(em.Options.ResponseBody)
+	if err != nil {
+		return
+	}
+	if (fieldRef.DescRef.Message == nil) || fieldRef.DescRef.Desc.IsList() || fieldRef.DescRef.Desc.IsMap() {
+		return fmt.Errorf("response body field %s must be a singular message field", em.Options.ResponseBody)
+	}
+	em.ResponseBodyFieldRef = fieldRef
+	return
+}
+
+// isFieldPathOverlapped checks if one of the field paths is identical to or
+// a part of the other.
+func isFieldPathOverlapped(fieldPath1, fieldPath2 string) bool {
+	return (fieldPath1 == fieldPath2) ||
+		strings.HasPrefix(fieldPath1, fieldPath2+".") ||
+		strings.HasPrefix(fieldPath2, fieldPath1+".")
+}
+
 type EndpointURLPathMethod struct {
-	URLPath   *URLPath
-	MethodRef *EndpointMethod
+	HTTPMethod string
+	URLPath    *URLPath
+	MethodRef  *EndpointMethod
 }
 
 func (m *EndpointURLPathMethod) String() string {
 	if m == nil {
 		return "<nil>"
 	}
-	return "[" + string(m.URLPath.RawPath) + "](" + m.MethodRef.RouteIdentTail + ")"
+	return "[" + string(m.URLPath.RawPath) + "](" + m.MethodRef.RouteIdentTail + "){" + strings.Join(m.URLPath.ParamNames(), ", ") + "}"
+}
+
+// QueryBindFieldNames returns paths of input fields which are bound from
+// query parameters when bind_query option is enabled.
+func (m *EndpointURLPathMethod) QueryBindFieldNames() []string {
+	return queryBindFieldNames(m)
 }
 
 type EndpointPath struct {
@@ -349,6 +696,46 @@ func (p *EndpointPath) String() string {
 		", options=" + p.OptionsRef.String() + "}"
 }
 
+// URLPathMethods returns defined method references in the order of
+// GET, POST, PUT, DELETE, PATCH, HEAD and OPTIONS.
+func (p *EndpointPath) URLPathMethods() (result []*EndpointURLPathMethod) {
+	for _, ref := range []*EndpointURLPathMethod{
+		p.GetRef, p.PostRef, p.PutRef, p.DeleteRef, p.PatchRef, p.HeadRef, p.OptionsRef} {
+		if ref != nil {
+			result = append(result, ref)
+		}
+	}
+	return
+}
+
+// AllowMethods returns HTTP methods of the path for Allow header of method
+// not allowed responses.
+func (p *EndpointPath) AllowMethods() string {
+	refs := p.URLPathMethods()
+	methods := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		methods = append(methods, ref.HTTPMethod)
+	}
+	return strings.Join(methods, ", ")
+}
+
+// MethodNotAllowedRouteIdent returns route identifier of method not allowed
+// responses of the path. Empty string is returned when HTTP methods of the
+// path are served by different RPC methods.
+func (p *EndpointPath) MethodNotAllowedRouteIdent() string {
+	refs := p.URLPathMethods()
+	if len(refs) == 0 {
+		return ""
+	}
+	routeIdent := refs[0].MethodRef.RouteIdentTail
+	for _, ref := range refs[1:] {
+		if ref.MethodRef.RouteIdentTail != routeIdent {
+			return ""
+		}
+	}
+	return routeIdent
+}
+
 type EndpointPathByURLBarePath []*EndpointPath
 
 func (a EndpointPathByURLBarePath) Len() int      { return len(a) }
@@ -366,6 +753,14 @@ type EndpointPathError struct {
 	MessageText string
 }
 
+func (e *EndpointPathError) Error() string {
+	var routeIdent string
+	if e.EndpointMethodRef != nil {
+		routeIdent = e.EndpointMethodRef.RouteIdentTail
+	}
+	return "[" + e.Method + "] " + e.URLPath + " (" + routeIdent + "): " + e.MessageText
+}
+
 type EndpointPathContainer struct {
 	Paths  map[string]*EndpointPath
 	Errors []*EndpointPathError
@@ -409,10 +804,18 @@ func (c *EndpointPathContainer) parseURLPathWithEndpointMethod(urlPath string, e
 				continue
 			}
 			pathPart.DestFieldRef = fieldRef
+			if (endpointMethodRef.BodyFieldRef != nil) && endpointMethodRef.HaveRequestBody(method) &&
+				isFieldPathOverlapped(pathPart.DestFieldName, endpointMethodRef.Options.Body) {
+				c.AppendError(urlPath, method, endpointMethodRef, "capture dest field overlaps with body field: [", pathPart.DestFieldName, "] and [", endpointMethodRef.Options.Body, "]")
+				err = errors.New("capture dest field overlaps with body field")
+				continue
+			}
 		}
 		if (pathPart.PartType == URLPathPartCapture) && pathPart.PatternByteMapper.Empty() {
 			var targetType string
-			if pathPart.DestFieldRef != nil {
+			if (pathPart.DestFieldRef != nil) && (pathPart.DestFieldRef.DescRef.Enum != nil) {
+				targetType = URLPartEnumTypeName
+			} else if pathPart.DestFieldRef != nil {
 				targetType = pathPart.DestFieldRef.GoType
 			} else if pathPart.DestSetterArg0Type != "" {
 				targetType = pathPart.DestSetterArg0Type
@@ -431,11 +834,48 @@ func (c *EndpointPathContainer) parseURLPathWithEndpointMethod(urlPath string, e
 			}
 		}
 	}
+	if err1 := checkParamNamesUnique(urlPathParsed, endpointMethodRef); err1 != nil {
+		c.AppendError(urlPath, method, endpointMethodRef, err1)
+		err = err1
+	}
 	return
 }
 
+// checkParamNamesUnique checks that parameter names of captures and
+// capture names of request parameter bindings are not duplicated within
+// the route.
+func checkParamNamesUnique(urlPath *URLPath, endpointMethodRef *EndpointMethod) error {
+	paramNames := make(map[string]struct{})
+	for _, pathPart := range urlPath.Parts {
+		if pathPart.PartType != URLPathPartCapture {
+			continue
+		}
+		paramName := pathPart.ParamName()
+		if paramName == "" {
+			continue
+		}
+		if _, ok := paramNames[paramName]; ok {
+			return errors.New("duplicated capture name: [" + paramName + "]")
+		}
+		paramNames[paramName] = struct{}{}
+	}
+	for _, bindings := range [][]*RequestParamBinding{endpointMethodRef.ParamBindings, endpointMethodRef.FormFileBindings} {
+		for _, binding := range bindings {
+			captureName := binding.Part.CaptureName
+			if captureName == "" {
+				continue
+			}
+			if _, ok := paramNames[captureName]; ok {
+				return errors.New("duplicated capture name: [" + captureName + "] of " + binding.Source.String() + " " + binding.Name)
+			}
+			paramNames[captureName] = struct{}{}
+		}
+	}
+	return nil
+}
+
 func (c *EndpointPathContainer) AddEndpointPath(urlPath, method string, endpointMethodRef *EndpointMethod) {
-	c.Traces = append(c.Traces, urlPath+"\t["+method+"]\t"+endpointMethodRef.DescRef.GoName)
+	c.Traces = append(c.Traces, urlPath+"\t["+method+"]\t"+endpointMethodRef.RouteIdentTail)
 	urlPathParsed, err := c.parseURLPathWithEndpointMethod(urlPath, endpointMethodRef, method)
 	if err != nil {
 		return
@@ -449,8 +889,9 @@ func (c *EndpointPathContainer) AddEndpointPath(urlPath, method string, endpoint
 		c.Paths[canonicalPath] = endpointPath
 	}
 	urlPathMethodRef := &EndpointURLPathMethod{
-		URLPath:   urlPathParsed,
-		MethodRef: endpointMethodRef,
+		HTTPMethod: method,
+		URLPath:    urlPathParsed,
+		MethodRef:  endpointMethodRef,
 	}
 	switch method {
 	case http.MethodGet:
@@ -477,6 +918,18 @@ func (c *EndpointPathContainer) AddEndpointPath(urlPath, method string, endpoint
 	c.cachedSortedPaths = nil
 }
 
+// Err returns joined errors collected in the container or nil if there is no error.
+func (c *EndpointPathContainer) Err() error {
+	if len(c.Errors) == 0 {
+		return nil
+	}
+	errs := make([]error, len(c.Errors))
+	for idx, e := range c.Errors {
+		errs[idx] = e
+	}
+	return errors.Join(errs...)
+}
+
 func (c *EndpointPathContainer) SortedEndpointPaths() []*EndpointPath {
 	if c.cachedSortedPaths != nil {
 		return c.cachedSortedPaths
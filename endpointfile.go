@@ -0,0 +1,122 @@
+package protocgenghe
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	nameconv "github.com/yinyin/go-convert-naming-convention"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/yinyin/protoc-gen-go-grpc-http-endpoint/ghegen"
+)
+
+type EndpointFile struct {
+	ProtoFilePath string
+	GoImportPath  protogen.GoImportPath
+
+	PathNamingConv NamingConventionConverter
+
+	Services []*EndpointService
+
+	DescRef *protogen.File
+	Options ghegen.GHEFileOptions
+}
+
+func NewEndpointFile(descRef *protogen.File) *EndpointFile {
+	return &EndpointFile{
+		ProtoFilePath:  descRef.Desc.Path(),
+		GoImportPath:   descRef.GoImportPath,
+		PathNamingConv: &NoopNamingConventionConverter{},
+		DescRef:        descRef,
+	}
+}
+
+func (ef *EndpointFile) mergePathNamingConventionOption() {
+	if ef.Options.PathNamingConvention == "" {
+		return
+	}
+	opts := nameconv.NewDefaultOptions()
+	if len(ef.Options.CommonInitialisms) != 0 {
+		opts.AddCommonInitialisms(ef.Options.CommonInitialisms...)
+	}
+	if len(ef.Options.NamingOverride) != 0 {
+		opts.SetExceptionRules(ef.Options.NamingOverride)
+	}
+	ef.PathNamingConv = NewNamingConventionConverter(ef.Options.PathNamingConvention, opts)
+}
+
+func (ef *EndpointFile) SetOptions(optionsMessageRef protoreflect.ProtoMessage) {
+	proto.Merge(&ef.Options, optionsMessageRef)
+	ef.Options.NormalizeValues()
+	ef.mergePathNamingConventionOption()
+}
+
+// HTTPStatusCodeOverride is an entry of HTTP status code override for gRPC
+// status code.
+type HTTPStatusCodeOverride struct {
+	Code           codes.Code
+	HTTPStatusCode int
+}
+
+// HTTPStatusCodeOverrides returns HTTP status code overrides in file options
+// ordered by gRPC code.
+func (ef *EndpointFile) HTTPStatusCodeOverrides() (result []*HTTPStatusCodeOverride, err error) {
+	for codeName, statusCode := range ef.Options.HttpStatusCodes {
+		var code codes.Code
+		if err = code.UnmarshalJSON([]byte(strconv.Quote(codeName))); err != nil {
+			return nil, fmt.Errorf("unknown gRPC code in http_status_codes: [%s]", codeName)
+		}
+		if (statusCode < 100) || (statusCode > 599) {
+			return nil, fmt.Errorf("invalid HTTP status code in http_status_codes: [%s: %d]", codeName, statusCode)
+		}
+		result = append(result, &HTTPStatusCodeOverride{
+			Code:           code,
+			HTTPStatusCode: int(statusCode),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Code < result[j].Code
+	})
+	return
+}
+
+// LoadServices creates EndpointService and EndpointMethod instances for
+// services defined in the file with GHE options applied.
+//
+// File options must be set before invoking this method.
+func (ef *EndpointFile) LoadServices() {
+	for _, serviceDesc := range ef.DescRef.Services {
+		es := NewEndpointService(ef.ProtoFilePath, ef.GoImportPath, serviceDesc, ef.PathNamingConv)
+		es.MergeJSONOptions(ef.Options.Json)
+		if opts := GetGHEServiceOptions(serviceDesc.Desc); opts != nil {
+			es.SetOptions(opts)
+		}
+		for _, methodDesc := range serviceDesc.Methods {
+			em := NewEndpointMethod(methodDesc, ef.PathNamingConv, es)
+			var bindingMethods []*EndpointMethod
+			if opts := GetGHEMethodOptions(methodDesc.Desc); opts != nil {
+				em.SetOptions(opts)
+			} else if rule := GetGoogleAPIHTTPRule(methodDesc.Desc); rule != nil {
+				bindingMethods = em.SetHTTPRule(rule, ef.PathNamingConv)
+			}
+			es.Methods = append(es.Methods, em)
+			es.Methods = append(es.Methods, bindingMethods...)
+		}
+		ef.Services = append(ef.Services, es)
+	}
+}
+
+// LoadEndpointFile creates EndpointFile with services from given file
+// the same way as the plugin does.
+func LoadEndpointFile(descRef *protogen.File) *EndpointFile {
+	ef := NewEndpointFile(descRef)
+	if opts := GetGHEFileOptions(descRef.Desc); opts != nil {
+		ef.SetOptions(opts)
+	}
+	ef.LoadServices()
+	return ef
+}
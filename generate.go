@@ -0,0 +1,412 @@
+package protocgenghe
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	httpPackage    = protogen.GoImportPath("net/http")
+	stringsPackage = protogen.GoImportPath("strings")
+
+	grpcPackage       = protogen.GoImportPath("google.golang.org/grpc")
+	grpcCodesPackage  = protogen.GoImportPath("google.golang.org/grpc/codes")
+	protojsonPackage  = protogen.GoImportPath("google.golang.org/protobuf/encoding/protojson")
+	gheRuntimePackage = protogen.GoImportPath("github.com/yinyin/protoc-gen-go-grpc-http-endpoint/ghert")
+)
+
+// Modes of generated handler for invoking RPC methods.
+const (
+	// Invoke methods of server interface implementation in-process.
+	HandlerModeServer = "server"
+
+	// Forward requests to remote gRPC server with client stub.
+	HandlerModeClient = "client"
+)
+
+// Values of error_encoding option.
+const (
+	ErrorEncodingStatusJSON  = "status_json"
+	ErrorEncodingProblemJSON = "problem_json"
+)
+
+// EncodingRuntimeNames maps values of encodings option to encoding
+// constants of runtime package.
+var EncodingRuntimeNames = map[string]string{
+	"json":      "EncodingJSON",
+	"protobuf":  "EncodingProtobuf",
+	"prototext": "EncodingProtoText",
+}
+
+// GeneratedFileNameSuffix is appended to GeneratedFilenamePrefix of proto file
+// to form the name of generated file.
+const GeneratedFileNameSuffix = "_ghe.pb.go"
+
+// GenerateOptions contains plugin parameters which affect generated code.
+type GenerateOptions struct {
+	// Emit debug file with path traces and route tree.
+	Debug bool
+
+	// HandlerMode is one of HandlerModeServer (default) or HandlerModeClient.
+	HandlerMode string
+
+	// Emit OpenAPI document of each Go package.
+	OpenAPI bool
+}
+
+func (genOpts *GenerateOptions) checkValues() error {
+	switch genOpts.HandlerMode {
+	case "":
+		genOpts.HandlerMode = HandlerModeServer
+	case HandlerModeServer, HandlerModeClient:
+	default:
+		return errors.New("unknown handler mode: [" + genOpts.HandlerMode + "]")
+	}
+	return nil
+}
+
+func handlerTypeName(es *EndpointService) string {
+	return es.DescRef.GoName + "HTTPEndpoint"
+}
+
+func errorWriterVarName(es *EndpointService) string {
+	return "_" + es.DescRef.GoName + "_HTTPErrorWriter"
+}
+
+func messageCodecVarName(em *EndpointMethod) string {
+	return "_" + em.ParentService.DescRef.GoName + "_" + em.RouteIdentSuffix + "_HTTPCodec"
+}
+
+func httpMethodTitle(method string) string {
+	if method == "" {
+		return ""
+	}
+	return method[:1] + strings.ToLower(method[1:])
+}
+
+type serviceHandlerGenerator struct {
+	gen     *protogen.Plugin
+	g       *protogen.GeneratedFile
+	genOpts *GenerateOptions
+
+	ef            *EndpointFile
+	es            *EndpointService
+	pathContainer *EndpointPathContainer
+	routeRoot     *URLRouteRadixNode
+
+	handlerFuncNames map[*EndpointURLPathMethod]string
+	usedFuncNames    map[string]struct{}
+
+	// RouteIdentTail of the handler function being generated.
+	currentRouteIdent string
+}
+
+func newServiceHandlerGenerator(gen *protogen.Plugin, genOpts *GenerateOptions, ef *EndpointFile, es *EndpointService) *serviceHandlerGenerator {
+	pathContainer := NewEndpointPathContainer()
+	es.ExportEndpointPaths(pathContainer)
+	return &serviceHandlerGenerator{
+		gen:              gen,
+		genOpts:          genOpts,
+		ef:               ef,
+		es:               es,
+		pathContainer:    pathContainer,
+		routeRoot:        NewURLRouteRadixRoot(),
+		handlerFuncNames: make(map[*EndpointURLPathMethod]string),
+		usedFuncNames:    make(map[string]struct{}),
+	}
+}
+
+func (sg *serviceHandlerGenerator) prepare() error {
+	if err := sg.pathContainer.Err(); err != nil {
+		return err
+	}
+	if err := sg.routeRoot.ImportEndpointPaths(sg.pathContainer.SortedEndpointPaths()); err != nil {
+		return err
+	}
+	for _, endpointPath := range sg.pathContainer.SortedEndpointPaths() {
+		for _, ref := range endpointPath.URLPathMethods() {
+			baseName := "serve" + ref.MethodRef.RouteIdentTail + "By" + httpMethodTitle(ref.HTTPMethod)
+			funcName := baseName
+			for idx := 2; ; idx++ {
+				if _, ok := sg.usedFuncNames[funcName]; !ok {
+					break
+				}
+				funcName = baseName + strconv.FormatInt(int64(idx), 10)
+			}
+			sg.usedFuncNames[funcName] = struct{}{}
+			sg.handlerFuncNames[ref] = funcName
+		}
+	}
+	return nil
+}
+
+func (sg *serviceHandlerGenerator) haveEndpoints() bool {
+	return len(sg.pathContainer.Paths) != 0
+}
+
+func (sg *serviceHandlerGenerator) genHandlerType() {
+	g := sg.g
+	typeName := handlerTypeName(sg.es)
+	g.P("// ", typeName, " serves HTTP endpoints of ", sg.es.DescRef.GoName, ".")
+	GenServiceComments(g, sg.es.DescRef)
+	if sg.es.DescRef.Desc.Options().(*descriptorpb.ServiceOptions).GetDeprecated() {
+		g.P("//")
+		g.P(DeprecationComment)
+	}
+	if sg.genOpts.HandlerMode == HandlerModeClient {
+		sg.genClientModeHandlerType(typeName)
+	} else {
+		sg.genServerModeHandlerType(typeName)
+	}
+}
+
+// haveBidiStreamEndpoints checks if any endpoint of the service is served
+// over WebSocket.
+func (sg *serviceHandlerGenerator) haveBidiStreamEndpoints() bool {
+	for _, endpointPath := range sg.pathContainer.SortedEndpointPaths() {
+		for _, ref := range endpointPath.URLPathMethods() {
+			switch em := ref.MethodRef; {
+			case (ref.HTTPMethod == http.MethodHead) || (ref.HTTPMethod == http.MethodOptions) || em.IsExtraEndpoint:
+			case em.IsStreamingClient() && em.IsStreamingServer():
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (sg *serviceHandlerGenerator) genCheckWebSocketOriginField() {
+	if !sg.haveBidiStreamEndpoints() {
+		return
+	}
+	g := sg.g
+	g.P()
+	g.P("// CheckWebSocketOrigin decides if WebSocket handshake of r is accepted.")
+	g.P("// ", gheRuntimePackage.Ident("CheckWebSocketOrigin"), " is used when nil.")
+	g.P("CheckWebSocketOrigin func(r *", httpPackage.Ident("Request"), ") bool")
+}
+
+func (sg *serviceHandlerGenerator) genServerModeHandlerType(typeName string) {
+	g := sg.g
+	serverIdent := sg.es.GoImportPath.Ident(sg.es.DescRef.GoName + "Server")
+	g.P("type ", typeName, " struct {")
+	g.P("srv ", serverIdent)
+	sg.genCheckWebSocketOriginField()
+	g.P("}")
+	g.P()
+	g.P("// New", typeName, " creates ", typeName, " which invokes methods of srv.")
+	g.P("func New", typeName, "(srv ", serverIdent, ") *", typeName, " {")
+	g.P("return &", typeName, "{")
+	g.P("srv: srv,")
+	g.P("}")
+	g.P("}")
+	g.P()
+}
+
+func (sg *serviceHandlerGenerator) genClientModeHandlerType(typeName string) {
+	g := sg.g
+	clientIdent := sg.es.GoImportPath.Ident(sg.es.DescRef.GoName + "Client")
+	newClientIdent := sg.es.GoImportPath.Ident("New" + sg.es.DescRef.GoName + "Client")
+	g.P("type ", typeName, " struct {")
+	g.P("client ", clientIdent)
+	sg.genCheckWebSocketOriginField()
+	g.P("}")
+	g.P()
+	g.P("// New", typeName, " creates ", typeName, " which forwards requests to gRPC server over cc.")
+	g.P("func New", typeName, "(cc ", grpcPackage.Ident("ClientConnInterface"), ") *", typeName, " {")
+	g.P("return &", typeName, "{")
+	g.P("client: ", newClientIdent, "(cc),")
+	g.P("}")
+	g.P("}")
+	g.P()
+}
+
+func (sg *serviceHandlerGenerator) genErrorWriter() error {
+	g := sg.g
+	statusCodeOverrides, err := sg.ef.HTTPStatusCodeOverrides()
+	if err != nil {
+		return err
+	}
+	errorEncoding := sg.es.Options.ErrorEncoding
+	if errorEncoding == "" {
+		errorEncoding = sg.ef.Options.ErrorEncoding
+	}
+	switch errorEncoding {
+	case "", ErrorEncodingStatusJSON:
+	case ErrorEncodingProblemJSON:
+	default:
+		return errors.New("unknown error encoding: [" + errorEncoding + "]")
+	}
+	varName := errorWriterVarName(sg.es)
+	g.P("// ", varName, " writes error responses of ", handlerTypeName(sg.es), ".")
+	g.P("var ", varName, " = &", gheRuntimePackage.Ident("ErrorWriter"), "{")
+	if errorEncoding == ErrorEncodingProblemJSON {
+		g.P("Encoding: ", gheRuntimePackage.Ident("ErrorEncodingProblemJSON"), ",")
+	}
+	if len(statusCodeOverrides) != 0 {
+		g.P("StatusCodes: map[", grpcCodesPackage.Ident("Code"), "]int{")
+		for _, override := range statusCodeOverrides {
+			g.P(grpcCodesPackage.Ident(override.Code.String()), ": ", override.HTTPStatusCode, ",")
+		}
+		g.P("},")
+	}
+	g.P("}")
+	g.P()
+	return nil
+}
+
+// genMessageCodecs generates message codecs for methods with JSON options.
+func (sg *serviceHandlerGenerator) genMessageCodecs() {
+	g := sg.g
+	generated := make(map[*EndpointMethod]struct{})
+	for _, endpointPath := range sg.pathContainer.SortedEndpointPaths() {
+		for _, ref := range endpointPath.URLPathMethods() {
+			em := ref.MethodRef
+			if _, ok := generated[em]; ok || em.IsExtraEndpoint || !haveJSONOptions(&em.JSONOptions) {
+				continue
+			}
+			generated[em] = struct{}{}
+			opts := &em.JSONOptions
+			varName := messageCodecVarName(em)
+			g.P("// ", varName, " marshals messages of ", em.DescRef.GoName, ".")
+			g.P("var ", varName, " = &", gheRuntimePackage.Ident("MessageCodec"), "{")
+			if opts.GetUseProtoNames() || opts.GetEmitUnpopulated() || opts.GetUseEnumNumbers() {
+				g.P("JSONMarshal: ", protojsonPackage.Ident("MarshalOptions"), "{")
+				if opts.GetUseProtoNames() {
+					g.P("UseProtoNames: true,")
+				}
+				if opts.GetEmitUnpopulated() {
+					g.P("EmitUnpopulated: true,")
+				}
+				if opts.GetUseEnumNumbers() {
+					g.P("UseEnumNumbers: true,")
+				}
+				g.P("},")
+			}
+			if opts.GetDiscardUnknown() {
+				g.P("JSONUnmarshal: ", protojsonPackage.Ident("UnmarshalOptions"), "{")
+				g.P("DiscardUnknown: true,")
+				g.P("},")
+			}
+			g.P("}")
+			g.P()
+		}
+	}
+}
+
+// messageCodecExpr returns expression of message codec for em.
+func (sg *serviceHandlerGenerator) messageCodecExpr(em *EndpointMethod) string {
+	if !haveJSONOptions(&em.JSONOptions) {
+		return sg.g.QualifiedGoIdent(gheRuntimePackage.Ident("DefaultMessageCodec"))
+	}
+	return messageCodecVarName(em)
+}
+
+// errorWriterMethod returns expression of the method of error writer.
+func (sg *serviceHandlerGenerator) errorWriterMethod(methodName string) string {
+	return errorWriterVarName(sg.es) + "." + methodName
+}
+
+// rpcInvokeTarget returns expression of the object to invoke RPC methods on.
+func (sg *serviceHandlerGenerator) rpcInvokeTarget() string {
+	if sg.genOpts.HandlerMode == HandlerModeClient {
+		return "hnd.client"
+	}
+	return "hnd.srv"
+}
+
+func (sg *serviceHandlerGenerator) genService(g *protogen.GeneratedFile) error {
+	sg.g = g
+	sg.genHandlerType()
+	if err := sg.genErrorWriter(); err != nil {
+		return fmt.Errorf("service %s: %w", sg.es.DescRef.GoName, err)
+	}
+	sg.genMessageCodecs()
+	sg.genServeHTTP()
+	for _, endpointPath := range sg.pathContainer.SortedEndpointPaths() {
+		for _, ref := range endpointPath.URLPathMethods() {
+			if err := sg.genHandlerFunc(ref); err != nil {
+				return fmt.Errorf("service %s: [%s] %s: %w", sg.es.DescRef.GoName, ref.HTTPMethod, string(ref.URLPath.RawPath), err)
+			}
+		}
+	}
+	return nil
+}
+
+func genFileHeader(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile) {
+	g.P("// Code generated by protoc-gen-go-grpc-http-endpoint. DO NOT EDIT.")
+	g.P("// versions:")
+	g.P("// - protoc-gen-go-grpc-http-endpoint v", CodeVersion)
+	g.P("// - protoc                           ", ProtocVersion(gen))
+	if file.Proto.GetOptions().GetDeprecated() {
+		g.P("// ", file.Desc.Path(), " is a deprecated file.")
+	} else {
+		g.P("// source: ", file.Desc.Path())
+	}
+	g.P()
+	GenLeadingComments(g, file.Desc.SourceLocations().ByPath(protoreflect.SourcePath{FileDescriptorProtoSyntaxFieldNumber}))
+	g.P("package ", file.GoPackageName)
+	g.P()
+}
+
+func genDebugTraces(debugFile *GeneratedDebugFile, serviceGenerators []*serviceHandlerGenerator) {
+	for _, sg := range serviceGenerators {
+		debugFile.P("# ", sg.es.DescRef.GoName, " (", sg.es.URLPath, ")")
+		for _, trace := range sg.pathContainer.Traces {
+			debugFile.P(trace)
+		}
+		for _, endpointPath := range sg.pathContainer.SortedEndpointPaths() {
+			debugFile.P(endpointPath.String())
+		}
+		dumpRouteNode(debugFile, sg.routeRoot, 0)
+		debugFile.P()
+	}
+}
+
+// GenerateFile generates a _ghe.pb.go file containing HTTP endpoint handlers
+// of services in given proto file.
+// Returns nil GeneratedFile if no HTTP endpoint is defined in the file.
+func GenerateFile(gen *protogen.Plugin, file *protogen.File, genOpts *GenerateOptions) (*protogen.GeneratedFile, error) {
+	if err := genOpts.checkValues(); err != nil {
+		return nil, err
+	}
+	ef := LoadEndpointFile(file)
+	var serviceGenerators []*serviceHandlerGenerator
+	var errs []error
+	for _, es := range ef.Services {
+		sg := newServiceHandlerGenerator(gen, genOpts, ef, es)
+		if err := sg.prepare(); err != nil {
+			errs = append(errs, fmt.Errorf("service %s: %w", es.DescRef.GoName, err))
+			continue
+		}
+		if !sg.haveEndpoints() {
+			continue
+		}
+		serviceGenerators = append(serviceGenerators, sg)
+	}
+	if genOpts.Debug {
+		debugFile := NewGeneratedDebugFile(gen, file.GeneratedFilenamePrefix+"_ghe_debug_", file.GoImportPath)
+		genDebugTraces(debugFile, serviceGenerators)
+	}
+	if len(errs) != 0 {
+		return nil, fmt.Errorf("%s: %w", file.Desc.Path(), errors.Join(errs...))
+	}
+	if len(serviceGenerators) == 0 {
+		return nil, nil
+	}
+	g := gen.NewGeneratedFile(file.GeneratedFilenamePrefix+GeneratedFileNameSuffix, file.GoImportPath)
+	genFileHeader(gen, file, g)
+	for _, sg := range serviceGenerators {
+		if err := sg.genService(g); err != nil {
+			return nil, fmt.Errorf("%s: %w", file.Desc.Path(), err)
+		}
+	}
+	return g, nil
+}
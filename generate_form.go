@@ -0,0 +1,85 @@
+package protocgenghe
+
+import (
+	"strconv"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+const ioPackage = protogen.GoImportPath("io")
+
+// formBindFieldNames returns paths of fields under body field to be bound
+// from form keys. Paths are relative to the input message.
+func formBindFieldNames(ref *EndpointURLPathMethod) []string {
+	em := ref.MethodRef
+	var fieldNames []string
+	for _, fieldName := range em.QueryFieldNames() {
+		if (em.Options.Body == "*") || isFieldPathOverlapped(fieldName, em.Options.Body) {
+			fieldNames = append(fieldNames, fieldName)
+		}
+	}
+	return excludeFieldNames(fieldNames, boundFieldNames(ref))
+}
+
+func (sg *serviceHandlerGenerator) genFormFileCase(binding *RequestParamBinding) error {
+	g := sg.g
+	part := binding.Part
+	g.P("case ", strconv.Quote(binding.Name), ":")
+	if part.DestFieldRef == nil {
+		setterArgs := ""
+		for _, arg := range part.DestSetterArgs {
+			setterArgs += ", " + arg
+		}
+		g.P("if err := ", part.DestSetterFuncName, "(in, part", setterArgs, "); err != nil {")
+		g.P("return ", gheRuntimePackage.Ident("NewDecodeError"), "(", strconv.Quote(binding.DisplayName()), ", \"\", err)")
+		g.P("}")
+		return nil
+	}
+	g.P("v, err := ", gheRuntimePackage.Ident("ReadFormFile"), "(part)")
+	g.P("if err != nil {")
+	g.P("return err")
+	g.P("}")
+	return sg.genFieldValueAssign("in", part.DestFieldRef, "v")
+}
+
+// genFormBody generates code to decode URL encoded or multipart form body
+// into input message `in`.
+func (sg *serviceHandlerGenerator) genFormBody(ref *EndpointURLPathMethod) error {
+	g := sg.g
+	em := ref.MethodRef
+	fieldNames := formBindFieldNames(ref)
+	formVar := "form"
+	if len(fieldNames) == 0 {
+		formVar = "_"
+	}
+	if len(em.FormFileBindings) == 0 {
+		g.P(formVar, ", err := ", gheRuntimePackage.Ident("ParseForm"), "(r, nil)")
+	} else {
+		g.P(formVar, ", err := ", gheRuntimePackage.Ident("ParseForm"), "(r, func(name string, part ", ioPackage.Ident("Reader"), ") error {")
+		g.P("switch name {")
+		for _, binding := range em.FormFileBindings {
+			if err := sg.genFormFileCase(binding); err != nil {
+				return err
+			}
+		}
+		g.P("}")
+		g.P("return nil")
+		g.P("})")
+	}
+	g.P("if err != nil {")
+	sg.genWriteError("err")
+	g.P("}")
+	if len(fieldNames) == 0 {
+		return nil
+	}
+	g.P("for key, values := range form {")
+	g.P("switch key {")
+	for _, fieldName := range fieldNames {
+		if err := sg.genValuesFieldCase(em, fieldName); err != nil {
+			return err
+		}
+	}
+	g.P("}")
+	g.P("}")
+	return nil
+}
@@ -0,0 +1,728 @@
+package protocgenghe
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	nameconv "github.com/yinyin/go-convert-naming-convention"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// enumDecodeFuncKey is the key of decode function of enum fields in
+// decode function name maps.
+const enumDecodeFuncKey = "(enum)"
+
+var captureDecodeFuncNames = map[string]string{
+	"bool":            "DecodeBool",
+	"int32":           "DecodeInt32",
+	"uint32":          "DecodeUint32",
+	"int64":           "DecodeInt64",
+	"uint64":          "DecodeUint64",
+	"float32":         "DecodeFloat32",
+	"float64":         "DecodeFloat64",
+	"string":          "DecodeString",
+	"[]byte":          "DecodeBytes",
+	enumDecodeFuncKey: "DecodeEnum",
+}
+
+// plainDecodeFuncNames is captureDecodeFuncNames for query, header and
+// cookie values which are already unescaped.
+var plainDecodeFuncNames = map[string]string{
+	"bool":            "DecodeBool",
+	"int32":           "DecodeInt32",
+	"uint32":          "DecodeUint32",
+	"int64":           "DecodeInt64",
+	"uint64":          "DecodeUint64",
+	"float32":         "DecodeFloat32",
+	"float64":         "DecodeFloat64",
+	"string":          "DecodeQueryString",
+	"[]byte":          "DecodeQueryBytes",
+	enumDecodeFuncKey: "DecodeQueryEnum",
+}
+
+func captureVarName(captureIndex int) string {
+	return "capture" + strconv.FormatInt(int64(captureIndex), 10)
+}
+
+func handlerParamVarName(paramIndex int) string {
+	return "param" + strconv.FormatInt(int64(paramIndex), 10)
+}
+
+// captureDisplayName returns name of capture part for diagnostic messages.
+func captureDisplayName(part *URLPathPart) string {
+	if paramName := part.ParamName(); paramName != "" {
+		return paramName
+	}
+	return part.DestSetterFuncName
+}
+
+// captureGoNameTail converts parameter name into upper camel case Go
+// identifier to be appended to variable name prefix.
+func captureGoNameTail(paramName string) string {
+	converted := nameconv.ToUpperCamelCase(strings.NewReplacer(".", "_", "-", "_").Replace(paramName), nil)
+	var b strings.Builder
+	for _, ch := range converted {
+		if ((ch >= 'a') && (ch <= 'z')) || ((ch >= 'A') && (ch <= 'Z')) || ((ch >= '0') && (ch <= '9')) {
+			b.WriteRune(ch)
+		}
+	}
+	result := b.String()
+	if (result != "") && (result[0] >= 'a') && (result[0] <= 'z') {
+		result = strings.ToUpper(result[:1]) + result[1:]
+	}
+	return result
+}
+
+// captureVarNames returns names of handler function parameters of captures
+// in ref. Names are derived from parameter names of captures and fall back
+// to index of capture.
+func captureVarNames(ref *EndpointURLPathMethod) (result []string) {
+	usedNames := make(map[string]struct{})
+	for _, part := range ref.URLPath.Parts {
+		if part.PartType != URLPathPartCapture {
+			continue
+		}
+		varName := captureVarName(len(result))
+		if nameTail := captureGoNameTail(part.ParamName()); nameTail != "" {
+			if _, ok := usedNames["capture"+nameTail]; !ok {
+				varName = "capture" + nameTail
+			}
+		}
+		usedNames[varName] = struct{}{}
+		result = append(result, varName)
+	}
+	return
+}
+
+// fieldElementGoType returns Go type of field or type of element if field is repeated.
+func fieldElementGoType(fieldRef *CaptureDestFieldRef) string {
+	if fieldRef.DescRef.Desc.IsList() {
+		return strings.TrimPrefix(fieldRef.GoType, "[]")
+	}
+	return fieldRef.GoType
+}
+
+func (sg *serviceHandlerGenerator) genCaptureParams(ref *EndpointURLPathMethod) string {
+	captureVars := captureVarNames(ref)
+	if len(captureVars) == 0 {
+		return ""
+	}
+	return strings.Join(captureVars, ", ") + " string"
+}
+
+// decodeValueExpr returns expression which decodes string valueExpr into
+// value of given Go type. Expression evaluates to (value, error).
+func (sg *serviceHandlerGenerator) decodeValueExpr(decodeFuncNames map[string]string, goType string, enumRef *protogen.Enum, valueExpr string) (string, error) {
+	g := sg.g
+	if enumRef != nil {
+		enumType := g.QualifiedGoIdent(enumRef.GoIdent)
+		return g.QualifiedGoIdent(gheRuntimePackage.Ident(decodeFuncNames[enumDecodeFuncKey])) + "[" + enumType + "](" +
+			valueExpr + ", " + enumType + "(0).Descriptor())", nil
+	}
+	funcName, ok := decodeFuncNames[goType]
+	if !ok {
+		return "", errors.New("unsupported value type: [" + goType + "]")
+	}
+	return g.QualifiedGoIdent(gheRuntimePackage.Ident(funcName)) + "(" + valueExpr + ")", nil
+}
+
+func (sg *serviceHandlerGenerator) fieldDecodeValueExpr(decodeFuncNames map[string]string, fieldRef *CaptureDestFieldRef, valueExpr string) (string, error) {
+	if fieldRef.DescRef.Desc.IsMap() || (fieldRef.DescRef.Message != nil) {
+		return "", errors.New("cannot assign value to non-scalar field: " + strings.Join(fieldRef.GoNameRef, "."))
+	}
+	return sg.decodeValueExpr(decodeFuncNames, fieldElementGoType(fieldRef), fieldRef.DescRef.Enum, valueExpr)
+}
+
+// genFieldPathAlloc generates code to allocate messages of the first
+// pathLen fields of fieldRef under message msgVar.
+// Returns expression of the last allocated message.
+func (sg *serviceHandlerGenerator) genFieldPathAlloc(msgVar string, fieldRef *CaptureDestFieldRef, pathLen int) (target string, err error) {
+	g := sg.g
+	target = msgVar
+	for _, fieldDescRef := range fieldRef.PathDescRefs[:pathLen] {
+		if (fieldDescRef.Oneof != nil) && !fieldDescRef.Oneof.Desc.IsSynthetic() {
+			return "", errors.New("oneof field is not supported in field path: " + strings.Join(fieldRef.GoNameRef, "."))
+		}
+		if fieldDescRef.Desc.IsList() || fieldDescRef.Desc.IsMap() {
+			return "", errors.New("repeated field is not supported in field path: " + strings.Join(fieldRef.GoNameRef, "."))
+		}
+		target += "." + fieldDescRef.GoName
+		g.P("if ", target, " == nil {")
+		g.P(target, " = new(", fieldDescRef.Message.GoIdent, ")")
+		g.P("}")
+	}
+	return
+}
+
+// genFieldValueAssign generates code to assign valueVar to the field
+// referenced by fieldRef of message msgVar. Intermediate messages are
+// allocated as needed.
+func (sg *serviceHandlerGenerator) genFieldValueAssign(msgVar string, fieldRef *CaptureDestFieldRef, valueVar string) error {
+	g := sg.g
+	lastIndex := len(fieldRef.PathDescRefs) - 1
+	target, err := sg.genFieldPathAlloc(msgVar, fieldRef, lastIndex)
+	if err != nil {
+		return err
+	}
+	fieldDescRef := fieldRef.PathDescRefs[lastIndex]
+	switch {
+	case fieldDescRef.Desc.IsList():
+		target += "." + fieldDescRef.GoName
+		g.P(target, " = append(", target, ", ", valueVar, ")")
+	case (fieldDescRef.Oneof != nil) && !fieldDescRef.Oneof.Desc.IsSynthetic():
+		g.P(target, ".", fieldDescRef.Oneof.GoName, " = &", fieldDescRef.GoIdent, "{", fieldDescRef.GoName, ": ", valueVar, "}")
+	case fieldRef.IsPresencePointer:
+		g.P(target, ".", fieldDescRef.GoName, " = &", valueVar)
+	default:
+		g.P(target, ".", fieldDescRef.GoName, " = ", valueVar)
+	}
+	return nil
+}
+
+// genWriteError generates code to write error of errExpr as response of
+// the route being generated and return.
+func (sg *serviceHandlerGenerator) genWriteError(errExpr string) {
+	g := sg.g
+	g.P(sg.errorWriterMethod("WriteRouteError"), "(w, r, ", strconv.Quote(sg.currentRouteIdent), ", ", errExpr, ")")
+	g.P("return")
+}
+
+func (sg *serviceHandlerGenerator) genWriteDecodeError(name, valueExpr string) {
+	g := sg.g
+	sg.genWriteError(g.QualifiedGoIdent(gheRuntimePackage.Ident("NewDecodeError")) + "(" + strconv.Quote(name) + ", " + valueExpr + ", err)")
+}
+
+func (sg *serviceHandlerGenerator) genCaptureToField(part *URLPathPart, captureVar string) error {
+	g := sg.g
+	decodeExpr, err := sg.fieldDecodeValueExpr(captureDecodeFuncNames, part.DestFieldRef, captureVar)
+	if err != nil {
+		return err
+	}
+	g.P("{")
+	g.P("v, err := ", decodeExpr)
+	g.P("if err != nil {")
+	sg.genWriteDecodeError(captureDisplayName(part), captureVar)
+	g.P("}")
+	if err = sg.genFieldValueAssign("in", part.DestFieldRef, "v"); err != nil {
+		return err
+	}
+	g.P("}")
+	return nil
+}
+
+func (sg *serviceHandlerGenerator) genCaptureToSetter(part *URLPathPart, captureVar string) error {
+	g := sg.g
+	decodeExpr, err := sg.decodeValueExpr(captureDecodeFuncNames, part.DestSetterArg0Type, nil, captureVar)
+	if err != nil {
+		return err
+	}
+	setterArgs := ""
+	for _, arg := range part.DestSetterArgs {
+		setterArgs += ", " + arg
+	}
+	g.P("{")
+	g.P("v, err := ", decodeExpr)
+	g.P("if err == nil {")
+	g.P("err = ", part.DestSetterFuncName, "(in, v", setterArgs, ")")
+	g.P("}")
+	g.P("if err != nil {")
+	sg.genWriteDecodeError(captureDisplayName(part), captureVar)
+	g.P("}")
+	g.P("}")
+	return nil
+}
+
+func (sg *serviceHandlerGenerator) genCaptureToHandlerParam(part *URLPathPart, captureVar, paramVar string) error {
+	g := sg.g
+	decodeExpr, err := sg.decodeValueExpr(captureDecodeFuncNames, part.DestHandlerParamType, nil, captureVar)
+	if err != nil {
+		return err
+	}
+	g.P(paramVar, ", err := ", decodeExpr)
+	g.P("if err != nil {")
+	sg.genWriteDecodeError(captureDisplayName(part), captureVar)
+	g.P("}")
+	return nil
+}
+
+// paramBindingValuesExpr returns expression of the values of request
+// parameter for binding. Expression evaluates to []string.
+func (sg *serviceHandlerGenerator) paramBindingValuesExpr(binding *RequestParamBinding) string {
+	funcName := "HeaderValues"
+	if binding.Source == RequestParamCookie {
+		funcName = "CookieValues"
+	}
+	return sg.g.QualifiedGoIdent(gheRuntimePackage.Ident(funcName)) + "(r, " + strconv.Quote(binding.Name) + ")"
+}
+
+func (sg *serviceHandlerGenerator) genParamBindingToField(binding *RequestParamBinding) error {
+	g := sg.g
+	fieldRef := binding.Part.DestFieldRef
+	decodeExpr, err := sg.fieldDecodeValueExpr(plainDecodeFuncNames, fieldRef, "value")
+	if err != nil {
+		return err
+	}
+	isList := fieldRef.DescRef.Desc.IsList()
+	if isList {
+		g.P("for _, value := range ", sg.paramBindingValuesExpr(binding), " {")
+	} else {
+		g.P("if values := ", sg.paramBindingValuesExpr(binding), "; len(values) != 0 {")
+		g.P("value := values[0]")
+	}
+	g.P("v, err := ", decodeExpr)
+	g.P("if err != nil {")
+	sg.genWriteDecodeError(binding.DisplayName(), "value")
+	g.P("}")
+	if err = sg.genFieldValueAssign("in", fieldRef, "v"); err != nil {
+		return err
+	}
+	g.P("}")
+	return nil
+}
+
+func (sg *serviceHandlerGenerator) genParamBindingToSetter(binding *RequestParamBinding) error {
+	g := sg.g
+	part := binding.Part
+	decodeExpr, err := sg.decodeValueExpr(plainDecodeFuncNames, part.DestSetterArg0Type, nil, "values[0]")
+	if err != nil {
+		return err
+	}
+	setterArgs := ""
+	for _, arg := range part.DestSetterArgs {
+		setterArgs += ", " + arg
+	}
+	g.P("if values := ", sg.paramBindingValuesExpr(binding), "; len(values) != 0 {")
+	g.P("v, err := ", decodeExpr)
+	g.P("if err == nil {")
+	g.P("err = ", part.DestSetterFuncName, "(in, v", setterArgs, ")")
+	g.P("}")
+	g.P("if err != nil {")
+	sg.genWriteDecodeError(binding.DisplayName(), "values[0]")
+	g.P("}")
+	g.P("}")
+	return nil
+}
+
+// genParamBindingToHandlerParam generates code to decode request parameter
+// into handler parameter. Zero value is used when the parameter is absent.
+func (sg *serviceHandlerGenerator) genParamBindingToHandlerParam(binding *RequestParamBinding, paramVar string) error {
+	g := sg.g
+	part := binding.Part
+	decodeExpr, err := sg.decodeValueExpr(plainDecodeFuncNames, part.DestHandlerParamType, nil, "values[0]")
+	if err != nil {
+		return err
+	}
+	g.P("var ", paramVar, " ", part.DestHandlerParamType)
+	g.P("if values := ", sg.paramBindingValuesExpr(binding), "; len(values) != 0 {")
+	g.P("v, err := ", decodeExpr)
+	g.P("if err != nil {")
+	sg.genWriteDecodeError(binding.DisplayName(), "values[0]")
+	g.P("}")
+	g.P(paramVar, " = v")
+	g.P("}")
+	return nil
+}
+
+// genInputParamBindings generates code to decode headers and cookies into
+// input message `in`.
+func (sg *serviceHandlerGenerator) genInputParamBindings(ref *EndpointURLPathMethod) (err error) {
+	for _, binding := range ref.MethodRef.ParamBindings {
+		switch {
+		case binding.Part.DestFieldRef != nil:
+			err = sg.genParamBindingToField(binding)
+		case binding.Part.DestSetterFuncName != "":
+			err = sg.genParamBindingToSetter(binding)
+		case binding.Part.DestHandlerParamName != "":
+			err = errors.New("handler parameter binding requires custom handler function: [" + binding.RawBinding + "]")
+		}
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// genCustomHandlerInvoke generates code to invoke custom handler function with
+// handler parameters decoded from captures. Custom handler function receives
+// no input message, so captures and bindings must go to handler parameters.
+func (sg *serviceHandlerGenerator) genCustomHandlerInvoke(ref *EndpointURLPathMethod, funcName string) error {
+	var paramArgs string
+	captureVars := captureVarNames(ref)
+	captureIndex := 0
+	paramIndex := 0
+	for _, part := range ref.URLPath.Parts {
+		if part.PartType != URLPathPartCapture {
+			continue
+		}
+		captureVar := captureVars[captureIndex]
+		captureIndex++
+		if part.DestHandlerParamName == "" {
+			return errors.New("only handler parameter capture is supported for custom handler function " + funcName + " (" + ref.HTTPMethod + "): [" + string(part.RawPathPart) + "]")
+		}
+		paramVar := handlerParamVarName(paramIndex)
+		paramIndex++
+		if err := sg.genCaptureToHandlerParam(part, captureVar, paramVar); err != nil {
+			return err
+		}
+		paramArgs += ", " + paramVar
+	}
+	for _, binding := range ref.MethodRef.ParamBindings {
+		if binding.Part.DestHandlerParamName == "" {
+			return errors.New("only handler parameter binding is supported for custom handler function " + funcName + " (" + ref.HTTPMethod + "): [" + binding.RawBinding + "]")
+		}
+		paramVar := handlerParamVarName(paramIndex)
+		paramIndex++
+		if err := sg.genParamBindingToHandlerParam(binding, paramVar); err != nil {
+			return err
+		}
+		paramArgs += ", " + paramVar
+	}
+	sg.g.P(funcName, "(w, r", paramArgs, ")")
+	return nil
+}
+
+// genInputCaptures generates code to decode captures into input message `in`.
+func (sg *serviceHandlerGenerator) genInputCaptures(ref *EndpointURLPathMethod) (err error) {
+	captureVars := captureVarNames(ref)
+	captureIndex := 0
+	for _, part := range ref.URLPath.Parts {
+		if part.PartType != URLPathPartCapture {
+			continue
+		}
+		captureVar := captureVars[captureIndex]
+		captureIndex++
+		switch {
+		case part.DestFieldRef != nil:
+			err = sg.genCaptureToField(part, captureVar)
+		case part.DestSetterFuncName != "":
+			err = sg.genCaptureToSetter(part, captureVar)
+		case part.DestHandlerParamName != "":
+			err = errors.New("handler parameter capture requires custom handler function: [" + string(part.RawPathPart) + "]")
+		}
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// genCaptureTemplateChecks generates code to respond route not found when
+// captured values do not match templates of google.api.http variables.
+func (sg *serviceHandlerGenerator) genCaptureTemplateChecks(ref *EndpointURLPathMethod) {
+	g := sg.g
+	em := ref.MethodRef
+	if len(em.CaptureTemplates) == 0 {
+		return
+	}
+	captureVars := captureVarNames(ref)
+	captureIndex := 0
+	for _, part := range ref.URLPath.Parts {
+		if part.PartType != URLPathPartCapture {
+			continue
+		}
+		captureVar := captureVars[captureIndex]
+		captureIndex++
+		tmpl, ok := em.CaptureTemplates[part.DestFieldName]
+		if !ok {
+			continue
+		}
+		g.P("if !", gheRuntimePackage.Ident("MatchPathTemplate"), "(", captureVar, ", ", strconv.Quote(tmpl), ") {")
+		g.P(sg.errorWriterMethod("WriteRouteNotFound"), "(w, r)")
+		g.P("return")
+		g.P("}")
+	}
+}
+
+// allowedEncodingsExpr returns expression of encodings allowed for
+// request and response bodies of em.
+func (sg *serviceHandlerGenerator) allowedEncodingsExpr(em *EndpointMethod) string {
+	if len(em.Options.Encodings) == 0 {
+		return sg.g.QualifiedGoIdent(gheRuntimePackage.Ident("AllEncodings"))
+	}
+	encodingExprs := make([]string, 0, len(em.Options.Encodings))
+	for _, encoding := range em.Options.Encodings {
+		encodingExprs = append(encodingExprs, sg.g.QualifiedGoIdent(gheRuntimePackage.Ident(EncodingRuntimeNames[encoding])))
+	}
+	return strings.Join(encodingExprs, " | ")
+}
+
+// genRequestBody generates code to decode request body into input
+// message `in` or the body field of it.
+func (sg *serviceHandlerGenerator) genRequestBody(ref *EndpointURLPathMethod) error {
+	g := sg.g
+	em := ref.MethodRef
+	if !em.HaveRequestBody(ref.HTTPMethod) {
+		return nil
+	}
+	if em.Options.AcceptForm {
+		g.P("if ", gheRuntimePackage.Ident("IsFormRequest"), "(r) {")
+		if err := sg.genFormBody(ref); err != nil {
+			return err
+		}
+		g.P("} else {")
+	}
+	target := "in"
+	if em.BodyFieldRef != nil {
+		var err error
+		if target, err = sg.genFieldPathAlloc("in", em.BodyFieldRef, len(em.BodyFieldRef.PathDescRefs)); err != nil {
+			return err
+		}
+	}
+	g.P("if err := ", sg.messageCodecExpr(em), ".DecodeRequest(r, ", sg.allowedEncodingsExpr(em), ", ", target, "); err != nil {")
+	sg.genWriteError("err")
+	g.P("}")
+	if em.Options.AcceptForm {
+		g.P("}")
+	}
+	return nil
+}
+
+// boundFieldNames returns paths of fields captured from URL path or bound
+// from header, cookie or form file.
+func boundFieldNames(ref *EndpointURLPathMethod) (result []string) {
+	for _, part := range ref.URLPath.Parts {
+		if part.DestFieldName != "" {
+			result = append(result, part.DestFieldName)
+		}
+	}
+	for _, binding := range ref.MethodRef.ParamBindings {
+		if binding.Part.DestFieldName != "" {
+			result = append(result, binding.Part.DestFieldName)
+		}
+	}
+	for _, binding := range ref.MethodRef.FormFileBindings {
+		if binding.Part.DestFieldName != "" {
+			result = append(result, binding.Part.DestFieldName)
+		}
+	}
+	return
+}
+
+// excludeFieldNames returns fieldNames which do not overlap with any of
+// excludedFieldNames.
+func excludeFieldNames(fieldNames, excludedFieldNames []string) (result []string) {
+	for _, fieldName := range fieldNames {
+		excluded := false
+		for _, excludedFieldName := range excludedFieldNames {
+			if isFieldPathOverlapped(fieldName, excludedFieldName) {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			result = append(result, fieldName)
+		}
+	}
+	return
+}
+
+// queryBindFieldNames returns paths of fields to be bound from query
+// parameters. Fields captured from URL path, bound from header or cookie,
+// or mapped from request body are excluded.
+func queryBindFieldNames(ref *EndpointURLPathMethod) []string {
+	em := ref.MethodRef
+	excludedFieldNames := boundFieldNames(ref)
+	if em.HaveRequestBody(ref.HTTPMethod) {
+		if em.BodyFieldRef == nil {
+			return nil
+		}
+		excludedFieldNames = append(excludedFieldNames, em.Options.Body)
+	}
+	return excludeFieldNames(em.QueryFieldNames(), excludedFieldNames)
+}
+
+// genValuesFieldCase generates switch case which decodes `values` of
+// query parameter or form key `key` into field of input message `in`.
+func (sg *serviceHandlerGenerator) genValuesFieldCase(em *EndpointMethod, fieldName string) error {
+	g := sg.g
+	fieldRef, err := em.FindInputFieldRef(fieldName)
+	if err != nil {
+		return err
+	}
+	decodeExpr, err := sg.fieldDecodeValueExpr(plainDecodeFuncNames, fieldRef, "value")
+	if err != nil {
+		return err
+	}
+	g.P("case ", strconv.Quote(fieldName), ":")
+	isList := fieldRef.DescRef.Desc.IsList()
+	if isList {
+		g.P("for _, value := range values {")
+	} else {
+		g.P("if len(values) > 1 {")
+		sg.genWriteError(g.QualifiedGoIdent(gheRuntimePackage.Ident("NewDecodeError")) + "(key, values[1], " +
+			g.QualifiedGoIdent(gheRuntimePackage.Ident("ErrMultipleQueryValues")) + ")")
+		g.P("}")
+		g.P("value := values[0]")
+	}
+	g.P("v, err := ", decodeExpr)
+	g.P("if err != nil {")
+	sg.genWriteDecodeError(fieldName, "value")
+	g.P("}")
+	if err = sg.genFieldValueAssign("in", fieldRef, "v"); err != nil {
+		return err
+	}
+	if isList {
+		g.P("}")
+	}
+	return nil
+}
+
+// genQueryBinding generates code to decode query parameters into input
+// message `in`.
+func (sg *serviceHandlerGenerator) genQueryBinding(ref *EndpointURLPathMethod) error {
+	g := sg.g
+	em := ref.MethodRef
+	if !em.Options.BindQuery {
+		return nil
+	}
+	fieldNames := queryBindFieldNames(ref)
+	if (len(fieldNames) == 0) && !em.Options.RejectUnknownQuery {
+		return nil
+	}
+	g.P("{")
+	g.P("query, err := ", gheRuntimePackage.Ident("ParseQuery"), "(r)")
+	g.P("if err != nil {")
+	sg.genWriteError("err")
+	g.P("}")
+	g.P("for key, values := range query {")
+	g.P("switch key {")
+	for _, fieldName := range fieldNames {
+		if err := sg.genValuesFieldCase(em, fieldName); err != nil {
+			return err
+		}
+	}
+	if em.Options.RejectUnknownQuery {
+		g.P("default:")
+		sg.genWriteError(g.QualifiedGoIdent(gheRuntimePackage.Ident("NewDecodeError")) + "(key, values[0], " +
+			g.QualifiedGoIdent(gheRuntimePackage.Ident("ErrUnknownQueryParameter")) + ")")
+	}
+	g.P("}")
+	g.P("}")
+	g.P("}")
+	return nil
+}
+
+// responseBodyExpr returns expression of the message to be serialized as
+// response body. Getters are used so nil messages on the path are tolerated.
+func responseBodyExpr(em *EndpointMethod) string {
+	if em.ResponseBodyFieldRef == nil {
+		return "out"
+	}
+	expr := "out"
+	for _, goName := range em.ResponseBodyFieldRef.GoNameRef {
+		expr += ".Get" + goName + "()"
+	}
+	return expr
+}
+
+// genInputMessage generates code to allocate input message `in` and fill
+// it from request body, query, headers, cookies and captures.
+func (sg *serviceHandlerGenerator) genInputMessage(ref *EndpointURLPathMethod) error {
+	g := sg.g
+	em := ref.MethodRef
+	g.P("in := new(", em.DescRef.Input.GoIdent, ")")
+	if !em.IsStreamingClient() {
+		if err := sg.genRequestBody(ref); err != nil {
+			return err
+		}
+	}
+	if err := sg.genQueryBinding(ref); err != nil {
+		return err
+	}
+	if err := sg.genInputParamBindings(ref); err != nil {
+		return err
+	}
+	return sg.genInputCaptures(ref)
+}
+
+// successStatusExpr returns expression of HTTP status code for successful
+// response without status extraction.
+func (sg *serviceHandlerGenerator) successStatusExpr(em *EndpointMethod) string {
+	if em.Options.SuccessStatus != 0 {
+		return strconv.FormatInt(int64(em.Options.SuccessStatus), 10)
+	}
+	return sg.g.QualifiedGoIdent(httpPackage.Ident("StatusOK"))
+}
+
+func (sg *serviceHandlerGenerator) genMaxUploadSize(ref *EndpointURLPathMethod) {
+	em := ref.MethodRef
+	if (em.Options.MaxUploadSize > 0) && em.HaveRequestBody(ref.HTTPMethod) {
+		sg.g.P("r.Body = ", httpPackage.Ident("MaxBytesReader"), "(w, r.Body, ", em.Options.MaxUploadSize, ")")
+	}
+}
+
+func (sg *serviceHandlerGenerator) genRPCInvoke(ref *EndpointURLPathMethod) error {
+	g := sg.g
+	em := ref.MethodRef
+	sg.genCaptureTemplateChecks(ref)
+	if em.IsStreamingClient() && em.IsStreamingServer() {
+		return sg.genBidiStreamInvoke(ref)
+	}
+	if em.IsStreamingClient() {
+		return sg.genClientStreamInvoke(ref)
+	}
+	if em.IsStreamingServer() {
+		return sg.genServerStreamInvoke(ref)
+	}
+	sg.genMaxUploadSize(ref)
+	g.P("respEncoding, err := ", gheRuntimePackage.Ident("NegotiateEncoding"), "(r, ", sg.allowedEncodingsExpr(em), ")")
+	g.P("if err != nil {")
+	sg.genWriteError("err")
+	g.P("}")
+	if err := sg.genInputMessage(ref); err != nil {
+		return err
+	}
+	g.P("out, err := ", sg.rpcInvokeTarget(), ".", em.DescRef.GoName, "(r.Context(), in)")
+	g.P("if err != nil {")
+	sg.genWriteError("err")
+	g.P("}")
+	sg.genWriteResponse(em)
+	return nil
+}
+
+// genWriteResponse generates code to write output message `out` in
+// negotiated encoding `respEncoding`.
+func (sg *serviceHandlerGenerator) genWriteResponse(em *EndpointMethod) {
+	g := sg.g
+	statusCodeExpr := sg.successStatusExpr(em)
+	if em.Options.GoExtractHttpStatusCode != "" {
+		g.P("statusCode := ", em.Options.GoExtractHttpStatusCode, "(out)")
+		g.P("if statusCode == 0 {")
+		g.P("statusCode = ", statusCodeExpr)
+		g.P("}")
+		statusCodeExpr = "statusCode"
+	}
+	g.P(sg.messageCodecExpr(em), ".WriteResponse(w, r, ", errorWriterVarName(sg.es), ", ", strconv.Quote(sg.currentRouteIdent), ", respEncoding, ", statusCodeExpr, ", ", responseBodyExpr(em), ")")
+}
+
+func (sg *serviceHandlerGenerator) genHandlerFunc(ref *EndpointURLPathMethod) (err error) {
+	g := sg.g
+	em := ref.MethodRef
+	funcName := sg.handlerFuncNames[ref]
+	sg.currentRouteIdent = em.RouteIdentTail
+	g.P("// ", funcName, " handles ", ref.HTTPMethod, " request on `", string(ref.URLPath.RawPath), "`.")
+	funcParams := "w " + g.QualifiedGoIdent(httpPackage.Ident("ResponseWriter")) +
+		", r *" + g.QualifiedGoIdent(httpPackage.Ident("Request"))
+	if captureParams := sg.genCaptureParams(ref); captureParams != "" {
+		funcParams += ", " + captureParams
+	}
+	g.P("func (hnd *", handlerTypeName(sg.es), ") ", funcName, "(", funcParams, ") {")
+	switch {
+	case ref.HTTPMethod == http.MethodHead:
+		err = sg.genCustomHandlerInvoke(ref, em.Options.GoHeadHandlerFunc)
+	case ref.HTTPMethod == http.MethodOptions:
+		err = sg.genCustomHandlerInvoke(ref, em.Options.GoOptionsHandlerFunc)
+	case em.IsExtraEndpoint:
+		err = sg.genCustomHandlerInvoke(ref, em.Options.GoHandlerFunc)
+	default:
+		err = sg.genRPCInvoke(ref)
+	}
+	if err != nil {
+		return
+	}
+	g.P("}")
+	g.P()
+	return
+}
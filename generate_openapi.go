@@ -0,0 +1,688 @@
+package protocgenghe
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// OpenAPIFileName is the name of OpenAPI document generated into the
+// directory of each Go package.
+const OpenAPIFileName = "openapi.yaml"
+
+const openAPIVersion = "3.1.0"
+
+// Media types of message encodings in OpenAPI document. Must be in sync
+// with runtime package.
+var encodingMediaTypes = map[string]string{
+	"json":      "application/json",
+	"protobuf":  "application/x-protobuf",
+	"prototext": "application/x-prototext",
+}
+
+const (
+	mediaTypeEventStream    = "text/event-stream"
+	mediaTypeNDJSON         = "application/x-ndjson"
+	mediaTypeProblemJSON    = "application/problem+json"
+	mediaTypeFormURLEncoded = "application/x-www-form-urlencoded"
+	mediaTypeMultipartForm  = "multipart/form-data"
+)
+
+const (
+	openAPIStatusSchemaName  = "google.rpc.Status"
+	openAPIProblemSchemaName = "ghe.ProblemDocument"
+)
+
+// openAPISchemaStyle carries JSON options which change the shape of
+// messages in JSON.
+type openAPISchemaStyle struct {
+	useProtoNames  bool
+	useEnumNumbers bool
+}
+
+func (style openAPISchemaStyle) nameSuffix() (suffix string) {
+	if style.useProtoNames {
+		suffix += "_ProtoNames"
+	}
+	if style.useEnumNumbers {
+		suffix += "_EnumNumbers"
+	}
+	return
+}
+
+// commentText converts proto comments into plain text.
+func commentText(c protogen.Comments) string {
+	lines := strings.Split(strings.TrimRight(string(c), "\n"), "\n")
+	for idx, line := range lines {
+		lines[idx] = strings.TrimRight(strings.TrimPrefix(line, " "), " \t")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func fieldCommentText(field *protogen.Field) string {
+	if field.Comments.Leading != "" {
+		return commentText(field.Comments.Leading)
+	}
+	return commentText(field.Comments.Trailing)
+}
+
+func schemaRef(name string) *yamlMap {
+	return newYAMLMap().Set("$ref", "#/components/schemas/"+name)
+}
+
+func typeSchema(typeName, format string) *yamlMap {
+	return newYAMLMap().Set("type", typeName).SetNonEmpty("format", format)
+}
+
+// openAPIParamName returns name of path parameter for capture part.
+func openAPIParamName(part *URLPathPart, captureIndex int) string {
+	if paramName := part.ParamName(); paramName != "" {
+		return paramName
+	}
+	return "arg" + strconv.FormatInt(int64(captureIndex), 10)
+}
+
+// openAPIDocument collects paths and schemas of one Go package.
+type openAPIDocument struct {
+	fileName string
+
+	goImportPath protogen.GoImportPath
+	protoFiles   []string
+
+	tags  []any
+	paths *yamlMap
+
+	schemas      map[string]*yamlMap
+	operationIDs map[string]struct{}
+}
+
+func newOpenAPIDocument(fileName string, goImportPath protogen.GoImportPath) *openAPIDocument {
+	return &openAPIDocument{
+		fileName:     fileName,
+		goImportPath: goImportPath,
+		paths:        newYAMLMap(),
+		schemas:      make(map[string]*yamlMap),
+		operationIDs: make(map[string]struct{}),
+	}
+}
+
+// paramSchema returns schema of value decoded from string of given Go type.
+func (doc *openAPIDocument) paramSchema(goType string, enumRef *protogen.Enum) *yamlMap {
+	if strings.HasPrefix(goType, "[]") && (goType != "[]byte") {
+		return newYAMLMap().Set("type", "array").Set("items", doc.paramSchema(goType[2:], enumRef))
+	}
+	if enumRef != nil {
+		return schemaRef(doc.enumSchemaName(enumRef, openAPISchemaStyle{}))
+	}
+	switch goType {
+	case "bool":
+		return typeSchema("boolean", "")
+	case "int32", "int64":
+		return typeSchema("integer", goType)
+	case "uint32":
+		return typeSchema("integer", "int64").Set("minimum", 0)
+	case "uint64":
+		return typeSchema("integer", "").Set("minimum", 0)
+	case "float32":
+		return typeSchema("number", "float")
+	case "float64":
+		return typeSchema("number", "double")
+	case "[]byte":
+		return typeSchema("string", "").Set("contentEncoding", "base64")
+	}
+	return typeSchema("string", "")
+}
+
+// partParamSchema returns schema of value of capture part.
+func (doc *openAPIDocument) partParamSchema(part *URLPathPart) *yamlMap {
+	if fieldRef := part.DestFieldRef; fieldRef != nil {
+		return doc.paramSchema(fieldRef.GoType, fieldRef.DescRef.Enum)
+	}
+	if part.DestSetterArg0Type != "" {
+		return doc.paramSchema(part.DestSetterArg0Type, nil)
+	}
+	return doc.paramSchema(part.DestHandlerParamType, nil)
+}
+
+func partDescription(part *URLPathPart) string {
+	if part.DestFieldRef != nil {
+		return fieldCommentText(part.DestFieldRef.DescRef)
+	}
+	return ""
+}
+
+func (doc *openAPIDocument) enumSchemaName(enumRef *protogen.Enum, style openAPISchemaStyle) string {
+	name := string(enumRef.Desc.FullName())
+	if style.useEnumNumbers {
+		name += "_EnumNumbers"
+	}
+	if _, ok := doc.schemas[name]; ok {
+		return name
+	}
+	schema := newYAMLMap()
+	doc.schemas[name] = schema
+	values := make([]any, 0, len(enumRef.Values))
+	for _, value := range enumRef.Values {
+		if style.useEnumNumbers {
+			values = append(values, int32(value.Desc.Number()))
+		} else {
+			values = append(values, string(value.Desc.Name()))
+		}
+	}
+	if style.useEnumNumbers {
+		schema.Set("type", "integer").Set("format", "int32")
+	} else {
+		schema.Set("type", "string")
+	}
+	schema.Set("enum", values)
+	schema.SetNonEmpty("description", commentText(enumRef.Comments.Leading))
+	return name
+}
+
+func anySchema() *yamlMap {
+	return typeSchema("object", "").Set("properties", newYAMLMap().Set("@type", typeSchema("string", "")))
+}
+
+// wellKnownTypeSchema returns schema of well-known types which have special
+// JSON mapping or nil for other messages.
+func (doc *openAPIDocument) wellKnownTypeSchema(message *protogen.Message) *yamlMap {
+	switch message.Desc.FullName() {
+	case "google.protobuf.Timestamp":
+		return typeSchema("string", "date-time")
+	case "google.protobuf.Duration", "google.protobuf.FieldMask":
+		return typeSchema("string", "")
+	case "google.protobuf.Empty", "google.protobuf.Struct":
+		return typeSchema("object", "")
+	case "google.protobuf.ListValue":
+		return typeSchema("array", "")
+	case "google.protobuf.Value":
+		return newYAMLMap()
+	case "google.protobuf.Any":
+		return anySchema()
+	case "google.protobuf.DoubleValue", "google.protobuf.FloatValue",
+		"google.protobuf.Int64Value", "google.protobuf.UInt64Value",
+		"google.protobuf.Int32Value", "google.protobuf.UInt32Value",
+		"google.protobuf.BoolValue", "google.protobuf.StringValue", "google.protobuf.BytesValue":
+		return doc.singularFieldSchema(message.Fields[0], openAPISchemaStyle{})
+	}
+	return nil
+}
+
+// messageSchema returns schema reference of message in JSON form.
+func (doc *openAPIDocument) messageSchema(message *protogen.Message, style openAPISchemaStyle) *yamlMap {
+	if schema := doc.wellKnownTypeSchema(message); schema != nil {
+		return schema
+	}
+	name := string(message.Desc.FullName()) + style.nameSuffix()
+	if _, ok := doc.schemas[name]; ok {
+		return schemaRef(name)
+	}
+	schema := newYAMLMap()
+	doc.schemas[name] = schema
+	schema.Set("type", "object")
+	schema.SetNonEmpty("description", commentText(message.Comments.Leading))
+	properties := newYAMLMap()
+	for _, field := range message.Fields {
+		propName := field.Desc.JSONName()
+		if style.useProtoNames {
+			propName = string(field.Desc.Name())
+		}
+		fieldSchema := doc.fieldSchema(field, style)
+		fieldSchema.SetNonEmpty("description", fieldCommentText(field))
+		if field.Desc.Options().(*descriptorpb.FieldOptions).GetDeprecated() {
+			fieldSchema.Set("deprecated", true)
+		}
+		properties.Set(propName, fieldSchema)
+	}
+	if properties.Len() != 0 {
+		schema.Set("properties", properties)
+	}
+	return schemaRef(name)
+}
+
+// singularFieldSchema returns schema of single value of field in
+// protojson mapping.
+func (doc *openAPIDocument) singularFieldSchema(field *protogen.Field, style openAPISchemaStyle) *yamlMap {
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return typeSchema("boolean", "")
+	case protoreflect.EnumKind:
+		return schemaRef(doc.enumSchemaName(field.Enum, style))
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return typeSchema("integer", "int32")
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return typeSchema("integer", "int64").Set("minimum", 0)
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		// 64-bit integers are written as decimal strings by protojson.
+		return typeSchema("string", "int64")
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return typeSchema("string", "").Set("pattern", "^[0-9]+$")
+	case protoreflect.FloatKind:
+		return typeSchema("number", "float")
+	case protoreflect.DoubleKind:
+		return typeSchema("number", "double")
+	case protoreflect.StringKind:
+		return typeSchema("string", "")
+	case protoreflect.BytesKind:
+		return typeSchema("string", "").Set("contentEncoding", "base64")
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return doc.messageSchema(field.Message, style)
+	}
+	return newYAMLMap()
+}
+
+func (doc *openAPIDocument) fieldSchema(field *protogen.Field, style openAPISchemaStyle) *yamlMap {
+	switch {
+	case field.Desc.IsMap():
+		return typeSchema("object", "").Set("additionalProperties", doc.singularFieldSchema(field.Message.Fields[1], style))
+	case field.Desc.IsList():
+		return typeSchema("array", "").Set("items", doc.singularFieldSchema(field, style))
+	}
+	return doc.singularFieldSchema(field, style)
+}
+
+func (doc *openAPIDocument) errorSchemaName(problemJSON bool) string {
+	if problemJSON {
+		if _, ok := doc.schemas[openAPIProblemSchemaName]; !ok {
+			doc.schemas[openAPIProblemSchemaName] = typeSchema("object", "").
+				Set("description", "RFC 7807 problem details of failed request.").
+				Set("properties", newYAMLMap().
+					Set("type", typeSchema("string", "")).
+					Set("title", typeSchema("string", "")).
+					Set("status", typeSchema("integer", "int32")).
+					Set("detail", typeSchema("string", "")).
+					Set("instance", typeSchema("string", "").Set("description", "Route identifier of the endpoint.")))
+		}
+		return openAPIProblemSchemaName
+	}
+	if _, ok := doc.schemas[openAPIStatusSchemaName]; !ok {
+		doc.schemas[openAPIStatusSchemaName] = typeSchema("object", "").
+			Set("description", "gRPC status of failed request.").
+			Set("properties", newYAMLMap().
+				Set("code", typeSchema("integer", "int32")).
+				Set("message", typeSchema("string", "")).
+				Set("details", typeSchema("array", "").Set("items", anySchema())))
+	}
+	return openAPIStatusSchemaName
+}
+
+func encodingNames(em *EndpointMethod) []string {
+	if len(em.Options.Encodings) == 0 {
+		return []string{"json", "protobuf", "prototext"}
+	}
+	return em.Options.Encodings
+}
+
+func schemaStyle(em *EndpointMethod) openAPISchemaStyle {
+	return openAPISchemaStyle{
+		useProtoNames:  em.JSONOptions.GetUseProtoNames(),
+		useEnumNumbers: em.JSONOptions.GetUseEnumNumbers(),
+	}
+}
+
+// pathTemplate returns OpenAPI path template of ref and parameters of
+// captures in it.
+func (doc *openAPIDocument) pathTemplate(ref *EndpointURLPathMethod) (string, []any) {
+	var b strings.Builder
+	var params []any
+	usedNames := make(map[string]struct{})
+	captureIndex := 0
+	for _, part := range ref.URLPath.Parts {
+		if part.PartType != URLPathPartCapture {
+			b.Write(part.FixedPath)
+			continue
+		}
+		baseName := openAPIParamName(part, captureIndex)
+		name := baseName
+		for idx := 2; ; idx++ {
+			if _, ok := usedNames[name]; !ok {
+				break
+			}
+			name = baseName + "_" + strconv.FormatInt(int64(idx), 10)
+		}
+		usedNames[name] = struct{}{}
+		captureIndex++
+		b.WriteString("{" + name + "}")
+		params = append(params, newYAMLMap().
+			Set("name", name).
+			Set("in", "path").
+			Set("required", true).
+			SetNonEmpty("description", partDescription(part)).
+			Set("schema", doc.partParamSchema(part)))
+	}
+	template := b.String()
+	if !strings.HasPrefix(template, "/") {
+		template = "/" + template
+	}
+	return template, params
+}
+
+func (doc *openAPIDocument) operationID(ref *EndpointURLPathMethod) string {
+	baseID := ref.MethodRef.RouteIdentTail
+	operationID := baseID
+	if _, ok := doc.operationIDs[operationID]; ok {
+		baseID += "By" + httpMethodTitle(ref.HTTPMethod)
+		operationID = baseID
+		for idx := 2; ; idx++ {
+			if _, ok := doc.operationIDs[operationID]; !ok {
+				break
+			}
+			operationID = baseID + strconv.FormatInt(int64(idx), 10)
+		}
+	}
+	doc.operationIDs[operationID] = struct{}{}
+	return operationID
+}
+
+func (doc *openAPIDocument) queryParams(ref *EndpointURLPathMethod) (params []any, err error) {
+	em := ref.MethodRef
+	if !em.Options.BindQuery {
+		return
+	}
+	for _, fieldName := range queryBindFieldNames(ref) {
+		fieldRef, err := em.FindInputFieldRef(fieldName)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, newYAMLMap().
+			Set("name", fieldName).
+			Set("in", "query").
+			SetNonEmpty("description", fieldCommentText(fieldRef.DescRef)).
+			Set("schema", doc.paramSchema(fieldRef.GoType, fieldRef.DescRef.Enum)))
+	}
+	return
+}
+
+func (doc *openAPIDocument) requestParamBindingParams(em *EndpointMethod) (params []any) {
+	for _, binding := range em.ParamBindings {
+		param := newYAMLMap().Set("name", binding.Name)
+		switch binding.Source {
+		case RequestParamHeader:
+			param.Set("in", "header")
+		case RequestParamCookie:
+			param.Set("in", "cookie")
+		default:
+			continue
+		}
+		param.SetNonEmpty("description", partDescription(binding.Part))
+		param.Set("schema", doc.partParamSchema(binding.Part))
+		params = append(params, param)
+	}
+	return
+}
+
+// formSchema returns schema of URL encoded or multipart form body.
+func (doc *openAPIDocument) formSchema(ref *EndpointURLPathMethod, multipart bool) (*yamlMap, error) {
+	em := ref.MethodRef
+	properties := newYAMLMap()
+	for _, fieldName := range formBindFieldNames(ref) {
+		fieldRef, err := em.FindInputFieldRef(fieldName)
+		if err != nil {
+			return nil, err
+		}
+		properties.Set(fieldName, doc.paramSchema(fieldRef.GoType, fieldRef.DescRef.Enum).
+			SetNonEmpty("description", fieldCommentText(fieldRef.DescRef)))
+	}
+	if multipart {
+		for _, binding := range em.FormFileBindings {
+			properties.Set(binding.Name, typeSchema("string", "").Set("contentMediaType", "application/octet-stream"))
+		}
+	}
+	return typeSchema("object", "").Set("properties", properties), nil
+}
+
+func (doc *openAPIDocument) requestBody(ref *EndpointURLPathMethod) (*yamlMap, error) {
+	em := ref.MethodRef
+	if !em.HaveRequestBody(ref.HTTPMethod) {
+		return nil, nil
+	}
+	style := schemaStyle(em)
+	var schema *yamlMap
+	if em.BodyFieldRef != nil {
+		schema = doc.messageSchema(em.BodyFieldRef.DescRef.Message, style)
+	} else {
+		schema = doc.messageSchema(em.DescRef.Input, style)
+	}
+	content := newYAMLMap()
+	requestBody := newYAMLMap().Set("required", true)
+	if em.IsStreamingClient() {
+		requestBody.Set("description", "Stream of "+string(em.DescRef.Input.Desc.FullName())+" messages.")
+		for _, encoding := range encodingNames(em) {
+			switch encoding {
+			case "json":
+				content.Set(mediaTypeNDJSON, newYAMLMap().Set("schema", schema))
+			case "protobuf":
+				content.Set(encodingMediaTypes[encoding], newYAMLMap().Set("schema", typeSchema("string", "").
+					Set("contentMediaType", encodingMediaTypes[encoding]).
+					Set("description", "Messages prefixed with varint length.")))
+			}
+		}
+		return requestBody.Set("content", content), nil
+	}
+	for _, encoding := range encodingNames(em) {
+		content.Set(encodingMediaTypes[encoding], newYAMLMap().Set("schema", schema))
+	}
+	if em.Options.AcceptForm {
+		for _, multipart := range []bool{false, true} {
+			formSchema, err := doc.formSchema(ref, multipart)
+			if err != nil {
+				return nil, err
+			}
+			mediaType := mediaTypeFormURLEncoded
+			if multipart {
+				mediaType = mediaTypeMultipartForm
+			}
+			content.Set(mediaType, newYAMLMap().Set("schema", formSchema))
+		}
+	}
+	return requestBody.Set("content", content), nil
+}
+
+func (doc *openAPIDocument) responses(ref *EndpointURLPathMethod, problemJSON bool) *yamlMap {
+	em := ref.MethodRef
+	responses := newYAMLMap()
+	statusCode := http.StatusOK
+	if em.Options.SuccessStatus != 0 {
+		statusCode = int(em.Options.SuccessStatus)
+	}
+	style := schemaStyle(em)
+	outputName := string(em.DescRef.Output.Desc.FullName())
+	switch {
+	case em.IsStreamingClient() && em.IsStreamingServer():
+		responses.Set(strconv.FormatInt(http.StatusSwitchingProtocols, 10), newYAMLMap().
+			Set("description", "Switched to WebSocket. Text frames carry JSON and binary frames carry protobuf encoded messages of "+
+				string(em.DescRef.Input.Desc.FullName())+" and "+outputName+"."))
+	case em.IsStreamingServer():
+		schema := doc.messageSchema(em.DescRef.Output, style)
+		content := newYAMLMap()
+		if em.Options.StreamFormat != "ndjson" {
+			content.Set(mediaTypeEventStream, newYAMLMap().Set("schema", schema))
+		}
+		if em.Options.StreamFormat != "sse" {
+			content.Set(mediaTypeNDJSON, newYAMLMap().Set("schema", schema))
+		}
+		responses.Set(strconv.FormatInt(int64(statusCode), 10), newYAMLMap().
+			Set("description", "Stream of "+outputName+" messages.").
+			Set("content", content))
+	default:
+		var schema *yamlMap
+		if em.ResponseBodyFieldRef != nil {
+			schema = doc.messageSchema(em.ResponseBodyFieldRef.DescRef.Message, style)
+		} else {
+			schema = doc.messageSchema(em.DescRef.Output, style)
+		}
+		content := newYAMLMap()
+		for _, encoding := range encodingNames(em) {
+			content.Set(encodingMediaTypes[encoding], newYAMLMap().Set("schema", schema))
+		}
+		responses.Set(strconv.FormatInt(int64(statusCode), 10), newYAMLMap().
+			Set("description", http.StatusText(statusCode)).
+			Set("content", content))
+	}
+	errorMediaType := encodingMediaTypes["json"]
+	if problemJSON {
+		errorMediaType = mediaTypeProblemJSON
+	}
+	responses.Set("default", newYAMLMap().
+		Set("description", "Error").
+		Set("content", newYAMLMap().Set(errorMediaType, newYAMLMap().Set("schema", schemaRef(doc.errorSchemaName(problemJSON))))))
+	return responses
+}
+
+func (doc *openAPIDocument) operation(ref *EndpointURLPathMethod, tag string, problemJSON bool, pathParams []any) (*yamlMap, error) {
+	em := ref.MethodRef
+	op := newYAMLMap()
+	op.Set("operationId", doc.operationID(ref))
+	op.Set("tags", []any{tag})
+	customHandler := em.IsExtraEndpoint || (ref.HTTPMethod == http.MethodHead) || (ref.HTTPMethod == http.MethodOptions)
+	if em.DescRef != nil {
+		op.SetNonEmpty("description", commentText(em.DescRef.Comments.Leading))
+		if em.DescRef.Desc.Options().(*descriptorpb.MethodOptions).GetDeprecated() {
+			op.Set("deprecated", true)
+		}
+	}
+	params := slices.Clone(pathParams)
+	if !customHandler {
+		queryParams, err := doc.queryParams(ref)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, queryParams...)
+	}
+	params = append(params, doc.requestParamBindingParams(em)...)
+	if len(params) != 0 {
+		op.Set("parameters", params)
+	}
+	if customHandler {
+		op.Set("responses", newYAMLMap().Set("default", newYAMLMap().Set("description", "Response of custom handler.")))
+		return op, nil
+	}
+	requestBody, err := doc.requestBody(ref)
+	if err != nil {
+		return nil, err
+	}
+	if requestBody != nil {
+		op.Set("requestBody", requestBody)
+	}
+	op.Set("responses", doc.responses(ref, problemJSON))
+	return op, nil
+}
+
+// addService adds routes of es into the document. Error encoding of the
+// service decides the schema of error responses.
+func (doc *openAPIDocument) addService(ef *EndpointFile, es *EndpointService) error {
+	pathContainer := NewEndpointPathContainer()
+	es.ExportEndpointPaths(pathContainer)
+	if err := pathContainer.Err(); err != nil {
+		return err
+	}
+	if len(pathContainer.Paths) == 0 {
+		return nil
+	}
+	tag := string(es.DescRef.Desc.FullName())
+	doc.tags = append(doc.tags, newYAMLMap().
+		Set("name", tag).
+		SetNonEmpty("description", commentText(es.DescRef.Comments.Leading)))
+	errorEncoding := es.Options.ErrorEncoding
+	if errorEncoding == "" {
+		errorEncoding = ef.Options.ErrorEncoding
+	}
+	problemJSON := errorEncoding == ErrorEncodingProblemJSON
+	for _, endpointPath := range pathContainer.SortedEndpointPaths() {
+		for _, ref := range endpointPath.URLPathMethods() {
+			template, pathParams := doc.pathTemplate(ref)
+			pathItem, _ := doc.paths.Get(template).(*yamlMap)
+			if pathItem == nil {
+				pathItem = newYAMLMap()
+				doc.paths.Set(template, pathItem)
+			}
+			methodKey := strings.ToLower(ref.HTTPMethod)
+			if pathItem.Get(methodKey) != nil {
+				return fmt.Errorf("[%s] %s (%s): conflicts with other route of the same OpenAPI path: [%s]",
+					ref.HTTPMethod, string(ref.URLPath.RawPath), ref.MethodRef.RouteIdentTail, template)
+			}
+			op, err := doc.operation(ref, tag, problemJSON, pathParams)
+			if err != nil {
+				return fmt.Errorf("[%s] %s (%s): %w", ref.HTTPMethod, string(ref.URLPath.RawPath), ref.MethodRef.RouteIdentTail, err)
+			}
+			pathItem.Set(methodKey, op)
+		}
+	}
+	return nil
+}
+
+func (doc *openAPIDocument) haveEndpoints() bool {
+	return doc.paths.Len() != 0
+}
+
+func (doc *openAPIDocument) marshal() []byte {
+	info := newYAMLMap().
+		Set("title", string(doc.goImportPath)).
+		Set("description", "HTTP endpoints generated from "+strings.Join(doc.protoFiles, ", ")+".").
+		Set("version", "0.0.0")
+	root := newYAMLMap().
+		Set("openapi", openAPIVersion).
+		Set("info", info)
+	if len(doc.tags) != 0 {
+		root.Set("tags", doc.tags)
+	}
+	root.Set("paths", doc.paths)
+	schemaNames := make([]string, 0, len(doc.schemas))
+	for name := range doc.schemas {
+		schemaNames = append(schemaNames, name)
+	}
+	slices.Sort(schemaNames)
+	schemas := newYAMLMap()
+	for _, name := range schemaNames {
+		schemas.Set(name, doc.schemas[name])
+	}
+	root.Set("components", newYAMLMap().Set("schemas", schemas))
+	return root.MarshalYAML()
+}
+
+// GenerateOpenAPIFiles generates an OpenAPI document for each Go package
+// which has HTTP endpoints defined in proto files to be generated.
+func GenerateOpenAPIFiles(gen *protogen.Plugin) error {
+	var docs []*openAPIDocument
+	docByImportPath := make(map[protogen.GoImportPath]*openAPIDocument)
+	var errs []error
+	for _, file := range gen.Files {
+		if !file.Generate {
+			continue
+		}
+		doc := docByImportPath[file.GoImportPath]
+		if doc == nil {
+			doc = newOpenAPIDocument(path.Join(path.Dir(file.GeneratedFilenamePrefix), OpenAPIFileName), file.GoImportPath)
+			docByImportPath[file.GoImportPath] = doc
+			docs = append(docs, doc)
+		}
+		ef := LoadEndpointFile(file)
+		for _, es := range ef.Services {
+			if err := doc.addService(ef, es); err != nil {
+				errs = append(errs, fmt.Errorf("%s: service %s: %w", file.Desc.Path(), es.DescRef.GoName, err))
+			}
+		}
+		doc.protoFiles = append(doc.protoFiles, file.Desc.Path())
+	}
+	if len(errs) != 0 {
+		return errors.Join(errs...)
+	}
+	for _, doc := range docs {
+		if !doc.haveEndpoints() {
+			continue
+		}
+		g := gen.NewGeneratedFile(doc.fileName, doc.goImportPath)
+		if _, err := g.Write(doc.marshal()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package protocgenghe
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// collectOpenAPIRefs walks node of parsed document and collects values of
+// `$ref` and `format` keys.
+func collectOpenAPIRefs(node any, refs, formats map[string]struct{}) {
+	switch v := node.(type) {
+	case map[string]any:
+		for key, value := range v {
+			switch key {
+			case "$ref":
+				refs[value.(string)] = struct{}{}
+			case "format":
+				formats[value.(string)] = struct{}{}
+			}
+			collectOpenAPIRefs(value, refs, formats)
+		}
+	case []any:
+		for _, item := range v {
+			collectOpenAPIRefs(item, refs, formats)
+		}
+	}
+}
+
+func TestGenerateOpenAPIFixture(t *testing.T) {
+	gen, err := NewPluginWithFileDescriptorSet(fixtureFileDescriptorSet(), []string{
+		fixtureDir + "/route.proto",
+		fixtureDir + "/relay.proto",
+		fixtureDir + "/handler.proto",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = GenerateOpenAPIFiles(gen); err != nil {
+		t.Fatal(err)
+	}
+	resp := gen.Response()
+	if resp.Error != nil {
+		t.Fatal(resp.GetError())
+	}
+	if len(resp.File) != 1 {
+		t.Fatalf("expecting one document, got %d", len(resp.File))
+	}
+	content := []byte(resp.File[0].GetContent())
+	goldenPath := filepath.Join(fixtureDir, OpenAPIFileName)
+	if *updateGolden {
+		if err = os.WriteFile(goldenPath, content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	} else {
+		golden, err := os.ReadFile(goldenPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(content, golden) {
+			t.Errorf("generated document differs from %s, run `go test -run TestGenerateOpenAPIFixture -update` if the change is intended", goldenPath)
+		}
+	}
+	var doc map[string]any
+	if err = yaml.Unmarshal(content, &doc); err != nil {
+		t.Fatalf("cannot parse generated document: %v", err)
+	}
+	if doc["openapi"] != openAPIVersion {
+		t.Errorf("openapi version %v, want %s", doc["openapi"], openAPIVersion)
+	}
+	paths, _ := doc["paths"].(map[string]any)
+	for _, p := range []string{
+		"/fixture/items/{id}",
+		"/fixture/books/{name}:publish",
+		"/v1/{name}",
+		"/relay/echo/{id}",
+		"/handler/shelves/{id}",
+		"/problem/items/{id}",
+	} {
+		if _, ok := paths[p]; !ok {
+			t.Errorf("path %s not found in document", p)
+		}
+	}
+	components, _ := doc["components"].(map[string]any)
+	schemas, _ := components["schemas"].(map[string]any)
+	routeRequest, _ := schemas["ghe.fixture.RouteRequest"].(map[string]any)
+	properties, _ := routeRequest["properties"].(map[string]any)
+	for name, want := range map[string]map[string]any{
+		"revision": {"type": "string", "format": "int64"},
+		"count":    {"type": "integer", "format": "int64", "minimum": 0},
+		"total":    {"type": "string", "pattern": "^[0-9]+$"},
+	} {
+		if got := properties[name]; !reflect.DeepEqual(got, want) {
+			t.Errorf("schema of RouteRequest.%s: %v, want %v", name, got, want)
+		}
+	}
+	refs := make(map[string]struct{})
+	formats := make(map[string]struct{})
+	collectOpenAPIRefs(doc, refs, formats)
+	for ref := range refs {
+		name, ok := strings.CutPrefix(ref, "#/components/schemas/")
+		if !ok {
+			t.Errorf("unexpected reference: %s", ref)
+			continue
+		}
+		if _, ok = schemas[name]; !ok {
+			t.Errorf("unresolved reference: %s", ref)
+		}
+	}
+	for format := range formats {
+		switch format {
+		case "int32", "int64", "float", "double", "binary":
+		default:
+			t.Errorf("non-standard format: %s", format)
+		}
+	}
+}
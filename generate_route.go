@@ -0,0 +1,155 @@
+package protocgenghe
+
+import (
+	"strconv"
+	"strings"
+)
+
+func routePathVarName(level int) string {
+	return "p" + strconv.FormatInt(int64(level), 10)
+}
+
+func routeCaptureVarName(captureIndex int) string {
+	return "c" + strconv.FormatInt(int64(captureIndex), 10)
+}
+
+func byteLiteral(b byte) string {
+	return strconv.QuoteRuneToASCII(rune(b))
+}
+
+func (sg *serviceHandlerGenerator) genRouteLeaf(leaf *EndpointPath, captureCount int) {
+	g := sg.g
+	captureArgs := ""
+	for idx := 0; idx < captureCount; idx++ {
+		captureArgs += ", " + routeCaptureVarName(idx)
+	}
+	g.P("switch r.Method {")
+	for _, ref := range leaf.URLPathMethods() {
+		g.P("case ", httpPackage.Ident("Method"+httpMethodTitle(ref.HTTPMethod)), ":")
+		g.P("hnd.", sg.handlerFuncNames[ref], "(w, r", captureArgs, ")")
+		g.P("return")
+	}
+	g.P("}")
+	g.P(sg.errorWriterMethod("WriteMethodNotAllowed"), "(w, r, ", strconv.Quote(leaf.MethodNotAllowedRouteIdent()), ", ", strconv.Quote(leaf.AllowMethods()), ")")
+	g.P("return")
+}
+
+// routeCaptureLenExpr returns expression to compute the maximum length of
+// bytes acceptable by the capture pattern of node.
+func (sg *serviceHandlerGenerator) routeCaptureLenExpr(node *URLRouteRadixNode, pathVar string) string {
+	bits0, bits1 := node.Part.PatternByteMapper.ByteMap()
+	return sg.g.QualifiedGoIdent(gheRuntimePackage.Ident("CaptureLen")) + "(" + pathVar +
+		", 0x" + strconv.FormatUint(bits0, 16) + ", 0x" + strconv.FormatUint(bits1, 16) + ")"
+}
+
+// captureNeedBacktrack checks if shorter captures of given capture node might
+// lead to a match when the longest capture failed.
+// Backtracking is not necessary when all children are fixed part and
+// the leading bytes of them are not acceptable by the capture pattern.
+func captureNeedBacktrack(node *URLRouteRadixNode) bool {
+	for _, childNode := range node.Children {
+		if childNode.Part.PartType != URLPathPartFixed {
+			return true
+		}
+		if len(childNode.Part.FixedPath) == 0 {
+			return true
+		}
+		if b := childNode.Part.FixedPath[0]; (b < 0x80) && node.Part.PatternByteMapper.HasByte(b) {
+			return true
+		}
+	}
+	return false
+}
+
+// genRouteNode generates code for matching the part of node against
+// remaining path in path variable of given level.
+func (sg *serviceHandlerGenerator) genRouteNode(node *URLRouteRadixNode, level, captureCount int) {
+	g := sg.g
+	pathVar := routePathVarName(level)
+	nextPathVar := routePathVarName(level + 1)
+	switch node.Part.PartType {
+	case URLPathPartFixed:
+		g.P("// ", node.Part.CanonicalText())
+		g.P("if ", stringsPackage.Ident("HasPrefix"), "(", pathVar, ", ", strconv.Quote(string(node.Part.FixedPath)), ") {")
+		g.P(nextPathVar, " := ", pathVar, "[", len(node.Part.FixedPath), ":]")
+		sg.genRouteNodeBody(node, level+1, captureCount)
+		g.P("}")
+	case URLPathPartCapture:
+		captureVar := routeCaptureVarName(captureCount)
+		captureInit := captureVar + " := " + pathVar + "[:" + sg.routeCaptureLenExpr(node, pathVar) + "]"
+		g.P("// ", node.Part.CanonicalText())
+		if captureNeedBacktrack(node) {
+			g.P("for ", captureInit, "; len(", captureVar, ") != 0; ", captureVar, " = ", captureVar, "[:len(", captureVar, ")-1] {")
+		} else {
+			g.P("if ", captureInit, "; len(", captureVar, ") != 0 {")
+		}
+		g.P(nextPathVar, " := ", pathVar, "[len(", captureVar, "):]")
+		sg.genRouteNodeBody(node, level+1, captureCount+1)
+		g.P("}")
+	}
+}
+
+func (sg *serviceHandlerGenerator) genRouteNodeBody(node *URLRouteRadixNode, level, captureCount int) {
+	g := sg.g
+	pathVar := routePathVarName(level)
+	if node.Leaf != nil {
+		g.P("if len(", pathVar, ") == 0 {")
+		sg.genRouteLeaf(node.Leaf, captureCount)
+		g.P("}")
+	}
+	sg.genRouteChildren(node, level, captureCount)
+}
+
+func (sg *serviceHandlerGenerator) genRouteChildren(node *URLRouteRadixNode, level, captureCount int) {
+	g := sg.g
+	pathVar := routePathVarName(level)
+	var fixedChildren, captureChildren []*URLRouteRadixNode
+	for _, childNode := range node.OrderedChildren() {
+		if childNode.Part.PartType == URLPathPartFixed {
+			fixedChildren = append(fixedChildren, childNode)
+		} else {
+			captureChildren = append(captureChildren, childNode)
+		}
+	}
+	if len(fixedChildren) > 1 {
+		g.P("if len(", pathVar, ") != 0 {")
+		g.P("switch ", pathVar, "[0] {")
+		for _, childNode := range fixedChildren {
+			if len(childNode.Part.FixedPath) == 0 {
+				continue
+			}
+			g.P("case ", byteLiteral(childNode.Part.FixedPath[0]), ":")
+			sg.genRouteNode(childNode, level, captureCount)
+		}
+		g.P("}")
+		g.P("}")
+	} else {
+		for _, childNode := range fixedChildren {
+			sg.genRouteNode(childNode, level, captureCount)
+		}
+	}
+	for _, childNode := range captureChildren {
+		sg.genRouteNode(childNode, level, captureCount)
+	}
+}
+
+func (sg *serviceHandlerGenerator) genServeHTTP() {
+	g := sg.g
+	g.P("// ServeHTTP implements http.Handler interface.")
+	g.P("func (hnd *", handlerTypeName(sg.es), ") ServeHTTP(w ", httpPackage.Ident("ResponseWriter"), ", r *", httpPackage.Ident("Request"), ") {")
+	g.P("p0 := r.URL.EscapedPath()")
+	g.P("for (len(p0) != 0) && (p0[0] == '/') {")
+	g.P("p0 = p0[1:]")
+	g.P("}")
+	sg.genRouteChildren(sg.routeRoot, 0, 0)
+	g.P(sg.errorWriterMethod("WriteRouteNotFound"), "(w, r)")
+	g.P("}")
+	g.P()
+}
+
+func dumpRouteNode(debugFile *GeneratedDebugFile, node *URLRouteRadixNode, level int) {
+	debugFile.P(strings.Repeat("  ", level), node.String())
+	for _, childNode := range node.OrderedChildren() {
+		dumpRouteNode(debugFile, childNode, level+1)
+	}
+}
@@ -0,0 +1,147 @@
+package protocgenghe
+
+// StreamFormatRuntimeNames maps values of stream_format option to stream
+// format constants of runtime package.
+var StreamFormatRuntimeNames = map[string]string{
+	"ndjson": "StreamFormatNDJSON",
+	"sse":    "StreamFormatSSE",
+}
+
+func (sg *serviceHandlerGenerator) allowedStreamFormatsExpr(em *EndpointMethod) string {
+	if em.Options.StreamFormat == "" {
+		return sg.g.QualifiedGoIdent(gheRuntimePackage.Ident("AllStreamFormats"))
+	}
+	return sg.g.QualifiedGoIdent(gheRuntimePackage.Ident(StreamFormatRuntimeNames[em.Options.StreamFormat]))
+}
+
+// genStreamWriteError generates code to write error of started stream as
+// the last frame, or as error response otherwise.
+func (sg *serviceHandlerGenerator) genStreamWriteError(streamVar string) {
+	g := sg.g
+	g.P("if ", streamVar, ".Started() {")
+	g.P(streamVar, ".WriteError(err)")
+	g.P("return")
+	g.P("}")
+	sg.genWriteError("err")
+}
+
+// genServerStreamInvoke generates code to invoke server-streaming method
+// and relay messages as server-sent events or NDJSON lines.
+func (sg *serviceHandlerGenerator) genServerStreamInvoke(ref *EndpointURLPathMethod) error {
+	g := sg.g
+	em := ref.MethodRef
+	sg.genMaxUploadSize(ref)
+	g.P("streamFormat, err := ", gheRuntimePackage.Ident("NegotiateStreamFormat"), "(r, ", sg.allowedStreamFormatsExpr(em), ")")
+	g.P("if err != nil {")
+	sg.genWriteError("err")
+	g.P("}")
+	if err := sg.genInputMessage(ref); err != nil {
+		return err
+	}
+	codecExpr := sg.messageCodecExpr(em)
+	statusCodeExpr := sg.successStatusExpr(em)
+	if sg.genOpts.HandlerMode == HandlerModeClient {
+		g.P("stream, err := ", sg.rpcInvokeTarget(), ".", em.DescRef.GoName, "(r.Context(), in)")
+		g.P("if err != nil {")
+		sg.genWriteError("err")
+		g.P("}")
+		g.P("sw := ", gheRuntimePackage.Ident("NewMessageStreamWriter"), "(w, ", codecExpr, ", streamFormat, ", statusCodeExpr, ")")
+		g.P("for {")
+		g.P("out, err := stream.Recv()")
+		g.P("if err == ", ioPackage.Ident("EOF"), " {")
+		g.P("break")
+		g.P("}")
+		g.P("if err != nil {")
+		sg.genStreamWriteError("sw")
+		g.P("}")
+		g.P("if err = sw.WriteMessage(out); err != nil {")
+		g.P("return")
+		g.P("}")
+		g.P("}")
+		g.P("sw.Finish()")
+		return nil
+	}
+	g.P("stream := ", gheRuntimePackage.Ident("NewServerStream"), "[", em.DescRef.Output.GoIdent, "](w, r, ", codecExpr, ", streamFormat, ", statusCodeExpr, ")")
+	g.P("if err = ", sg.rpcInvokeTarget(), ".", em.DescRef.GoName, "(in, stream); err != nil {")
+	sg.genStreamWriteError("stream")
+	g.P("}")
+	g.P("stream.Finish()")
+	return nil
+}
+
+// genClientStreamInvoke generates code to invoke client-streaming method
+// with messages read from request body and write the reply as response.
+func (sg *serviceHandlerGenerator) genClientStreamInvoke(ref *EndpointURLPathMethod) error {
+	g := sg.g
+	em := ref.MethodRef
+	sg.genMaxUploadSize(ref)
+	g.P("respEncoding, err := ", gheRuntimePackage.Ident("NegotiateEncoding"), "(r, ", sg.allowedEncodingsExpr(em), ")")
+	g.P("if err != nil {")
+	sg.genWriteError("err")
+	g.P("}")
+	g.P("reader, err := ", gheRuntimePackage.Ident("NewMessageStreamReader"), "(r, ", sg.messageCodecExpr(em), ", ", sg.allowedEncodingsExpr(em), ")")
+	g.P("if err != nil {")
+	sg.genWriteError("err")
+	g.P("}")
+	if err := sg.genInputMessage(ref); err != nil {
+		return err
+	}
+	g.P("stream := ", gheRuntimePackage.Ident("NewClientStream"), "[", em.DescRef.Input.GoIdent, ", ", em.DescRef.Output.GoIdent, "](w, r, reader, in, ", !em.Options.CapturesFirstMessageOnly, ")")
+	if sg.genOpts.HandlerMode == HandlerModeClient {
+		g.P("client, err := ", sg.rpcInvokeTarget(), ".", em.DescRef.GoName, "(r.Context())")
+		g.P("if err != nil {")
+		sg.genWriteError("err")
+		g.P("}")
+		g.P("for {")
+		g.P("msg, err := stream.Recv()")
+		g.P("if err == ", ioPackage.Ident("EOF"), " {")
+		g.P("break")
+		g.P("}")
+		g.P("if err != nil {")
+		sg.genWriteError("err")
+		g.P("}")
+		g.P("if err = client.Send(msg); err == ", ioPackage.Ident("EOF"), " {")
+		g.P("break")
+		g.P("} else if err != nil {")
+		sg.genWriteError("err")
+		g.P("}")
+		g.P("}")
+		g.P("out, err := client.CloseAndRecv()")
+	} else {
+		g.P("if err = ", sg.rpcInvokeTarget(), ".", em.DescRef.GoName, "(stream); err != nil {")
+		sg.genWriteError("err")
+		g.P("}")
+		g.P("out, err := stream.Reply()")
+	}
+	g.P("if err != nil {")
+	sg.genWriteError("err")
+	g.P("}")
+	sg.genWriteResponse(em)
+	return nil
+}
+
+// genBidiStreamInvoke generates code to upgrade the request to WebSocket
+// and invoke bidirectional streaming method on it.
+func (sg *serviceHandlerGenerator) genBidiStreamInvoke(ref *EndpointURLPathMethod) error {
+	g := sg.g
+	em := ref.MethodRef
+	if err := sg.genInputMessage(ref); err != nil {
+		return err
+	}
+	g.P("conn, err := ", gheRuntimePackage.Ident("UpgradeWebSocket"), "(w, r, ", sg.messageCodecExpr(em), ", ", sg.allowedEncodingsExpr(em), ", ", em.Options.MaxUploadSize, ", hnd.CheckWebSocketOrigin)")
+	g.P("if err != nil {")
+	sg.genWriteError("err")
+	g.P("}")
+	g.P("stream := ", gheRuntimePackage.Ident("NewBidiStream"), "[", em.DescRef.Input.GoIdent, ", ", em.DescRef.Output.GoIdent, "](conn, in, ", !em.Options.CapturesFirstMessageOnly, ")")
+	if sg.genOpts.HandlerMode == HandlerModeClient {
+		g.P("client, err := ", sg.rpcInvokeTarget(), ".", em.DescRef.GoName, "(conn.Context())")
+		g.P("if err != nil {")
+		g.P("conn.Close(err)")
+		g.P("return")
+		g.P("}")
+		g.P("conn.Close(", gheRuntimePackage.Ident("RelayBidiStream"), "(stream, client))")
+	} else {
+		g.P("conn.Close(", sg.rpcInvokeTarget(), ".", em.DescRef.GoName, "(stream))")
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package protocgenghe
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/yinyin/protoc-gen-go-grpc-http-endpoint/ghegen"
+	"github.com/yinyin/protoc-gen-go-grpc-http-endpoint/internal/testfixture"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files of generated code")
+
+const fixtureDir = "internal/testfixture"
+
+// fixtureFileDescriptorSet collects fixture files and their imports with
+// imported files placed before importing files.
+func fixtureFileDescriptorSet() *descriptorpb.FileDescriptorSet {
+	fds := &descriptorpb.FileDescriptorSet{}
+	added := make(map[string]struct{})
+	var addFile func(fileDesc protoreflect.FileDescriptor)
+	addFile = func(fileDesc protoreflect.FileDescriptor) {
+		if _, ok := added[fileDesc.Path()]; ok {
+			return
+		}
+		added[fileDesc.Path()] = struct{}{}
+		imports := fileDesc.Imports()
+		for idx := 0; idx < imports.Len(); idx++ {
+			addFile(imports.Get(idx).FileDescriptor)
+		}
+		fds.File = append(fds.File, protodesc.ToFileDescriptorProto(fileDesc))
+	}
+	addFile(testfixture.File_internal_testfixture_route_proto)
+	addFile(testfixture.File_internal_testfixture_relay_proto)
+	addFile(testfixture.File_internal_testfixture_handler_proto)
+	return fds
+}
+
+func TestGenerateFixture(t *testing.T) {
+	tests := []struct {
+		protoName   string
+		handlerMode string
+		goldenName  string
+	}{
+		{"route.proto", HandlerModeServer, "route_ghe.pb.go"},
+		{"relay.proto", HandlerModeClient, "relay_ghe.pb.go"},
+		{"handler.proto", HandlerModeServer, "handler_ghe.pb.go"},
+	}
+	fds := fixtureFileDescriptorSet()
+	for _, tt := range tests {
+		t.Run(tt.protoName, func(t *testing.T) {
+			fileName := fixtureDir + "/" + tt.protoName
+			gen, err := NewPluginWithFileDescriptorSet(fds, []string{fileName})
+			if err != nil {
+				t.Fatal(err)
+			}
+			g, err := GenerateFile(gen, gen.FilesByPath[fileName], &GenerateOptions{
+				HandlerMode: tt.handlerMode,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			content, err := g.Content()
+			if err != nil {
+				t.Fatal(err)
+			}
+			goldenPath := filepath.Join(fixtureDir, tt.goldenName)
+			if *updateGolden {
+				if err = os.WriteFile(goldenPath, content, 0644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			golden, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(content, golden) {
+				t.Errorf("generated code differs from %s, run `go test -run TestGenerateFixture -update` if the change is intended", goldenPath)
+			}
+		})
+	}
+}
+
+// customHandlerFileDescriptorSet returns descriptor set of a file with a
+// service of baseOpts and one method of opts taking HandlerRequest of fixture
+// as input.
+func customHandlerFileDescriptorSet(baseOpts *ghegen.GHEServiceOptions, opts *ghegen.GHEMethodOptions) *descriptorpb.FileDescriptorSet {
+	serviceOpts := &descriptorpb.ServiceOptions{}
+	proto.SetExtension(serviceOpts, ghegen.E_Base, baseOpts)
+	methodOpts := &descriptorpb.MethodOptions{}
+	proto.SetExtension(methodOpts, ghegen.E_Endpoint, opts)
+	file := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("custom.proto"),
+		Package:    proto.String("ghe.custom"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"internal/testfixture/handler.proto", "ghe_options.proto"},
+		Options: &descriptorpb.FileOptions{
+			GoPackage: proto.String("example.com/custom"),
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name:    proto.String("CustomService"),
+			Options: serviceOpts,
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("GetShelf"),
+				InputType:  proto.String(".ghe.fixture.HandlerRequest"),
+				OutputType: proto.String(".ghe.fixture.HandlerReply"),
+				Options:    methodOpts,
+			}},
+		}},
+	}
+	fds := fixtureFileDescriptorSet()
+	fds.File = append(fds.File, file)
+	return fds
+}
+
+func TestGenerateCustomHandlerInputError(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseOpts *ghegen.GHEServiceOptions
+		opts     *ghegen.GHEMethodOptions
+		errText  string
+	}{
+		{"extra endpoint setter capture", &ghegen.GHEServiceOptions{
+			ExtraEndpoints: []*ghegen.GHEMethodOptions{{
+				Ident:         "Ping",
+				Get:           "ping/{setNote(string)}",
+				GoHandlerFunc: "servePing",
+			}},
+		}, &ghegen.GHEMethodOptions{
+			Get: "shelves",
+		}, "capture is supported for custom handler function servePing (GET): [{setNote(string)}]"},
+		{"head capture", nil, &ghegen.GHEMethodOptions{
+			Get:               "shelves/{id}",
+			GoHeadHandlerFunc: "headShelf",
+		}, "capture is supported for custom handler function headShelf (HEAD): [{id}]"},
+		{"options header", nil, &ghegen.GHEMethodOptions{
+			Get:                  "shelves",
+			GoOptionsHandlerFunc: "optionsShelf",
+			Headers:              []string{"X-Note: {note}"},
+		}, "binding is supported for custom handler function optionsShelf (OPTIONS): [X-Note: {note}]"},
+		{"options cookie", nil, &ghegen.GHEMethodOptions{
+			Get:                  "shelves",
+			GoOptionsHandlerFunc: "optionsShelf",
+			Cookies:              []string{"shelf_name: {shelf.name}"},
+		}, "binding is supported for custom handler function optionsShelf (OPTIONS): [shelf_name: {shelf.name}]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, err := NewPluginWithFileDescriptorSet(customHandlerFileDescriptorSet(tt.baseOpts, tt.opts), []string{"custom.proto"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = GenerateFile(gen, gen.FilesByPath["custom.proto"], &GenerateOptions{
+				HandlerMode: HandlerModeServer,
+			})
+			if err == nil {
+				t.Fatal("expecting generation error")
+			}
+			if !strings.Contains(err.Error(), tt.errText) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
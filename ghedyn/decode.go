@@ -0,0 +1,126 @@
+package ghedyn
+
+import (
+	"errors"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	protocgenghe "github.com/yinyin/protoc-gen-go-grpc-http-endpoint"
+	"github.com/yinyin/protoc-gen-go-grpc-http-endpoint/ghert"
+)
+
+// decodeValue decodes request parameter v into value of field fd.
+// Captures of URL path are escaped while query, header and cookie values
+// are not.
+func decodeValue(fd protoreflect.FieldDescriptor, v string, escaped bool) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		b, err := ghert.DecodeBool(v)
+		return protoreflect.ValueOfBool(b), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := ghert.DecodeInt32(v)
+		return protoreflect.ValueOfInt32(n), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := ghert.DecodeUint32(v)
+		return protoreflect.ValueOfUint32(n), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := ghert.DecodeInt64(v)
+		return protoreflect.ValueOfInt64(n), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := ghert.DecodeUint64(v)
+		return protoreflect.ValueOfUint64(n), err
+	case protoreflect.FloatKind:
+		n, err := ghert.DecodeFloat32(v)
+		return protoreflect.ValueOfFloat32(n), err
+	case protoreflect.DoubleKind:
+		n, err := ghert.DecodeFloat64(v)
+		return protoreflect.ValueOfFloat64(n), err
+	case protoreflect.StringKind:
+		decodeString := ghert.DecodeQueryString
+		if escaped {
+			decodeString = ghert.DecodeString
+		}
+		s, err := decodeString(v)
+		return protoreflect.ValueOfString(s), err
+	case protoreflect.BytesKind:
+		decodeBytes := ghert.DecodeQueryBytes
+		if escaped {
+			decodeBytes = ghert.DecodeBytes
+		}
+		b, err := decodeBytes(v)
+		return protoreflect.ValueOfBytes(b), err
+	case protoreflect.EnumKind:
+		decodeEnum := ghert.DecodeQueryEnum[protoreflect.EnumNumber]
+		if escaped {
+			decodeEnum = ghert.DecodeEnum[protoreflect.EnumNumber]
+		}
+		n, err := decodeEnum(v, fd.Enum())
+		return protoreflect.ValueOfEnum(n), err
+	}
+	return protoreflect.Value{}, errors.New("unsupported value type: [" + fd.Kind().String() + "]")
+}
+
+// checkFieldPath checks if messages of the first pathLen fields of
+// fieldRef can be allocated.
+func checkFieldPath(fieldRef *protocgenghe.CaptureDestFieldRef, pathLen int) error {
+	for _, fieldDescRef := range fieldRef.PathDescRefs[:pathLen] {
+		if fieldDescRef.Desc.IsList() || fieldDescRef.Desc.IsMap() {
+			return errors.New("repeated field is not supported in field path: " + string(fieldRef.DescRef.Desc.FullName()))
+		}
+		if (fieldDescRef.Oneof != nil) && !fieldDescRef.Oneof.Desc.IsSynthetic() {
+			return errors.New("oneof field is not supported in field path: " + string(fieldRef.DescRef.Desc.FullName()))
+		}
+	}
+	return nil
+}
+
+// checkValueFieldRef checks if values of request parameters can be
+// assigned to the field referenced by fieldRef.
+func checkValueFieldRef(fieldRef *protocgenghe.CaptureDestFieldRef) error {
+	if err := checkFieldPath(fieldRef, len(fieldRef.PathDescRefs)-1); err != nil {
+		return err
+	}
+	if fieldRef.DescRef.Desc.IsMap() || (fieldRef.DescRef.Desc.Message() != nil) {
+		return errors.New("cannot assign value to non-scalar field: " + string(fieldRef.DescRef.Desc.FullName()))
+	}
+	return nil
+}
+
+// mutableFieldMessage returns the message of the first pathLen fields of
+// fieldRef under msg. Intermediate messages are allocated as needed.
+func mutableFieldMessage(msg protoreflect.Message, fieldRef *protocgenghe.CaptureDestFieldRef, pathLen int) (protoreflect.Message, error) {
+	if err := checkFieldPath(fieldRef, pathLen); err != nil {
+		return nil, err
+	}
+	for _, fieldDescRef := range fieldRef.PathDescRefs[:pathLen] {
+		msg = msg.Mutable(fieldDescRef.Desc).Message()
+	}
+	return msg, nil
+}
+
+// setFieldValue assigns v to the field referenced by fieldRef of msg.
+// Value is appended when the field is repeated.
+func setFieldValue(msg protoreflect.Message, fieldRef *protocgenghe.CaptureDestFieldRef, v protoreflect.Value) error {
+	lastIndex := len(fieldRef.PathDescRefs) - 1
+	msg, err := mutableFieldMessage(msg, fieldRef, lastIndex)
+	if err != nil {
+		return err
+	}
+	fd := fieldRef.PathDescRefs[lastIndex].Desc
+	if fd.IsList() {
+		msg.Mutable(fd).List().Append(v)
+		return nil
+	}
+	msg.Set(fd, v)
+	return nil
+}
+
+// decodeFieldValue decodes v and assigns the value to the field referenced
+// by fieldRef of msg. Decode failure is reported as ghert.DecodeError.
+func decodeFieldValue(msg protoreflect.Message, fieldRef *protocgenghe.CaptureDestFieldRef, name, v string, escaped bool) error {
+	value, err := decodeValue(fieldRef.DescRef.Desc, v, escaped)
+	if err != nil {
+		return ghert.NewDecodeError(name, v, err)
+	}
+	return setFieldValue(msg, fieldRef, value)
+}
@@ -0,0 +1,246 @@
+// Package ghedyn serves HTTP endpoints of gRPC services with GHE options
+// loaded from descriptors at runtime. Requests are decoded into dynamicpb
+// messages and forwarded to gRPC server without generated code.
+//
+// Routes which need Go code in generated handlers, such as custom handler
+// functions, setter captures and streaming methods, respond with 501.
+// Form requests and go_extract_http_status_code option are not supported.
+package ghedyn
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	protocgenghe "github.com/yinyin/protoc-gen-go-grpc-http-endpoint"
+	"github.com/yinyin/protoc-gen-go-grpc-http-endpoint/ghert"
+)
+
+// serviceRoutes holds route tree and handlers of one service.
+type serviceRoutes struct {
+	routeRoot   *protocgenghe.URLRouteRadixNode
+	errorWriter *ghert.ErrorWriter
+	handlers    map[*protocgenghe.EndpointURLPathMethod]*routeHandler
+}
+
+func newErrorWriter(ef *protocgenghe.EndpointFile, es *protocgenghe.EndpointService) (*ghert.ErrorWriter, error) {
+	statusCodeOverrides, err := ef.HTTPStatusCodeOverrides()
+	if err != nil {
+		return nil, err
+	}
+	errorEncoding := es.Options.ErrorEncoding
+	if errorEncoding == "" {
+		errorEncoding = ef.Options.ErrorEncoding
+	}
+	ew := &ghert.ErrorWriter{}
+	switch errorEncoding {
+	case "", protocgenghe.ErrorEncodingStatusJSON:
+	case protocgenghe.ErrorEncodingProblemJSON:
+		ew.Encoding = ghert.ErrorEncodingProblemJSON
+	default:
+		return nil, errors.New("unknown error encoding: [" + errorEncoding + "]")
+	}
+	if len(statusCodeOverrides) != 0 {
+		ew.StatusCodes = make(map[codes.Code]int)
+		for _, override := range statusCodeOverrides {
+			ew.StatusCodes[override.Code] = override.HTTPStatusCode
+		}
+	}
+	return ew, nil
+}
+
+func newServiceRoutes(ef *protocgenghe.EndpointFile, es *protocgenghe.EndpointService, resolver *dynamicpb.Types) (*serviceRoutes, error) {
+	pathContainer := protocgenghe.NewEndpointPathContainer()
+	es.ExportEndpointPaths(pathContainer)
+	if err := pathContainer.Err(); err != nil {
+		return nil, err
+	}
+	endpointPaths := pathContainer.SortedEndpointPaths()
+	if len(endpointPaths) == 0 {
+		return nil, nil
+	}
+	errorWriter, err := newErrorWriter(ef, es)
+	if err != nil {
+		return nil, err
+	}
+	svc := &serviceRoutes{
+		routeRoot:   protocgenghe.NewURLRouteRadixRoot(),
+		errorWriter: errorWriter,
+		handlers:    make(map[*protocgenghe.EndpointURLPathMethod]*routeHandler),
+	}
+	if err = svc.routeRoot.ImportEndpointPaths(endpointPaths); err != nil {
+		return nil, err
+	}
+	for _, endpointPath := range endpointPaths {
+		for _, ref := range endpointPath.URLPathMethods() {
+			h, err := newRouteHandler(ref, resolver)
+			if err != nil {
+				return nil, fmt.Errorf("[%s] %s: %w", ref.HTTPMethod, string(ref.URLPath.RawPath), err)
+			}
+			svc.handlers[ref] = h
+		}
+	}
+	return svc, nil
+}
+
+// routeTable is the set of routes loaded from one descriptor set.
+type routeTable struct {
+	services []*serviceRoutes
+}
+
+func newRouteTable(fds *descriptorpb.FileDescriptorSet, files *protoregistry.Files) (*routeTable, error) {
+	gen, err := protocgenghe.NewPluginWithFileDescriptorSet(fds, nil)
+	if err != nil {
+		return nil, err
+	}
+	resolver := dynamicpb.NewTypes(files)
+	rt := &routeTable{}
+	for _, file := range gen.Files {
+		ef := protocgenghe.LoadEndpointFile(file)
+		for _, es := range ef.Services {
+			svc, err := newServiceRoutes(ef, es, resolver)
+			if err != nil {
+				return nil, fmt.Errorf("%s: service %s: %w", file.Desc.Path(), es.DescRef.GoName, err)
+			}
+			if svc != nil {
+				rt.services = append(rt.services, svc)
+			}
+		}
+	}
+	return rt, nil
+}
+
+// fileDescriptorSetOf collects files into FileDescriptorSet. Files are
+// ordered by path with imported files placed before importing files.
+func fileDescriptorSetOf(files *protoregistry.Files) *descriptorpb.FileDescriptorSet {
+	var fileDescs []protoreflect.FileDescriptor
+	files.RangeFiles(func(fileDesc protoreflect.FileDescriptor) bool {
+		fileDescs = append(fileDescs, fileDesc)
+		return true
+	})
+	sort.Slice(fileDescs, func(i, j int) bool {
+		return fileDescs[i].Path() < fileDescs[j].Path()
+	})
+	fds := &descriptorpb.FileDescriptorSet{}
+	added := make(map[string]struct{})
+	var addFile func(fileDesc protoreflect.FileDescriptor)
+	addFile = func(fileDesc protoreflect.FileDescriptor) {
+		if _, ok := added[fileDesc.Path()]; ok {
+			return
+		}
+		added[fileDesc.Path()] = struct{}{}
+		imports := fileDesc.Imports()
+		for idx := 0; idx < imports.Len(); idx++ {
+			addFile(imports.Get(idx).FileDescriptor)
+		}
+		fds.File = append(fds.File, protodesc.ToFileDescriptorProto(fileDesc))
+	}
+	for _, fileDesc := range fileDescs {
+		addFile(fileDesc)
+	}
+	return fds
+}
+
+// Gateway serves HTTP endpoints of services in loaded descriptors and
+// invokes methods through gRPC client connection.
+//
+// Descriptors can be loaded again while serving requests. Requests in
+// flight keep using the routes they started with.
+type Gateway struct {
+	// ErrorWriter writes responses of requests which do not match any
+	// service. ghert.DefaultErrorWriter is used when nil.
+	ErrorWriter *ghert.ErrorWriter
+
+	cc     grpc.ClientConnInterface
+	routes atomic.Pointer[routeTable]
+}
+
+// NewGateway creates Gateway which invokes methods over cc.
+// Requests are responded with 404 until descriptors are loaded.
+func NewGateway(cc grpc.ClientConnInterface) *Gateway {
+	return &Gateway{
+		cc: cc,
+	}
+}
+
+// LoadFileDescriptorSet replaces routes with endpoints of services in fds.
+// The set must include all imported files. Routes are kept unchanged when
+// error occurs.
+func (gw *Gateway) LoadFileDescriptorSet(fds *descriptorpb.FileDescriptorSet) error {
+	files, err := protodesc.NewFiles(fds)
+	if err != nil {
+		return err
+	}
+	rt, err := newRouteTable(fds, files)
+	if err != nil {
+		return err
+	}
+	gw.routes.Store(rt)
+	return nil
+}
+
+// LoadFiles replaces routes with endpoints of services in files.
+// Routes are kept unchanged when error occurs.
+func (gw *Gateway) LoadFiles(files *protoregistry.Files) error {
+	rt, err := newRouteTable(fileDescriptorSetOf(files), files)
+	if err != nil {
+		return err
+	}
+	gw.routes.Store(rt)
+	return nil
+}
+
+// LoadDescriptorSetFile replaces routes with endpoints of services in file
+// written by `protoc --include_imports --descriptor_set_out`.
+// Routes are kept unchanged when error occurs.
+func (gw *Gateway) LoadDescriptorSetFile(filePath string) error {
+	fds, err := protocgenghe.ReadFileDescriptorSet(filePath)
+	if err != nil {
+		return err
+	}
+	return gw.LoadFileDescriptorSet(fds)
+}
+
+func (gw *Gateway) writeRouteNotFound(w http.ResponseWriter, r *http.Request) {
+	ew := gw.ErrorWriter
+	if ew == nil {
+		ew = ghert.DefaultErrorWriter
+	}
+	ew.WriteRouteNotFound(w, r)
+}
+
+// ServeHTTP implements http.Handler interface. Services are tried in the
+// order of loaded files and the first one with matching URL path serves
+// the request.
+func (gw *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rt := gw.routes.Load()
+	if rt == nil {
+		gw.writeRouteNotFound(w, r)
+		return
+	}
+	method := []byte(r.Method)
+	path := []byte(r.URL.EscapedPath())
+	for _, svc := range rt.services {
+		m := svc.routeRoot.Match(method, path)
+		if m == nil {
+			continue
+		}
+		if m.URLPathMethod == nil {
+			svc.errorWriter.WriteMethodNotAllowed(w, r, m.EndpointPath.MethodNotAllowedRouteIdent(), m.EndpointPath.AllowMethods())
+			return
+		}
+		svc.handlers[m.URLPathMethod].serve(gw.cc, svc.errorWriter, w, r, m.Captures)
+		return
+	}
+	gw.writeRouteNotFound(w, r)
+}
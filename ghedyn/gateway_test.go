@@ -0,0 +1,460 @@
+package ghedyn_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/yinyin/protoc-gen-go-grpc-http-endpoint/ghedyn"
+	"github.com/yinyin/protoc-gen-go-grpc-http-endpoint/ghegen"
+	"github.com/yinyin/protoc-gen-go-grpc-http-endpoint/internal/testfixture"
+)
+
+type routeServer struct {
+	testfixture.UnimplementedRouteServiceServer
+}
+
+func routeReply(method string, in *testfixture.RouteRequest) (*testfixture.RouteReply, error) {
+	reply := &testfixture.RouteReply{
+		Method: method,
+	}
+	for _, v := range []string{in.Id, in.Path, in.Name, in.Tag} {
+		if v != "" {
+			reply.Values = append(reply.Values, v)
+		}
+	}
+	return reply, nil
+}
+
+func (routeServer) GetItem(ctx context.Context, in *testfixture.RouteRequest) (*testfixture.RouteReply, error) {
+	if in.Id == "private" {
+		return nil, status.Error(codes.PermissionDenied, "private item")
+	}
+	return routeReply("GetItem", in)
+}
+
+func (routeServer) DeleteItem(ctx context.Context, in *testfixture.RouteRequest) (*testfixture.RouteReply, error) {
+	return routeReply("DeleteItem", in)
+}
+
+func (routeServer) GetBook(ctx context.Context, in *testfixture.RouteRequest) (*testfixture.RouteReply, error) {
+	return routeReply("GetBook", in)
+}
+
+func (routeServer) GetShelf(ctx context.Context, in *testfixture.RouteRequest) (*testfixture.RouteReply, error) {
+	return routeReply("GetShelf", in)
+}
+
+type handlerServer struct {
+	testfixture.UnimplementedHandlerServiceServer
+}
+
+func handlerReply(method string, in *testfixture.HandlerRequest) (*testfixture.HandlerReply, error) {
+	return &testfixture.HandlerReply{
+		Method:  method,
+		Request: in,
+	}, nil
+}
+
+func (handlerServer) CreateShelf(ctx context.Context, in *testfixture.HandlerRequest) (*testfixture.HandlerReply, error) {
+	return handlerReply("CreateShelf", in)
+}
+
+func (handlerServer) GetShelf(ctx context.Context, in *testfixture.HandlerRequest) (*testfixture.HandlerReply, error) {
+	if in.Id == "missing" {
+		return nil, status.Error(codes.NotFound, "missing shelf")
+	}
+	in.Shelf = &testfixture.Shelf{Name: "shelf-" + in.Id}
+	return handlerReply("GetShelf", in)
+}
+
+func (handlerServer) ListShelves(ctx context.Context, in *testfixture.HandlerRequest) (*testfixture.HandlerReply, error) {
+	return handlerReply("ListShelves", in)
+}
+
+func (handlerServer) DeleteShelf(ctx context.Context, in *testfixture.HandlerRequest) (*testfixture.HandlerReply, error) {
+	return handlerReply("DeleteShelf", in)
+}
+
+// fileDescriptorSetOf collects fileDescs and their imports with imported
+// files placed before importing files.
+func fileDescriptorSetOf(fileDescs ...protoreflect.FileDescriptor) *descriptorpb.FileDescriptorSet {
+	fds := &descriptorpb.FileDescriptorSet{}
+	added := make(map[string]struct{})
+	var addFile func(fileDesc protoreflect.FileDescriptor)
+	addFile = func(fileDesc protoreflect.FileDescriptor) {
+		if _, ok := added[fileDesc.Path()]; ok {
+			return
+		}
+		added[fileDesc.Path()] = struct{}{}
+		imports := fileDesc.Imports()
+		for idx := 0; idx < imports.Len(); idx++ {
+			addFile(imports.Get(idx).FileDescriptor)
+		}
+		fds.File = append(fds.File, protodesc.ToFileDescriptorProto(fileDesc))
+	}
+	for _, fileDesc := range fileDescs {
+		addFile(fileDesc)
+	}
+	return fds
+}
+
+// newGateway creates Gateway which invokes fixture servers over bufconn.
+func newGateway(t *testing.T) *ghedyn.Gateway {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	testfixture.RegisterRouteServiceServer(srv, routeServer{})
+	testfixture.RegisterHandlerServiceServer(srv, handlerServer{})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	cc, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cc.Close() })
+	return ghedyn.NewGateway(cc)
+}
+
+func newFixtureGateway(t *testing.T) *ghedyn.Gateway {
+	gw := newGateway(t)
+	fds := fileDescriptorSetOf(
+		testfixture.File_internal_testfixture_route_proto,
+		testfixture.File_internal_testfixture_handler_proto)
+	if err := gw.LoadFileDescriptorSet(fds); err != nil {
+		t.Fatal(err)
+	}
+	return gw
+}
+
+func serveGateway(gw *ghedyn.Gateway, method, target, contentType, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, req)
+	return rec
+}
+
+func checkRouteReply(t *testing.T, rec *httptest.ResponseRecorder, method string, values []string) {
+	t.Helper()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status code %d: %s", rec.Code, rec.Body.String())
+	}
+	reply := &testfixture.RouteReply{}
+	if err := protojson.Unmarshal(rec.Body.Bytes(), reply); err != nil {
+		t.Fatalf("cannot decode reply %q: %v", rec.Body.String(), err)
+	}
+	if (reply.Method != method) || !reflect.DeepEqual(reply.Values, values) {
+		t.Errorf("reply %s %q, want %s %q", reply.Method, reply.Values, method, values)
+	}
+}
+
+func checkHandlerReply(t *testing.T, rec *httptest.ResponseRecorder, method string, request *testfixture.HandlerRequest) {
+	t.Helper()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status code %d: %s", rec.Code, rec.Body.String())
+	}
+	reply := &testfixture.HandlerReply{}
+	if err := protojson.Unmarshal(rec.Body.Bytes(), reply); err != nil {
+		t.Fatalf("cannot decode reply %q: %v", rec.Body.String(), err)
+	}
+	if reply.Method != method {
+		t.Errorf("method %s, want %s", reply.Method, method)
+	}
+	if !proto.Equal(reply.Request, request) {
+		t.Errorf("request %v, want %v", reply.Request, request)
+	}
+}
+
+func TestGatewayRoutes(t *testing.T) {
+	gw := newFixtureGateway(t)
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		statusCode int
+		rpcName    string
+		values     []string
+		allow      string
+	}{
+		{"capture", http.MethodGet, "/fixture/items/a1", http.StatusOK, "GetItem", []string{"a1"}, ""},
+		{"escaped capture", http.MethodGet, "/fixture/items/a%2F1", http.StatusOK, "GetItem", []string{"a/1"}, ""},
+		{"status error", http.MethodGet, "/fixture/items/private", http.StatusForbidden, "", nil, ""},
+		{"other method", http.MethodDelete, "/fixture/items/a1", http.StatusOK, "DeleteItem", []string{"a1"}, ""},
+		{"method not allowed", http.MethodPut, "/fixture/items/a1", http.StatusMethodNotAllowed, "", nil, "GET, DELETE"},
+		{"unknown path", http.MethodGet, "/other/items/a1", http.StatusNotFound, "", nil, ""},
+		{"multi-segment capture with query", http.MethodGet, "/fixture/books/a/b?tag=t%201", http.StatusOK, "GetBook", []string{"a/b", "t 1"}, ""},
+		{"rule template", http.MethodGet, "/v1/shelves/s1", http.StatusOK, "GetShelf", []string{"shelves/s1"}, ""},
+		{"rule template mismatch", http.MethodGet, "/v1/authors/a1", http.StatusNotFound, "", nil, ""},
+		{"unimplemented method", http.MethodGet, "/fixture/items/latest", http.StatusNotImplemented, "", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveGateway(gw, tt.method, tt.target, "", "")
+			if tt.statusCode == http.StatusOK {
+				checkRouteReply(t, rec, tt.rpcName, tt.values)
+				return
+			}
+			if rec.Code != tt.statusCode {
+				t.Fatalf("status code %d, want %d: %s", rec.Code, tt.statusCode, rec.Body.String())
+			}
+			if allow := rec.Header().Get("Allow"); allow != tt.allow {
+				t.Errorf("Allow %q, want %q", allow, tt.allow)
+			}
+		})
+	}
+}
+
+func TestGatewayDecodeInput(t *testing.T) {
+	gw := newFixtureGateway(t)
+	rec := serveGateway(gw, http.MethodPost, "/handler/shelves/s%201?note=100%2541&tags=a&tags=b&content=YQ%3D%3D", "application/json", `{"name":"n1","size":3,"other":1}`)
+	checkHandlerReply(t, rec, "CreateShelf", &testfixture.HandlerRequest{
+		Id:      "s 1",
+		Shelf:   &testfixture.Shelf{Name: "n1", Size: 3},
+		Note:    "100%41",
+		Tags:    []string{"a", "b"},
+		Content: []byte("a"),
+	})
+	rec = serveGateway(gw, http.MethodGet, "/handler/shelves?shelf.name=n%201&shelf.size=2", "", "")
+	checkHandlerReply(t, rec, "ListShelves", &testfixture.HandlerRequest{
+		Shelf: &testfixture.Shelf{Name: "n 1", Size: 2},
+	})
+	for _, target := range []string{
+		"/handler/shelves?shelf.size=x",
+		"/handler/shelves?note=x&note=y",
+		"/handler/shelves?content=YQ%253D%253D",
+	} {
+		if rec = serveGateway(gw, http.MethodGet, target, "", ""); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status code %d, want %d: %s", target, rec.Code, http.StatusBadRequest, rec.Body.String())
+		}
+	}
+}
+
+func TestGatewayResponse(t *testing.T) {
+	gw := newFixtureGateway(t)
+	rec := serveGateway(gw, http.MethodGet, "/handler/shelves/s1", "", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status code %d: %s", rec.Code, rec.Body.String())
+	}
+	shelf := &testfixture.Shelf{}
+	if err := protojson.Unmarshal(rec.Body.Bytes(), shelf); err != nil {
+		t.Fatal(err)
+	}
+	if shelf.Name != "shelf-s1" {
+		t.Errorf("response body %q, want shelf of request", rec.Body.String())
+	}
+	if rec = serveGateway(gw, http.MethodDelete, "/handler/shelves/s1", "", ""); rec.Code != http.StatusNoContent {
+		t.Errorf("success status %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	if rec = serveGateway(gw, http.MethodGet, "/handler/shelves/missing", "", ""); rec.Code != http.StatusGone {
+		t.Errorf("overridden status %d, want %d: %s", rec.Code, http.StatusGone, rec.Body.String())
+	}
+	rec = serveGateway(gw, http.MethodPost, "/handler/shelves/s1/upload", "application/x-www-form-urlencoded", "id=s2")
+	if rec.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("form request: status code %d, want %d", rec.Code, http.StatusUnsupportedMediaType)
+	}
+}
+
+func TestGatewayUnsupportedRoutes(t *testing.T) {
+	gw := newFixtureGateway(t)
+	tests := []struct {
+		name   string
+		method string
+		target string
+	}{
+		{"extra endpoint", http.MethodGet, "/handler/ping/p1"},
+		{"setter binding", http.MethodGet, "/handler/notes/n1"},
+		{"status code extraction", http.MethodPost, "/handler/shelves/s1/move"},
+		{"server streaming", http.MethodGet, "/handler/shelves/s1/watch"},
+		{"client streaming", http.MethodPost, "/handler/shelves/s1/collect"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveGateway(gw, tt.method, tt.target, "application/json", "{}")
+			if rec.Code != http.StatusNotImplemented {
+				t.Fatalf("status code %d, want %d: %s", rec.Code, http.StatusNotImplemented, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), "not supported by dynamic gateway") {
+				t.Errorf("unexpected error body: %s", rec.Body.String())
+			}
+		})
+	}
+}
+
+// oneofFileDescriptorSet builds descriptors of service which maps request
+// body into a field of oneof.
+func oneofFileDescriptorSet() *descriptorpb.FileDescriptorSet {
+	methodOpts := &descriptorpb.MethodOptions{}
+	proto.SetExtension(methodOpts, ghegen.E_Endpoint, &ghegen.GHEMethodOptions{
+		Post: "choices/{id}",
+		Body: "shelf",
+	})
+	serviceOpts := &descriptorpb.ServiceOptions{}
+	proto.SetExtension(serviceOpts, ghegen.E_Base, &ghegen.GHEServiceOptions{
+		Path: "oneof",
+	})
+	file := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("oneof.proto"),
+		Package:    proto.String("ghe.oneof"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"internal/testfixture/handler.proto", "ghe_options.proto"},
+		Options: &descriptorpb.FileOptions{
+			GoPackage: proto.String("example.com/oneof"),
+		},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("ChoiceRequest"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("id"),
+				Number:   proto.Int32(1),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				JsonName: proto.String("id"),
+			}, {
+				Name:       proto.String("shelf"),
+				Number:     proto.Int32(2),
+				Label:      descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:       descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+				TypeName:   proto.String(".ghe.fixture.Shelf"),
+				JsonName:   proto.String("shelf"),
+				OneofIndex: proto.Int32(0),
+			}},
+			OneofDecl: []*descriptorpb.OneofDescriptorProto{{
+				Name: proto.String("choice"),
+			}},
+		}},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name:    proto.String("ChoiceService"),
+			Options: serviceOpts,
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("Choose"),
+				InputType:  proto.String(".ghe.oneof.ChoiceRequest"),
+				OutputType: proto.String(".ghe.fixture.HandlerReply"),
+				Options:    methodOpts,
+			}},
+		}},
+	}
+	fds := fileDescriptorSetOf(testfixture.File_internal_testfixture_handler_proto)
+	fds.File = append(fds.File, file)
+	return fds
+}
+
+func TestGatewayRejectOneofFieldPath(t *testing.T) {
+	gw := newGateway(t)
+	if err := gw.LoadFileDescriptorSet(oneofFileDescriptorSet()); err != nil {
+		t.Fatal(err)
+	}
+	rec := serveGateway(gw, http.MethodPost, "/oneof/choices/c1", "application/json", `{"name":"n1"}`)
+	if rec.Code != http.StatusNotImplemented {
+		t.Fatalf("status code %d, want %d: %s", rec.Code, http.StatusNotImplemented, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), "oneof field is not supported in field path") {
+		t.Errorf("unexpected error body: %s", rec.Body.String())
+	}
+}
+
+func TestGatewayLoadDescriptorSetFile(t *testing.T) {
+	gw := newGateway(t)
+	if rec := serveGateway(gw, http.MethodGet, "/fixture/items/a1", "", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("before loading: status code %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	buf, err := proto.Marshal(fileDescriptorSetOf(testfixture.File_internal_testfixture_route_proto))
+	if err != nil {
+		t.Fatal(err)
+	}
+	filePath := filepath.Join(t.TempDir(), "route.pb")
+	if err = os.WriteFile(filePath, buf, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err = gw.LoadDescriptorSetFile(filePath); err != nil {
+		t.Fatal(err)
+	}
+	checkRouteReply(t, serveGateway(gw, http.MethodGet, "/fixture/items/a1", "", ""), "GetItem", []string{"a1"})
+	if err = gw.LoadDescriptorSetFile(filepath.Join(t.TempDir(), "missing.pb")); err == nil {
+		t.Error("expecting error on loading missing file")
+	}
+	checkRouteReply(t, serveGateway(gw, http.MethodGet, "/fixture/items/a1", "", ""), "GetItem", []string{"a1"})
+}
+
+func TestGatewayReload(t *testing.T) {
+	gw := newGateway(t)
+	routeFDS := fileDescriptorSetOf(testfixture.File_internal_testfixture_route_proto)
+	fullFDS := fileDescriptorSetOf(
+		testfixture.File_internal_testfixture_route_proto,
+		testfixture.File_internal_testfixture_handler_proto)
+	if err := gw.LoadFileDescriptorSet(routeFDS); err != nil {
+		t.Fatal(err)
+	}
+	if rec := serveGateway(gw, http.MethodGet, "/handler/shelves", "", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("before reload: status code %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	// Descriptor set without imported files is rejected and routes are kept.
+	brokenFDS := &descriptorpb.FileDescriptorSet{
+		File: fullFDS.File[len(fullFDS.File)-1:],
+	}
+	if err := gw.LoadFileDescriptorSet(brokenFDS); err == nil {
+		t.Error("expecting error on loading descriptor set without imports")
+	}
+	checkRouteReply(t, serveGateway(gw, http.MethodGet, "/fixture/items/a1", "", ""), "GetItem", []string{"a1"})
+	// Requests served while routes are swapped see either set of routes.
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for idx := 0; idx < 4; idx++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				rec := serveGateway(gw, http.MethodGet, "/fixture/items/a1", "", "")
+				if rec.Code != http.StatusOK {
+					t.Errorf("during reload: status code %d: %s", rec.Code, rec.Body.String())
+					return
+				}
+			}
+		}()
+	}
+	for idx := 0; idx < 20; idx++ {
+		fds := routeFDS
+		if (idx % 2) == 0 {
+			fds = fullFDS
+		}
+		if err := gw.LoadFileDescriptorSet(fds); err != nil {
+			t.Error(err)
+			break
+		}
+	}
+	close(stop)
+	wg.Wait()
+	if err := gw.LoadFileDescriptorSet(fullFDS); err != nil {
+		t.Fatal(err)
+	}
+	checkHandlerReply(t, serveGateway(gw, http.MethodGet, "/handler/shelves?note=x", "", ""), "ListShelves", &testfixture.HandlerRequest{
+		Note: "x",
+	})
+}
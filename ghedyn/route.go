@@ -0,0 +1,314 @@
+package ghedyn
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	protocgenghe "github.com/yinyin/protoc-gen-go-grpc-http-endpoint"
+	"github.com/yinyin/protoc-gen-go-grpc-http-endpoint/ghegen"
+	"github.com/yinyin/protoc-gen-go-grpc-http-endpoint/ghert"
+)
+
+// encodingValues maps values of encodings option to runtime encodings.
+var encodingValues = map[string]ghert.Encoding{
+	"json":      ghert.EncodingJSON,
+	"protobuf":  ghert.EncodingProtobuf,
+	"prototext": ghert.EncodingProtoText,
+}
+
+// routeHandler serves requests of one HTTP method on one URL path.
+type routeHandler struct {
+	ref        *protocgenghe.EndpointURLPathMethod
+	routeIdent string
+
+	// unsupportedErr is reported for routes which need generated code,
+	// ie. custom handler functions, setters, streaming or status code
+	// extraction.
+	unsupportedErr error
+
+	fullMethodName string
+	inputDesc      protoreflect.MessageDescriptor
+	outputDesc     protoreflect.MessageDescriptor
+
+	codec          *ghert.MessageCodec
+	encodings      ghert.Encoding
+	successStatus  int
+	haveBody       bool
+	queryFieldRefs map[string]*protocgenghe.CaptureDestFieldRef
+}
+
+func newUnsupportedError(reason string) error {
+	return &ghert.HTTPError{
+		StatusCode: http.StatusNotImplemented,
+		Message:    "not supported by dynamic gateway: " + reason,
+	}
+}
+
+// checkSupported checks if the route can be served without generated code.
+func checkSupported(ref *protocgenghe.EndpointURLPathMethod) error {
+	em := ref.MethodRef
+	switch {
+	case em.IsExtraEndpoint:
+		return newUnsupportedError("extra endpoint")
+	case ref.HTTPMethod == http.MethodHead:
+		return newUnsupportedError("HEAD handler function")
+	case ref.HTTPMethod == http.MethodOptions:
+		return newUnsupportedError("OPTIONS handler function")
+	case em.IsStreamingClient() || em.IsStreamingServer():
+		return newUnsupportedError("streaming method")
+	case em.Options.GoExtractHttpStatusCode != "":
+		return newUnsupportedError("go_extract_http_status_code option")
+	}
+	for _, part := range ref.URLPath.Parts {
+		if part.PartType != protocgenghe.URLPathPartCapture {
+			continue
+		}
+		if part.DestFieldRef == nil {
+			return newUnsupportedError("capture without field destination: [" + string(part.RawPathPart) + "]")
+		}
+		if err := checkValueFieldRef(part.DestFieldRef); err != nil {
+			return newUnsupportedError(err.Error())
+		}
+	}
+	if em.BodyFieldRef != nil {
+		if err := checkFieldPath(em.BodyFieldRef, len(em.BodyFieldRef.PathDescRefs)); err != nil {
+			return newUnsupportedError(err.Error())
+		}
+	}
+	for _, binding := range em.ParamBindings {
+		if binding.Part.DestFieldRef == nil {
+			return newUnsupportedError("binding without field destination: [" + binding.RawBinding + "]")
+		}
+		if err := checkValueFieldRef(binding.Part.DestFieldRef); err != nil {
+			return newUnsupportedError(err.Error())
+		}
+	}
+	return nil
+}
+
+func newMessageCodec(opts *ghegen.GHEJSONOptions, resolver *dynamicpb.Types) *ghert.MessageCodec {
+	return &ghert.MessageCodec{
+		JSONMarshal: protojson.MarshalOptions{
+			UseProtoNames:   opts.GetUseProtoNames(),
+			EmitUnpopulated: opts.GetEmitUnpopulated(),
+			UseEnumNumbers:  opts.GetUseEnumNumbers(),
+			Resolver:        resolver,
+		},
+		JSONUnmarshal: protojson.UnmarshalOptions{
+			DiscardUnknown: opts.GetDiscardUnknown(),
+			Resolver:       resolver,
+		},
+	}
+}
+
+func newRouteHandler(ref *protocgenghe.EndpointURLPathMethod, resolver *dynamicpb.Types) (*routeHandler, error) {
+	em := ref.MethodRef
+	h := &routeHandler{
+		ref:        ref,
+		routeIdent: em.RouteIdentTail,
+	}
+	if h.unsupportedErr = checkSupported(ref); h.unsupportedErr != nil {
+		return h, nil
+	}
+	methodDesc := em.DescRef.Desc
+	h.fullMethodName = "/" + string(methodDesc.Parent().FullName()) + "/" + string(methodDesc.Name())
+	h.inputDesc = methodDesc.Input()
+	h.outputDesc = methodDesc.Output()
+	h.codec = newMessageCodec(&em.JSONOptions, resolver)
+	for _, encoding := range em.Options.Encodings {
+		h.encodings |= encodingValues[encoding]
+	}
+	if h.encodings == 0 {
+		h.encodings = ghert.AllEncodings
+	}
+	h.successStatus = http.StatusOK
+	if em.Options.SuccessStatus != 0 {
+		h.successStatus = int(em.Options.SuccessStatus)
+	}
+	h.haveBody = em.HaveRequestBody(ref.HTTPMethod)
+	if em.Options.BindQuery {
+		h.queryFieldRefs = make(map[string]*protocgenghe.CaptureDestFieldRef)
+		for _, fieldName := range ref.QueryBindFieldNames() {
+			fieldRef, err := em.FindInputFieldRef(fieldName)
+			if err != nil {
+				return nil, err
+			}
+			h.queryFieldRefs[fieldName] = fieldRef
+		}
+	}
+	return h, nil
+}
+
+func (h *routeHandler) decodeRequestBody(r *http.Request, in protoreflect.Message) error {
+	em := h.ref.MethodRef
+	if em.Options.AcceptForm && ghert.IsFormRequest(r) {
+		return &ghert.HTTPError{
+			StatusCode: http.StatusUnsupportedMediaType,
+			Message:    "form request is not supported by dynamic gateway",
+		}
+	}
+	target := in
+	if em.BodyFieldRef != nil {
+		var err error
+		if target, err = mutableFieldMessage(in, em.BodyFieldRef, len(em.BodyFieldRef.PathDescRefs)); err != nil {
+			return err
+		}
+	}
+	return h.codec.DecodeRequest(r, h.encodings, target.Interface())
+}
+
+func (h *routeHandler) decodeQuery(r *http.Request, in protoreflect.Message) error {
+	em := h.ref.MethodRef
+	if (len(h.queryFieldRefs) == 0) && !em.Options.RejectUnknownQuery {
+		return nil
+	}
+	query, err := ghert.ParseQuery(r)
+	if err != nil {
+		return err
+	}
+	for key, values := range query {
+		fieldRef, ok := h.queryFieldRefs[key]
+		if !ok {
+			if em.Options.RejectUnknownQuery {
+				return ghert.NewDecodeError(key, values[0], ghert.ErrUnknownQueryParameter)
+			}
+			continue
+		}
+		if !fieldRef.DescRef.Desc.IsList() && (len(values) > 1) {
+			return ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues)
+		}
+		for _, value := range values {
+			if err = decodeFieldValue(in, fieldRef, key, value, false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (h *routeHandler) decodeParamBindings(r *http.Request, in protoreflect.Message) error {
+	for _, binding := range h.ref.MethodRef.ParamBindings {
+		var values []string
+		if binding.Source == protocgenghe.RequestParamCookie {
+			values = ghert.CookieValues(r, binding.Name)
+		} else {
+			values = ghert.HeaderValues(r, binding.Name)
+		}
+		fieldRef := binding.Part.DestFieldRef
+		if !fieldRef.DescRef.Desc.IsList() && (len(values) > 1) {
+			values = values[:1]
+		}
+		for _, value := range values {
+			if err := decodeFieldValue(in, fieldRef, binding.DisplayName(), value, false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (h *routeHandler) decodeCaptures(captures [][]byte, in protoreflect.Message) error {
+	captureIndex := 0
+	for _, part := range h.ref.URLPath.Parts {
+		if part.PartType != protocgenghe.URLPathPartCapture {
+			continue
+		}
+		if captureIndex >= len(captures) {
+			return errors.New("missing capture #" + strconv.FormatInt(int64(captureIndex), 10))
+		}
+		value := string(captures[captureIndex])
+		captureIndex++
+		if err := decodeFieldValue(in, part.DestFieldRef, part.ParamName(), value, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchCaptureTemplates checks captured values against templates of
+// google.api.http variables.
+func (h *routeHandler) matchCaptureTemplates(captures [][]byte) bool {
+	captureTemplates := h.ref.MethodRef.CaptureTemplates
+	if len(captureTemplates) == 0 {
+		return true
+	}
+	captureIndex := 0
+	for _, part := range h.ref.URLPath.Parts {
+		if part.PartType != protocgenghe.URLPathPartCapture {
+			continue
+		}
+		if captureIndex >= len(captures) {
+			return false
+		}
+		value := string(captures[captureIndex])
+		captureIndex++
+		if tmpl, ok := captureTemplates[part.DestFieldName]; ok && !ghert.MatchPathTemplate(value, tmpl) {
+			return false
+		}
+	}
+	return true
+}
+
+// decodeInput fills input message from request body, query, headers,
+// cookies and captures in the same order as generated handler.
+func (h *routeHandler) decodeInput(r *http.Request, captures [][]byte, in protoreflect.Message) error {
+	if h.haveBody {
+		if err := h.decodeRequestBody(r, in); err != nil {
+			return err
+		}
+	}
+	if err := h.decodeQuery(r, in); err != nil {
+		return err
+	}
+	if err := h.decodeParamBindings(r, in); err != nil {
+		return err
+	}
+	return h.decodeCaptures(captures, in)
+}
+
+// responseBody returns the message to be serialized as response body.
+func (h *routeHandler) responseBody(out protoreflect.Message) proto.Message {
+	if fieldRef := h.ref.MethodRef.ResponseBodyFieldRef; fieldRef != nil {
+		for _, fieldDescRef := range fieldRef.PathDescRefs {
+			out = out.Get(fieldDescRef.Desc).Message()
+		}
+	}
+	return out.Interface()
+}
+
+func (h *routeHandler) serve(cc grpc.ClientConnInterface, ew *ghert.ErrorWriter, w http.ResponseWriter, r *http.Request, captures [][]byte) {
+	if h.unsupportedErr != nil {
+		ew.WriteRouteError(w, r, h.routeIdent, h.unsupportedErr)
+		return
+	}
+	if !h.matchCaptureTemplates(captures) {
+		ew.WriteRouteNotFound(w, r)
+		return
+	}
+	if maxUploadSize := h.ref.MethodRef.Options.MaxUploadSize; (maxUploadSize > 0) && h.haveBody {
+		r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	}
+	respEncoding, err := ghert.NegotiateEncoding(r, h.encodings)
+	if err != nil {
+		ew.WriteRouteError(w, r, h.routeIdent, err)
+		return
+	}
+	in := dynamicpb.NewMessage(h.inputDesc)
+	if err = h.decodeInput(r, captures, in); err != nil {
+		ew.WriteRouteError(w, r, h.routeIdent, err)
+		return
+	}
+	out := dynamicpb.NewMessage(h.outputDesc)
+	if err = cc.Invoke(r.Context(), h.fullMethodName, in, out); err != nil {
+		ew.WriteRouteError(w, r, h.routeIdent, err)
+		return
+	}
+	h.codec.WriteResponse(w, r, ew, h.routeIdent, respEncoding, h.successStatus, h.responseBody(out))
+}
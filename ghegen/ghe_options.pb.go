@@ -32,6 +32,16 @@ type GHEFileOptions struct {
 	PathNamingConvention string            `protobuf:"bytes,1,opt,name=path_naming_convention,json=pathNamingConvention,proto3" json:"path_naming_convention,omitempty"`
 	CommonInitialisms    []string          `protobuf:"bytes,2,rep,name=common_initialisms,json=commonInitialisms,proto3" json:"common_initialisms,omitempty"`
 	NamingOverride       map[string]string `protobuf:"bytes,3,rep,name=naming_override,json=namingOverride,proto3" json:"naming_override,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Override HTTP status codes of gRPC status codes in error responses.
+	// Keys are gRPC code names in upper snake case, ie. `NOT_FOUND`.
+	HttpStatusCodes map[string]int32 `protobuf:"bytes,4,rep,name=http_status_codes,json=httpStatusCodes,proto3" json:"http_status_codes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Encoding of error response body.
+	// Acceptable values: `status_json` (default) for JSON object carrying
+	// code, message and details of gRPC status, or `problem_json` for
+	// RFC 7807 `application/problem+json` document.
+	ErrorEncoding string `protobuf:"bytes,5,opt,name=error_encoding,json=errorEncoding,proto3" json:"error_encoding,omitempty"`
+	// Options of JSON encoding. Can be overridden by service and method options.
+	Json *GHEJSONOptions `protobuf:"bytes,6,opt,name=json,proto3" json:"json,omitempty"`
 }
 
 func (x *GHEFileOptions) Reset() {
@@ -87,6 +97,104 @@ func (x *GHEFileOptions) GetNamingOverride() map[string]string {
 	return nil
 }
 
+func (x *GHEFileOptions) GetHttpStatusCodes() map[string]int32 {
+	if x != nil {
+		return x.HttpStatusCodes
+	}
+	return nil
+}
+
+func (x *GHEFileOptions) GetErrorEncoding() string {
+	if x != nil {
+		return x.ErrorEncoding
+	}
+	return ""
+}
+
+func (x *GHEFileOptions) GetJson() *GHEJSONOptions {
+	if x != nil {
+		return x.Json
+	}
+	return nil
+}
+
+// Options of protojson marshalling and unmarshalling.
+// Fields set in narrower scope override the ones in wider scope.
+type GHEJSONOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Use proto field names instead of lowerCamelCase names in output.
+	UseProtoNames *bool `protobuf:"varint,1,opt,name=use_proto_names,json=useProtoNames,proto3,oneof" json:"use_proto_names,omitempty"`
+	// Emit fields with default values in output.
+	EmitUnpopulated *bool `protobuf:"varint,2,opt,name=emit_unpopulated,json=emitUnpopulated,proto3,oneof" json:"emit_unpopulated,omitempty"`
+	// Emit enum values as numbers instead of names in output.
+	UseEnumNumbers *bool `protobuf:"varint,3,opt,name=use_enum_numbers,json=useEnumNumbers,proto3,oneof" json:"use_enum_numbers,omitempty"`
+	// Ignore unknown fields in input.
+	DiscardUnknown *bool `protobuf:"varint,4,opt,name=discard_unknown,json=discardUnknown,proto3,oneof" json:"discard_unknown,omitempty"`
+}
+
+func (x *GHEJSONOptions) Reset() {
+	*x = GHEJSONOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ghe_options_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GHEJSONOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GHEJSONOptions) ProtoMessage() {}
+
+func (x *GHEJSONOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_ghe_options_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GHEJSONOptions.ProtoReflect.Descriptor instead.
+func (*GHEJSONOptions) Descriptor() ([]byte, []int) {
+	return file_ghe_options_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GHEJSONOptions) GetUseProtoNames() bool {
+	if x != nil && x.UseProtoNames != nil {
+		return *x.UseProtoNames
+	}
+	return false
+}
+
+func (x *GHEJSONOptions) GetEmitUnpopulated() bool {
+	if x != nil && x.EmitUnpopulated != nil {
+		return *x.EmitUnpopulated
+	}
+	return false
+}
+
+func (x *GHEJSONOptions) GetUseEnumNumbers() bool {
+	if x != nil && x.UseEnumNumbers != nil {
+		return *x.UseEnumNumbers
+	}
+	return false
+}
+
+func (x *GHEJSONOptions) GetDiscardUnknown() bool {
+	if x != nil && x.DiscardUnknown != nil {
+		return *x.DiscardUnknown
+	}
+	return false
+}
+
 type GHEServiceOptions struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -95,12 +203,16 @@ type GHEServiceOptions struct {
 	Path              string              `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
 	StrictPrefixMatch string              `protobuf:"bytes,2,opt,name=strict_prefix_match,json=strictPrefixMatch,proto3" json:"strict_prefix_match,omitempty"`
 	ExtraEndpoints    []*GHEMethodOptions `protobuf:"bytes,3,rep,name=extra_endpoints,json=extraEndpoints,proto3" json:"extra_endpoints,omitempty"`
+	// Encoding of error response body. Overrides the file option.
+	ErrorEncoding string `protobuf:"bytes,4,opt,name=error_encoding,json=errorEncoding,proto3" json:"error_encoding,omitempty"`
+	// Options of JSON encoding. Overrides the file option.
+	Json *GHEJSONOptions `protobuf:"bytes,5,opt,name=json,proto3" json:"json,omitempty"`
 }
 
 func (x *GHEServiceOptions) Reset() {
 	*x = GHEServiceOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ghe_options_proto_msgTypes[1]
+		mi := &file_ghe_options_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -113,7 +225,7 @@ func (x *GHEServiceOptions) String() string {
 func (*GHEServiceOptions) ProtoMessage() {}
 
 func (x *GHEServiceOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_ghe_options_proto_msgTypes[1]
+	mi := &file_ghe_options_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -126,7 +238,7 @@ func (x *GHEServiceOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GHEServiceOptions.ProtoReflect.Descriptor instead.
 func (*GHEServiceOptions) Descriptor() ([]byte, []int) {
-	return file_ghe_options_proto_rawDescGZIP(), []int{1}
+	return file_ghe_options_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *GHEServiceOptions) GetPath() string {
@@ -150,6 +262,20 @@ func (x *GHEServiceOptions) GetExtraEndpoints() []*GHEMethodOptions {
 	return nil
 }
 
+func (x *GHEServiceOptions) GetErrorEncoding() string {
+	if x != nil {
+		return x.ErrorEncoding
+	}
+	return ""
+}
+
+func (x *GHEServiceOptions) GetJson() *GHEJSONOptions {
+	if x != nil {
+		return x.Json
+	}
+	return nil
+}
+
 type GHEMethodOptions struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -166,21 +292,78 @@ type GHEMethodOptions struct {
 	// Handler function for HEAD method. Applies to all above paths.
 	GoHeadHandlerFunc string `protobuf:"bytes,6,opt,name=go_head_handler_func,json=goHeadHandlerFunc,proto3" json:"go_head_handler_func,omitempty"`
 	// Handler function for OPTIONS method. Applies to all above paths.
+	// Captures and bindings of header and cookie of HEAD and OPTIONS methods
+	// must go to handler parameters.
 	GoOptionsHandlerFunc string `protobuf:"bytes,7,opt,name=go_options_handler_func,json=goOptionsHandlerFunc,proto3" json:"go_options_handler_func,omitempty"`
 	// Function to extract custom HTTP status code from reply object.
 	// HTTP status code for error object will not be able to customize.
+	// Invoked as `fn(reply)` and must return an `int`. Returning 0 falls
+	// back to success_status.
 	GoExtractHttpStatusCode string `protobuf:"bytes,8,opt,name=go_extract_http_status_code,json=goExtractHttpStatusCode,proto3" json:"go_extract_http_status_code,omitempty"`
 	// Define the identifier for this endpoint.
 	// Use method name if omitted. Required for extra endpoints.
 	Ident string `protobuf:"bytes,9,opt,name=ident,proto3" json:"ident,omitempty"`
 	// Handler function name for extra endpoint.
 	GoHandlerFunc string `protobuf:"bytes,10,opt,name=go_handler_func,json=goHandlerFunc,proto3" json:"go_handler_func,omitempty"`
+	// Field path of input message to be filled with request body of
+	// POST, PUT or PATCH request. Set to `*` to map request body to
+	// the whole input message.
+	// Fields captured from URL path must not overlap with body field.
+	Body string `protobuf:"bytes,11,opt,name=body,proto3" json:"body,omitempty"`
+	// Field path of output message to be serialized as response body
+	// instead of the whole output message.
+	ResponseBody string `protobuf:"bytes,12,opt,name=response_body,json=responseBody,proto3" json:"response_body,omitempty"`
+	// Bind URL query parameters to fields of input message which are
+	// neither captured from URL path nor mapped from request body.
+	// Parameter names are field paths such as `filter.state`.
+	BindQuery bool `protobuf:"varint,13,opt,name=bind_query,json=bindQuery,proto3" json:"bind_query,omitempty"`
+	// Reject request with query parameter not bound to any field.
+	RejectUnknownQuery bool `protobuf:"varint,14,opt,name=reject_unknown_query,json=rejectUnknownQuery,proto3" json:"reject_unknown_query,omitempty"`
+	// Map request headers to input fields, setter functions or handler
+	// parameters. Each entry is `Header-Name: {capture}` where capture
+	// shares grammar of URL path capture, ie. `X-Tenant-Id: {tenant_id}`,
+	// `X-Trace-Id: {setTraceId(string)}` or `X-Count: {count int32}`.
+	Headers []string `protobuf:"bytes,15,rep,name=headers,proto3" json:"headers,omitempty"`
+	// Map request cookies in the same way as headers.
+	// Each entry is `cookie_name: {capture}`.
+	Cookies []string `protobuf:"bytes,16,rep,name=cookies,proto3" json:"cookies,omitempty"`
+	// HTTP status code of successful response, ie. 201 for creation or
+	// 204 for empty reply. Must be 2xx. Use 200 if omitted.
+	SuccessStatus int32 `protobuf:"varint,17,opt,name=success_status,json=successStatus,proto3" json:"success_status,omitempty"`
+	// Restrict encodings of request and response bodies.
+	// Acceptable values: `json`, `protobuf` and `prototext`.
+	// All encodings are allowed if omitted.
+	Encodings []string `protobuf:"bytes,18,rep,name=encodings,proto3" json:"encodings,omitempty"`
+	// Options of JSON encoding. Overrides the file and service options.
+	Json *GHEJSONOptions `protobuf:"bytes,19,opt,name=json,proto3" json:"json,omitempty"`
+	// Accept `application/x-www-form-urlencoded` and `multipart/form-data`
+	// request body. Form keys are field paths from the input message, such
+	// as `user.name` with body `user`. Only fields under the body field are
+	// bound.
+	AcceptForm bool `protobuf:"varint,20,opt,name=accept_form,json=acceptForm,proto3" json:"accept_form,omitempty"`
+	// Map file parts of multipart form to bytes fields or streaming setter
+	// functions. Each entry is `part_name: {capture}` where capture is
+	// a bytes field path or `setterFn(io.Reader)`. Setter function is
+	// invoked as `setterFn(inputMessage, partReader, args...)`.
+	FormFiles []string `protobuf:"bytes,21,rep,name=form_files,json=formFiles,proto3" json:"form_files,omitempty"`
+	// Maximum size in bytes of request body. No limit if omitted.
+	// Limits size of each incoming WebSocket message for bidirectional
+	// streaming method, defaults to 4 MiB.
+	MaxUploadSize int64 `protobuf:"varint,22,opt,name=max_upload_size,json=maxUploadSize,proto3" json:"max_upload_size,omitempty"`
+	// Format of server-streaming response. Acceptable values: `sse` for
+	// `text/event-stream` or `ndjson` for newline delimited JSON.
+	// Selected by Accept header if omitted.
+	StreamFormat string `protobuf:"bytes,23,opt,name=stream_format,json=streamFormat,proto3" json:"stream_format,omitempty"`
+	// Apply captures and bindings of header, cookie and query only to the
+	// first message of client-streaming or bidirectional streaming request.
+	// Applied to every message if not set.
+	CapturesFirstMessageOnly bool `protobuf:"varint,24,opt,name=captures_first_message_only,json=capturesFirstMessageOnly,proto3" json:"captures_first_message_only,omitempty"`
 }
 
 func (x *GHEMethodOptions) Reset() {
 	*x = GHEMethodOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ghe_options_proto_msgTypes[2]
+		mi := &file_ghe_options_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -193,7 +376,7 @@ func (x *GHEMethodOptions) String() string {
 func (*GHEMethodOptions) ProtoMessage() {}
 
 func (x *GHEMethodOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_ghe_options_proto_msgTypes[2]
+	mi := &file_ghe_options_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -206,7 +389,7 @@ func (x *GHEMethodOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GHEMethodOptions.ProtoReflect.Descriptor instead.
 func (*GHEMethodOptions) Descriptor() ([]byte, []int) {
-	return file_ghe_options_proto_rawDescGZIP(), []int{2}
+	return file_ghe_options_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GHEMethodOptions) GetGet() string {
@@ -279,6 +462,104 @@ func (x *GHEMethodOptions) GetGoHandlerFunc() string {
 	return ""
 }
 
+func (x *GHEMethodOptions) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *GHEMethodOptions) GetResponseBody() string {
+	if x != nil {
+		return x.ResponseBody
+	}
+	return ""
+}
+
+func (x *GHEMethodOptions) GetBindQuery() bool {
+	if x != nil {
+		return x.BindQuery
+	}
+	return false
+}
+
+func (x *GHEMethodOptions) GetRejectUnknownQuery() bool {
+	if x != nil {
+		return x.RejectUnknownQuery
+	}
+	return false
+}
+
+func (x *GHEMethodOptions) GetHeaders() []string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *GHEMethodOptions) GetCookies() []string {
+	if x != nil {
+		return x.Cookies
+	}
+	return nil
+}
+
+func (x *GHEMethodOptions) GetSuccessStatus() int32 {
+	if x != nil {
+		return x.SuccessStatus
+	}
+	return 0
+}
+
+func (x *GHEMethodOptions) GetEncodings() []string {
+	if x != nil {
+		return x.Encodings
+	}
+	return nil
+}
+
+func (x *GHEMethodOptions) GetJson() *GHEJSONOptions {
+	if x != nil {
+		return x.Json
+	}
+	return nil
+}
+
+func (x *GHEMethodOptions) GetAcceptForm() bool {
+	if x != nil {
+		return x.AcceptForm
+	}
+	return false
+}
+
+func (x *GHEMethodOptions) GetFormFiles() []string {
+	if x != nil {
+		return x.FormFiles
+	}
+	return nil
+}
+
+func (x *GHEMethodOptions) GetMaxUploadSize() int64 {
+	if x != nil {
+		return x.MaxUploadSize
+	}
+	return 0
+}
+
+func (x *GHEMethodOptions) GetStreamFormat() string {
+	if x != nil {
+		return x.StreamFormat
+	}
+	return ""
+}
+
+func (x *GHEMethodOptions) GetCapturesFirstMessageOnly() bool {
+	if x != nil {
+		return x.CapturesFirstMessageOnly
+	}
+	return false
+}
+
 var file_ghe_options_proto_extTypes = []protoimpl.ExtensionInfo{
 	{
 		ExtendedType:  (*descriptorpb.FileOptions)(nil),
@@ -331,7 +612,7 @@ var file_ghe_options_proto_rawDesc = []byte{
 	0x6f, 0x74, 0x6f, 0x12, 0x11, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x65, 0x6e,
 	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
-	0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x98, 0x02, 0x0a, 0x0e, 0x47, 0x48, 0x45,
+	0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9e, 0x04, 0x0a, 0x0e, 0x47, 0x48, 0x45,
 	0x46, 0x69, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x34, 0x0a, 0x16, 0x70,
 	0x61, 0x74, 0x68, 0x5f, 0x6e, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x6f, 0x6e, 0x76, 0x65,
 	0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x14, 0x70, 0x61, 0x74,
@@ -345,65 +626,137 @@ var file_ghe_options_proto_rawDesc = []byte{
 	0x45, 0x46, 0x69, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x4e, 0x61, 0x6d,
 	0x69, 0x6e, 0x67, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79,
 	0x52, 0x0e, 0x6e, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65,
-	0x1a, 0x41, 0x0a, 0x13, 0x4e, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69,
-	0x64, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
-	0x02, 0x38, 0x01, 0x22, 0xa5, 0x01, 0x0a, 0x11, 0x47, 0x48, 0x45, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74,
-	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x2e, 0x0a,
-	0x13, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x5f, 0x6d,
-	0x61, 0x74, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x73, 0x74, 0x72, 0x69,
-	0x63, 0x74, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x4c, 0x0a,
-	0x0f, 0x65, 0x78, 0x74, 0x72, 0x61, 0x5f, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73,
-	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x68, 0x74,
-	0x74, 0x70, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x47, 0x48, 0x45, 0x4d, 0x65,
-	0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0e, 0x65, 0x78, 0x74,
-	0x72, 0x61, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0xdc, 0x02, 0x0a, 0x10,
+	0x12, 0x62, 0x0a, 0x11, 0x68, 0x74, 0x74, 0x70, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f,
+	0x63, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e,
+	0x47, 0x48, 0x45, 0x46, 0x69, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x48,
+	0x74, 0x74, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x0f, 0x68, 0x74, 0x74, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43,
+	0x6f, 0x64, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x65, 0x6e,
+	0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x35, 0x0a, 0x04, 0x6a,
+	0x73, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x67, 0x72, 0x70, 0x63,
+	0x2e, 0x68, 0x74, 0x74, 0x70, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x47, 0x48,
+	0x45, 0x4a, 0x53, 0x4f, 0x4e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x04, 0x6a, 0x73,
+	0x6f, 0x6e, 0x1a, 0x41, 0x0a, 0x13, 0x4e, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x4f, 0x76, 0x65, 0x72,
+	0x72, 0x69, 0x64, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x42, 0x0a, 0x14, 0x48, 0x74, 0x74, 0x70, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x9c, 0x02, 0x0a, 0x0e, 0x47, 0x48,
+	0x45, 0x4a, 0x53, 0x4f, 0x4e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2b, 0x0a, 0x0f,
+	0x75, 0x73, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x0d, 0x75, 0x73, 0x65, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x2e, 0x0a, 0x10, 0x65, 0x6d, 0x69,
+	0x74, 0x5f, 0x75, 0x6e, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x48, 0x01, 0x52, 0x0f, 0x65, 0x6d, 0x69, 0x74, 0x55, 0x6e, 0x70, 0x6f, 0x70,
+	0x75, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x2d, 0x0a, 0x10, 0x75, 0x73, 0x65,
+	0x5f, 0x65, 0x6e, 0x75, 0x6d, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x48, 0x02, 0x52, 0x0e, 0x75, 0x73, 0x65, 0x45, 0x6e, 0x75, 0x6d, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x73, 0x88, 0x01, 0x01, 0x12, 0x2c, 0x0a, 0x0f, 0x64, 0x69, 0x73, 0x63,
+	0x61, 0x72, 0x64, 0x5f, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x48, 0x03, 0x52, 0x0e, 0x64, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x55, 0x6e, 0x6b, 0x6e,
+	0x6f, 0x77, 0x6e, 0x88, 0x01, 0x01, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x75, 0x73, 0x65, 0x5f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x65,
+	0x6d, 0x69, 0x74, 0x5f, 0x75, 0x6e, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x42,
+	0x13, 0x0a, 0x11, 0x5f, 0x75, 0x73, 0x65, 0x5f, 0x65, 0x6e, 0x75, 0x6d, 0x5f, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x73, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x64, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64,
+	0x5f, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x22, 0x83, 0x02, 0x0a, 0x11, 0x47, 0x48, 0x45,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61,
+	0x74, 0x68, 0x12, 0x2e, 0x0a, 0x13, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x5f, 0x70, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x11, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x4d, 0x61, 0x74,
+	0x63, 0x68, 0x12, 0x4c, 0x0a, 0x0f, 0x65, 0x78, 0x74, 0x72, 0x61, 0x5f, 0x65, 0x6e, 0x64, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e,
+	0x47, 0x48, 0x45, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x0e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73,
+	0x12, 0x25, 0x0a, 0x0e, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69,
+	0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x45,
+	0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x35, 0x0a, 0x04, 0x6a, 0x73, 0x6f, 0x6e, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x68, 0x74, 0x74,
+	0x70, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x47, 0x48, 0x45, 0x4a, 0x53, 0x4f,
+	0x4e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x04, 0x6a, 0x73, 0x6f, 0x6e, 0x22, 0xe2,
+	0x06, 0x0a, 0x10, 0x47, 0x48, 0x45, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x67, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x75, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x64,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x63, 0x68, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x70, 0x61, 0x74, 0x63, 0x68, 0x12, 0x2f, 0x0a, 0x14, 0x67, 0x6f, 0x5f,
+	0x68, 0x65, 0x61, 0x64, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x5f, 0x66, 0x75, 0x6e,
+	0x63, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x67, 0x6f, 0x48, 0x65, 0x61, 0x64, 0x48,
+	0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x46, 0x75, 0x6e, 0x63, 0x12, 0x35, 0x0a, 0x17, 0x67, 0x6f,
+	0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72,
+	0x5f, 0x66, 0x75, 0x6e, 0x63, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x14, 0x67, 0x6f, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x46, 0x75, 0x6e,
+	0x63, 0x12, 0x3c, 0x0a, 0x1b, 0x67, 0x6f, 0x5f, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x5f,
+	0x68, 0x74, 0x74, 0x70, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x17, 0x67, 0x6f, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63,
+	0x74, 0x48, 0x74, 0x74, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x69, 0x64, 0x65, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x67, 0x6f, 0x5f, 0x68, 0x61, 0x6e, 0x64,
+	0x6c, 0x65, 0x72, 0x5f, 0x66, 0x75, 0x6e, 0x63, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x67, 0x6f, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x46, 0x75, 0x6e, 0x63, 0x12, 0x12, 0x0a,
+	0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x6f, 0x64,
+	0x79, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x62, 0x6f,
+	0x64, 0x79, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x69, 0x6e, 0x64, 0x5f, 0x71,
+	0x75, 0x65, 0x72, 0x79, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x62, 0x69, 0x6e, 0x64,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x30, 0x0a, 0x14, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x5f,
+	0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x12, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x55, 0x6e, 0x6b, 0x6e, 0x6f,
+	0x77, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6f, 0x6b, 0x69, 0x65, 0x73, 0x18, 0x10, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6f, 0x6b, 0x69, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x73,
+	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x11, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0d, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x18,
+	0x12, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x73,
+	0x12, 0x35, 0x0a, 0x04, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x2e, 0x47, 0x48, 0x45, 0x4a, 0x53, 0x4f, 0x4e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x04, 0x6a, 0x73, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x63, 0x63, 0x65, 0x70,
+	0x74, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x14, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x61, 0x63,
+	0x63, 0x65, 0x70, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x6f, 0x72, 0x6d,
+	0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x15, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x66, 0x6f,
+	0x72, 0x6d, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x75,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x16, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0d, 0x6d, 0x61, 0x78, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x23, 0x0a, 0x0d, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x46, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x12, 0x3d, 0x0a, 0x1b, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73,
+	0x5f, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x6f,
+	0x6e, 0x6c, 0x79, 0x18, 0x18, 0x20, 0x01, 0x28, 0x08, 0x52, 0x18, 0x63, 0x61, 0x70, 0x74, 0x75,
+	0x72, 0x65, 0x73, 0x46, 0x69, 0x72, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f,
+	0x6e, 0x6c, 0x79, 0x3a, 0x55, 0x0a, 0x04, 0x6f, 0x70, 0x74, 0x73, 0x12, 0x1c, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69,
+	0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd2, 0x86, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x21, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x65, 0x6e, 0x64,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x47, 0x48, 0x45, 0x46, 0x69, 0x6c, 0x65, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x04, 0x6f, 0x70, 0x74, 0x73, 0x3a, 0x5b, 0x0a, 0x04, 0x62, 0x61,
+	0x73, 0x65, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0xd2, 0x86, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e,
+	0x47, 0x48, 0x45, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x3a, 0x61, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x12, 0x1e, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0xd2, 0x86, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e,
 	0x47, 0x48, 0x45, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x12, 0x10, 0x0a, 0x03, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x67,
-	0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x70, 0x6f, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x75, 0x74, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x63, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x70, 0x61, 0x74, 0x63, 0x68, 0x12, 0x2f, 0x0a, 0x14, 0x67, 0x6f, 0x5f, 0x68, 0x65, 0x61,
-	0x64, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x5f, 0x66, 0x75, 0x6e, 0x63, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x67, 0x6f, 0x48, 0x65, 0x61, 0x64, 0x48, 0x61, 0x6e, 0x64,
-	0x6c, 0x65, 0x72, 0x46, 0x75, 0x6e, 0x63, 0x12, 0x35, 0x0a, 0x17, 0x67, 0x6f, 0x5f, 0x6f, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x5f, 0x66, 0x75,
-	0x6e, 0x63, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x14, 0x67, 0x6f, 0x4f, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x46, 0x75, 0x6e, 0x63, 0x12, 0x3c,
-	0x0a, 0x1b, 0x67, 0x6f, 0x5f, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x5f, 0x68, 0x74, 0x74,
-	0x70, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x08, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x17, 0x67, 0x6f, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x48, 0x74,
-	0x74, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05,
-	0x69, 0x64, 0x65, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x64, 0x65,
-	0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x67, 0x6f, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72,
-	0x5f, 0x66, 0x75, 0x6e, 0x63, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x67, 0x6f, 0x48,
-	0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x46, 0x75, 0x6e, 0x63, 0x3a, 0x55, 0x0a, 0x04, 0x6f, 0x70,
-	0x74, 0x73, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x18, 0xd2, 0x86, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e,
-	0x68, 0x74, 0x74, 0x70, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x47, 0x48, 0x45,
-	0x46, 0x69, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x04, 0x6f, 0x70, 0x74,
-	0x73, 0x3a, 0x5b, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd2, 0x86, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x24, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x65, 0x6e,
-	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x47, 0x48, 0x45, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x3a, 0x61,
-	0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1e, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x74,
-	0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd2, 0x86, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x23, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x65, 0x6e,
-	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x47, 0x48, 0x45, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64,
-	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e,
-	0x74, 0x42, 0x3b, 0x5a, 0x39, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x79, 0x69, 0x6e, 0x79, 0x69, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65,
-	0x6e, 0x2d, 0x67, 0x6f, 0x2d, 0x67, 0x72, 0x70, 0x63, 0x2d, 0x68, 0x74, 0x74, 0x70, 0x2d, 0x65,
-	0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2f, 0x67, 0x68, 0x65, 0x67, 0x65, 0x6e, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x42, 0x3b, 0x5a, 0x39, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x79, 0x69, 0x6e, 0x79, 0x69, 0x6e, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x67, 0x6f, 0x2d, 0x67, 0x72,
+	0x70, 0x63, 0x2d, 0x68, 0x74, 0x74, 0x70, 0x2d, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x2f, 0x67, 0x68, 0x65, 0x67, 0x65, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -418,30 +771,36 @@ func file_ghe_options_proto_rawDescGZIP() []byte {
 	return file_ghe_options_proto_rawDescData
 }
 
-var file_ghe_options_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_ghe_options_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_ghe_options_proto_goTypes = []interface{}{
 	(*GHEFileOptions)(nil),              // 0: grpc.httpendpoint.GHEFileOptions
-	(*GHEServiceOptions)(nil),           // 1: grpc.httpendpoint.GHEServiceOptions
-	(*GHEMethodOptions)(nil),            // 2: grpc.httpendpoint.GHEMethodOptions
-	nil,                                 // 3: grpc.httpendpoint.GHEFileOptions.NamingOverrideEntry
-	(*descriptorpb.FileOptions)(nil),    // 4: google.protobuf.FileOptions
-	(*descriptorpb.ServiceOptions)(nil), // 5: google.protobuf.ServiceOptions
-	(*descriptorpb.MethodOptions)(nil),  // 6: google.protobuf.MethodOptions
+	(*GHEJSONOptions)(nil),              // 1: grpc.httpendpoint.GHEJSONOptions
+	(*GHEServiceOptions)(nil),           // 2: grpc.httpendpoint.GHEServiceOptions
+	(*GHEMethodOptions)(nil),            // 3: grpc.httpendpoint.GHEMethodOptions
+	nil,                                 // 4: grpc.httpendpoint.GHEFileOptions.NamingOverrideEntry
+	nil,                                 // 5: grpc.httpendpoint.GHEFileOptions.HttpStatusCodesEntry
+	(*descriptorpb.FileOptions)(nil),    // 6: google.protobuf.FileOptions
+	(*descriptorpb.ServiceOptions)(nil), // 7: google.protobuf.ServiceOptions
+	(*descriptorpb.MethodOptions)(nil),  // 8: google.protobuf.MethodOptions
 }
 var file_ghe_options_proto_depIdxs = []int32{
-	3, // 0: grpc.httpendpoint.GHEFileOptions.naming_override:type_name -> grpc.httpendpoint.GHEFileOptions.NamingOverrideEntry
-	2, // 1: grpc.httpendpoint.GHEServiceOptions.extra_endpoints:type_name -> grpc.httpendpoint.GHEMethodOptions
-	4, // 2: grpc.httpendpoint.opts:extendee -> google.protobuf.FileOptions
-	5, // 3: grpc.httpendpoint.base:extendee -> google.protobuf.ServiceOptions
-	6, // 4: grpc.httpendpoint.endpoint:extendee -> google.protobuf.MethodOptions
-	0, // 5: grpc.httpendpoint.opts:type_name -> grpc.httpendpoint.GHEFileOptions
-	1, // 6: grpc.httpendpoint.base:type_name -> grpc.httpendpoint.GHEServiceOptions
-	2, // 7: grpc.httpendpoint.endpoint:type_name -> grpc.httpendpoint.GHEMethodOptions
-	8, // [8:8] is the sub-list for method output_type
-	8, // [8:8] is the sub-list for method input_type
-	5, // [5:8] is the sub-list for extension type_name
-	2, // [2:5] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	4,  // 0: grpc.httpendpoint.GHEFileOptions.naming_override:type_name -> grpc.httpendpoint.GHEFileOptions.NamingOverrideEntry
+	5,  // 1: grpc.httpendpoint.GHEFileOptions.http_status_codes:type_name -> grpc.httpendpoint.GHEFileOptions.HttpStatusCodesEntry
+	1,  // 2: grpc.httpendpoint.GHEFileOptions.json:type_name -> grpc.httpendpoint.GHEJSONOptions
+	3,  // 3: grpc.httpendpoint.GHEServiceOptions.extra_endpoints:type_name -> grpc.httpendpoint.GHEMethodOptions
+	1,  // 4: grpc.httpendpoint.GHEServiceOptions.json:type_name -> grpc.httpendpoint.GHEJSONOptions
+	1,  // 5: grpc.httpendpoint.GHEMethodOptions.json:type_name -> grpc.httpendpoint.GHEJSONOptions
+	6,  // 6: grpc.httpendpoint.opts:extendee -> google.protobuf.FileOptions
+	7,  // 7: grpc.httpendpoint.base:extendee -> google.protobuf.ServiceOptions
+	8,  // 8: grpc.httpendpoint.endpoint:extendee -> google.protobuf.MethodOptions
+	0,  // 9: grpc.httpendpoint.opts:type_name -> grpc.httpendpoint.GHEFileOptions
+	2,  // 10: grpc.httpendpoint.base:type_name -> grpc.httpendpoint.GHEServiceOptions
+	3,  // 11: grpc.httpendpoint.endpoint:type_name -> grpc.httpendpoint.GHEMethodOptions
+	12, // [12:12] is the sub-list for method output_type
+	12, // [12:12] is the sub-list for method input_type
+	9,  // [9:12] is the sub-list for extension type_name
+	6,  // [6:9] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_ghe_options_proto_init() }
@@ -463,7 +822,7 @@ func file_ghe_options_proto_init() {
 			}
 		}
 		file_ghe_options_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GHEServiceOptions); i {
+			switch v := v.(*GHEJSONOptions); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -475,6 +834,18 @@ func file_ghe_options_proto_init() {
 			}
 		}
 		file_ghe_options_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GHEServiceOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ghe_options_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GHEMethodOptions); i {
 			case 0:
 				return &v.state
@@ -487,13 +858,14 @@ func file_ghe_options_proto_init() {
 			}
 		}
 	}
+	file_ghe_options_proto_msgTypes[1].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_ghe_options_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   6,
 			NumExtensions: 3,
 			NumServices:   0,
 		},
@@ -12,6 +12,7 @@ func (x *GHEServiceOptions) NormalizeValues() {
 	}
 	x.Path = sanitizer.TrimURLPathPart(x.Path)
 	x.StrictPrefixMatch = sanitizer.TrimURLPathPart(x.StrictPrefixMatch)
+	x.ErrorEncoding = strings.TrimSpace(x.ErrorEncoding)
 	for _, extOpts := range x.ExtraEndpoints {
 		extOpts.NormalizeValues()
 	}
@@ -22,4 +23,19 @@ func (x *GHEMethodOptions) NormalizeValues() {
 		return
 	}
 	x.Ident = strings.TrimSpace(x.Ident)
+	x.Body = strings.TrimSpace(x.Body)
+	x.ResponseBody = strings.TrimSpace(x.ResponseBody)
+	x.GoExtractHttpStatusCode = strings.TrimSpace(x.GoExtractHttpStatusCode)
+	x.StreamFormat = strings.ToLower(strings.TrimSpace(x.StreamFormat))
+	for idx, encoding := range x.Encodings {
+		x.Encodings[idx] = strings.ToLower(strings.TrimSpace(encoding))
+	}
+}
+
+func (x *GHEFileOptions) NormalizeValues() {
+	if x == nil {
+		return
+	}
+	x.PathNamingConvention = strings.TrimSpace(x.PathNamingConvention)
+	x.ErrorEncoding = strings.TrimSpace(x.ErrorEncoding)
 }
@@ -0,0 +1,191 @@
+package ghert
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+)
+
+// MessageStreamReader reads messages from request body of newline delimited
+// JSON or varint length-prefixed binary protobuf.
+type MessageStreamReader struct {
+	body  *bufio.Reader
+	codec *MessageCodec
+	enc   Encoding
+}
+
+// NewMessageStreamReader creates MessageStreamReader for request body of r.
+// Encoding is selected by Content-Type header, `application/x-ndjson` and
+// `application/json` for NDJSON, and `application/x-protobuf` for
+// length-prefixed protobuf. Request without Content-Type is considered NDJSON.
+// Returns HTTPError with status 415 if the encoding is not in allowed.
+func NewMessageStreamReader(r *http.Request, codec *MessageCodec, allowed Encoding) (*MessageStreamReader, error) {
+	enc := EncodingJSON
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		enc = 0
+		if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+			if mediaType == contentTypeNDJSON {
+				enc = EncodingJSON
+			} else {
+				enc = EncodingFromMediaType(mediaType)
+			}
+		}
+		if (enc & allowed & (EncodingJSON | EncodingProtobuf)) == 0 {
+			return nil, &HTTPError{
+				StatusCode: http.StatusUnsupportedMediaType,
+				Message:    "unsupported content type: " + contentType,
+			}
+		}
+	}
+	body := r.Body
+	if body == nil {
+		body = http.NoBody
+	}
+	return &MessageStreamReader{
+		body:  bufio.NewReader(body),
+		codec: codec,
+		enc:   enc,
+	}, nil
+}
+
+func decodeStreamError(err error) error {
+	return &HTTPError{
+		StatusCode: http.StatusBadRequest,
+		Message:    "cannot decode request stream",
+		Err:        err,
+	}
+}
+
+// ReadMessage reads next message into msg.
+// Empty lines of NDJSON are skipped. Returns io.EOF at the end of body.
+func (sr *MessageStreamReader) ReadMessage(msg proto.Message) error {
+	if sr.enc == EncodingProtobuf {
+		err := protodelim.UnmarshalFrom(sr.body, msg)
+		if err == nil || err == io.EOF {
+			return err
+		}
+		var sizeErr *protodelim.SizeTooLargeError
+		if errors.Is(err, io.ErrUnexpectedEOF) || errors.As(err, &sizeErr) || errors.As(err, new(*http.MaxBytesError)) {
+			return readBodyError(err)
+		}
+		return decodeStreamError(err)
+	}
+	for {
+		line, err := sr.body.ReadBytes('\n')
+		if (err != nil) && (err != io.EOF) {
+			return readBodyError(err)
+		}
+		if line = bytes.TrimSpace(line); len(line) != 0 {
+			if err := sr.codec.JSONUnmarshal.Unmarshal(line, msg); err != nil {
+				return decodeStreamError(err)
+			}
+			return nil
+		}
+		if err == io.EOF {
+			return io.EOF
+		}
+	}
+}
+
+// ClientStream adapts MessageStreamReader to the server side stream of
+// client-streaming RPC method which receives messages of type *T and replies
+// message of type *R.
+//
+// Fields set in template are merged into the first received message, or
+// into every received message when mergeAll is set.
+type ClientStream[T, R any] struct {
+	w      http.ResponseWriter
+	ctx    context.Context
+	reader *MessageStreamReader
+
+	template proto.Message
+	mergeAll bool
+	received int
+
+	reply *R
+}
+
+// NewClientStream creates ClientStream bound to request r.
+func NewClientStream[T, R any](w http.ResponseWriter, r *http.Request, reader *MessageStreamReader, template *T, mergeAll bool) *ClientStream[T, R] {
+	s := &ClientStream[T, R]{
+		w:        w,
+		ctx:      r.Context(),
+		reader:   reader,
+		mergeAll: mergeAll,
+	}
+	if template != nil {
+		s.template = any(template).(proto.Message)
+	}
+	return s
+}
+
+func (s *ClientStream[T, R]) Recv() (*T, error) {
+	m := new(T)
+	if err := s.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (s *ClientStream[T, R]) SendAndClose(m *R) error {
+	s.reply = m
+	return nil
+}
+
+// Reply returns message given to SendAndClose.
+// Returns error if the method did not reply.
+func (s *ClientStream[T, R]) Reply() (*R, error) {
+	if s.reply == nil {
+		return nil, status.Error(codes.Internal, "client-streaming method returned without reply")
+	}
+	return s.reply, nil
+}
+
+// SetHeader adds metadata as `Grpc-Metadata-` prefixed response headers.
+func (s *ClientStream[T, R]) SetHeader(md metadata.MD) error {
+	setMetadataHeader(s.w.Header(), md)
+	return nil
+}
+
+func (s *ClientStream[T, R]) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+// SetTrailer is not supported and the trailer is discarded.
+func (s *ClientStream[T, R]) SetTrailer(md metadata.MD) {}
+
+func (s *ClientStream[T, R]) Context() context.Context {
+	return s.ctx
+}
+
+func (s *ClientStream[T, R]) SendMsg(m any) error {
+	return errors.New("send is not supported on client-streaming method")
+}
+
+func (s *ClientStream[T, R]) RecvMsg(m any) error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return errors.New("message is not proto.Message")
+	}
+	if err := s.reader.ReadMessage(msg); err != nil {
+		return err
+	}
+	if (s.template != nil) && (s.mergeAll || (s.received == 0)) {
+		proto.Merge(msg, s.template)
+	}
+	s.received++
+	return nil
+}
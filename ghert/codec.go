@@ -0,0 +1,274 @@
+package ghert
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	contentTypeJSON      = "application/json"
+	contentTypeProtobuf  = "application/x-protobuf"
+	contentTypeProtoText = "application/x-prototext"
+)
+
+// Encoding is a bit set of message encodings.
+type Encoding int
+
+const (
+	EncodingJSON Encoding = 1 << iota
+	EncodingProtobuf
+	EncodingProtoText
+
+	AllEncodings = EncodingJSON | EncodingProtobuf | EncodingProtoText
+)
+
+// encodingsInPreference lists single encodings in the order of preference
+// when client does not express one.
+var encodingsInPreference = []Encoding{EncodingJSON, EncodingProtobuf, EncodingProtoText}
+
+// MediaType returns media type of single encoding.
+func (e Encoding) MediaType() string {
+	switch e {
+	case EncodingJSON:
+		return contentTypeJSON
+	case EncodingProtobuf:
+		return contentTypeProtobuf
+	case EncodingProtoText:
+		return contentTypeProtoText
+	}
+	return ""
+}
+
+// EncodingFromMediaType returns encoding of given media type or 0 if
+// media type is not supported.
+func EncodingFromMediaType(mediaType string) Encoding {
+	switch strings.ToLower(mediaType) {
+	case contentTypeJSON:
+		return EncodingJSON
+	case contentTypeProtobuf, "application/protobuf", "application/vnd.google.protobuf":
+		return EncodingProtobuf
+	case contentTypeProtoText, "text/x-prototext":
+		return EncodingProtoText
+	}
+	return 0
+}
+
+func (e Encoding) first() Encoding {
+	for _, enc := range encodingsInPreference {
+		if (e & enc) != 0 {
+			return enc
+		}
+	}
+	return 0
+}
+
+// RequestEncoding returns encoding of request body from Content-Type header.
+// Request without Content-Type is considered JSON.
+// Returns HTTPError with status 415 if the encoding is not in allowed.
+func RequestEncoding(r *http.Request, allowed Encoding) (Encoding, error) {
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		if (allowed & EncodingJSON) != 0 {
+			return EncodingJSON, nil
+		}
+		return allowed.first(), nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err == nil {
+		if enc := EncodingFromMediaType(mediaType); (enc & allowed) != 0 {
+			return enc, nil
+		}
+	}
+	return 0, &HTTPError{
+		StatusCode: http.StatusUnsupportedMediaType,
+		Message:    "unsupported content type: " + contentType,
+	}
+}
+
+type acceptRange struct {
+	mediaType string
+	quality   float64
+	index     int
+}
+
+func parseAccept(accept string) (ranges []*acceptRange) {
+	for idx, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+		ranges = append(ranges, &acceptRange{
+			mediaType: mediaType,
+			quality:   quality,
+			index:     idx,
+		})
+	}
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].quality > ranges[j].quality
+	})
+	return
+}
+
+// NegotiateEncoding selects encoding of response body from Accept header.
+// Without Accept header, encoding of request body is used if allowed.
+// Returns HTTPError with status 406 if none of accepted media types is allowed.
+func NegotiateEncoding(r *http.Request, allowed Encoding) (Encoding, error) {
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err == nil {
+			if enc := EncodingFromMediaType(mediaType); (enc & allowed) != 0 {
+				return enc, nil
+			}
+		}
+		return allowed.first(), nil
+	}
+	for _, acceptRange := range parseAccept(accept) {
+		switch mediaType := acceptRange.mediaType; {
+		case mediaType == "*/*":
+			return allowed.first(), nil
+		case strings.HasSuffix(mediaType, "/*"):
+			mediaTypePrefix := mediaType[:len(mediaType)-1]
+			for _, enc := range encodingsInPreference {
+				if ((enc & allowed) != 0) && strings.HasPrefix(enc.MediaType(), mediaTypePrefix) {
+					return enc, nil
+				}
+			}
+		default:
+			if enc := EncodingFromMediaType(mediaType); (enc & allowed) != 0 {
+				return enc, nil
+			}
+		}
+	}
+	return 0, &HTTPError{
+		StatusCode: http.StatusNotAcceptable,
+		Message:    "not acceptable: " + accept,
+	}
+}
+
+// MessageCodec holds options for marshalling and unmarshalling messages.
+type MessageCodec struct {
+	JSONMarshal   protojson.MarshalOptions
+	JSONUnmarshal protojson.UnmarshalOptions
+}
+
+// DefaultMessageCodec is used by package level DecodeRequest and
+// WriteResponse functions.
+var DefaultMessageCodec = &MessageCodec{}
+
+func (c *MessageCodec) unmarshalMessage(enc Encoding, buf []byte, msg proto.Message) error {
+	switch enc {
+	case EncodingProtobuf:
+		return proto.Unmarshal(buf, msg)
+	case EncodingProtoText:
+		return prototext.Unmarshal(buf, msg)
+	}
+	return c.JSONUnmarshal.Unmarshal(buf, msg)
+}
+
+func (c *MessageCodec) marshalMessage(enc Encoding, msg proto.Message) ([]byte, error) {
+	switch enc {
+	case EncodingProtobuf:
+		return proto.Marshal(msg)
+	case EncodingProtoText:
+		return prototext.Marshal(msg)
+	}
+	return c.JSONMarshal.Marshal(msg)
+}
+
+// DecodeRequest decodes request body into msg with encoding selected by
+// Content-Type header.
+// Empty body is accepted and leaves msg untouched.
+// Content of msg is replaced when body is not empty.
+func (c *MessageCodec) DecodeRequest(r *http.Request, allowed Encoding, msg proto.Message) error {
+	if r.Body == nil {
+		return nil
+	}
+	buf, err := io.ReadAll(r.Body)
+	if err != nil {
+		return readBodyError(err)
+	}
+	if len(buf) == 0 {
+		return nil
+	}
+	enc, err := RequestEncoding(r, allowed)
+	if err != nil {
+		return err
+	}
+	if err = c.unmarshalMessage(enc, buf, msg); err != nil {
+		return &HTTPError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "cannot decode request body",
+			Err:        err,
+		}
+	}
+	return nil
+}
+
+// WriteResponse writes msg as response in given encoding with status code.
+// Body is omitted for status codes which do not allow one, ie. 204.
+// Marshal failure is written with ew as error of routeIdent,
+// DefaultErrorWriter is used if ew is nil.
+func (c *MessageCodec) WriteResponse(w http.ResponseWriter, r *http.Request, ew *ErrorWriter, routeIdent string, enc Encoding, statusCode int, msg proto.Message) {
+	if !bodyAllowedForStatus(statusCode) {
+		w.WriteHeader(statusCode)
+		return
+	}
+	buf, err := c.marshalMessage(enc, msg)
+	if err != nil {
+		if ew == nil {
+			ew = DefaultErrorWriter
+		}
+		ew.WriteRouteError(w, r, routeIdent, err)
+		return
+	}
+	w.Header().Set("Content-Type", enc.MediaType())
+	w.WriteHeader(statusCode)
+	w.Write(buf)
+}
+
+// DecodeRequest decodes request body into msg with DefaultMessageCodec.
+func DecodeRequest(r *http.Request, allowed Encoding, msg proto.Message) error {
+	return DefaultMessageCodec.DecodeRequest(r, allowed, msg)
+}
+
+// DecodeJSONRequest decodes JSON request body into msg.
+func DecodeJSONRequest(r *http.Request, msg proto.Message) error {
+	return DefaultMessageCodec.DecodeRequest(r, EncodingJSON, msg)
+}
+
+// WriteResponse writes msg as response with DefaultMessageCodec.
+func WriteResponse(w http.ResponseWriter, r *http.Request, enc Encoding, statusCode int, msg proto.Message) {
+	DefaultMessageCodec.WriteResponse(w, r, nil, "", enc, statusCode, msg)
+}
+
+// WriteJSONResponse writes msg as JSON response with given status code.
+func WriteJSONResponse(w http.ResponseWriter, r *http.Request, statusCode int, msg proto.Message) {
+	DefaultMessageCodec.WriteResponse(w, r, nil, "", EncodingJSON, statusCode, msg)
+}
+
+func bodyAllowedForStatus(statusCode int) bool {
+	switch {
+	case (statusCode >= 100) && (statusCode <= 199):
+		return false
+	case (statusCode == http.StatusNoContent) || (statusCode == http.StatusNotModified):
+		return false
+	}
+	return true
+}
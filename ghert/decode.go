@@ -0,0 +1,116 @@
+package ghert
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// DecodeError indicates the value of a request parameter cannot be decoded.
+type DecodeError struct {
+	Name  string
+	Value string
+	Err   error
+}
+
+func (e *DecodeError) Error() string {
+	return "invalid value for " + e.Name + ": " + strconv.Quote(e.Value) + ": " + e.Err.Error()
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// NewDecodeError creates a DecodeError if err is not nil.
+func NewDecodeError(name, value string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &DecodeError{
+		Name:  name,
+		Value: value,
+		Err:   err,
+	}
+}
+
+// DecodeString decodes escaped capture value into string.
+func DecodeString(v string) (string, error) {
+	if strings.IndexByte(v, '%') < 0 {
+		return v, nil
+	}
+	return url.PathUnescape(v)
+}
+
+// DecodeBytes decodes base64 encoded capture value into bytes.
+// Both standard and URL-safe alphabets are accepted, padding is optional.
+func DecodeBytes(v string) ([]byte, error) {
+	s, err := DecodeString(v)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBase64(s)
+}
+
+func decodeBase64(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+	if strings.ContainsAny(s, "+/") {
+		return base64.RawStdEncoding.DecodeString(s)
+	}
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
+func DecodeBool(v string) (bool, error) {
+	return strconv.ParseBool(v)
+}
+
+func DecodeInt32(v string) (int32, error) {
+	n, err := strconv.ParseInt(v, 10, 32)
+	return int32(n), err
+}
+
+func DecodeUint32(v string) (uint32, error) {
+	n, err := strconv.ParseUint(v, 10, 32)
+	return uint32(n), err
+}
+
+func DecodeInt64(v string) (int64, error) {
+	return strconv.ParseInt(v, 10, 64)
+}
+
+func DecodeUint64(v string) (uint64, error) {
+	return strconv.ParseUint(v, 10, 64)
+}
+
+func DecodeFloat32(v string) (float32, error) {
+	n, err := strconv.ParseFloat(v, 32)
+	return float32(n), err
+}
+
+func DecodeFloat64(v string) (float64, error) {
+	return strconv.ParseFloat(v, 64)
+}
+
+// DecodeEnum decodes escaped capture value of enum value name or number
+// into enum of type T.
+func DecodeEnum[T ~int32](v string, desc protoreflect.EnumDescriptor) (T, error) {
+	s, err := DecodeString(v)
+	if err != nil {
+		return 0, err
+	}
+	return decodeEnumValue[T](s, desc)
+}
+
+func decodeEnumValue[T ~int32](s string, desc protoreflect.EnumDescriptor) (T, error) {
+	if enumValue := desc.Values().ByName(protoreflect.Name(s)); enumValue != nil {
+		return T(enumValue.Number()), nil
+	}
+	n, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("unknown value of enum %s", desc.FullName())
+	}
+	return T(n), nil
+}
@@ -0,0 +1,271 @@
+package ghert
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// StatusClientClosedRequest is the non-standard HTTP status code for
+// requests canceled by client.
+const StatusClientClosedRequest = 499
+
+// HTTPError is an error with HTTP status code.
+type HTTPError struct {
+	StatusCode int
+	Message    string
+	Err        error
+}
+
+func (e *HTTPError) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+	return e.Message + ": " + e.Err.Error()
+}
+
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+// HTTPStatusFromCode maps gRPC status code to HTTP status code.
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return StatusClientClosedRequest
+	case codes.Unknown:
+		return http.StatusInternalServerError
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.Aborted:
+		return http.StatusConflict
+	case codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Internal:
+		return http.StatusInternalServerError
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DataLoss:
+		return http.StatusInternalServerError
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	}
+	return http.StatusInternalServerError
+}
+
+// CodeFromHTTPStatus maps HTTP status code to gRPC status code.
+func CodeFromHTTPStatus(statusCode int) codes.Code {
+	switch statusCode {
+	case http.StatusBadRequest, http.StatusUnsupportedMediaType, http.StatusRequestEntityTooLarge:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusConflict:
+		return codes.Aborted
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case StatusClientClosedRequest:
+		return codes.Canceled
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+	if (statusCode >= 200) && (statusCode < 300) {
+		return codes.OK
+	}
+	return codes.Unknown
+}
+
+// StatusFromError converts err into gRPC status.
+// Errors which do not carry gRPC status are converted by their HTTP status.
+func StatusFromError(err error) *status.Status {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return status.New(CodeFromHTTPStatus(httpErr.StatusCode), httpErr.Error())
+	}
+	var decodeErr *DecodeError
+	if errors.As(err, &decodeErr) {
+		return status.New(codes.InvalidArgument, decodeErr.Error())
+	}
+	if s, ok := status.FromError(err); ok {
+		return s
+	}
+	return status.FromContextError(err)
+}
+
+// ErrorEncoding selects the format of error response body.
+type ErrorEncoding int
+
+const (
+	// JSON object carrying code, message and details of gRPC status.
+	ErrorEncodingStatusJSON ErrorEncoding = iota
+
+	// RFC 7807 problem document in `application/problem+json`.
+	ErrorEncodingProblemJSON
+)
+
+const contentTypeProblemJSON = "application/problem+json"
+
+// ErrorWriter writes error responses.
+type ErrorWriter struct {
+	// StatusCodes overrides HTTP status codes of gRPC status codes.
+	StatusCodes map[codes.Code]int
+
+	Encoding ErrorEncoding
+}
+
+// DefaultErrorWriter is used by package level WriteError functions.
+var DefaultErrorWriter = &ErrorWriter{}
+
+// HTTPStatusFromCode maps gRPC status code to HTTP status code with
+// overrides applied.
+func (ew *ErrorWriter) HTTPStatusFromCode(code codes.Code) int {
+	if ew != nil {
+		if statusCode, ok := ew.StatusCodes[code]; ok {
+			return statusCode
+		}
+	}
+	return HTTPStatusFromCode(code)
+}
+
+// HTTPStatusFromError returns HTTP status code for given error.
+func (ew *ErrorWriter) HTTPStatusFromError(err error) int {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode
+	}
+	return ew.HTTPStatusFromCode(StatusFromError(err).Code())
+}
+
+func marshalStatus(s *status.Status) []byte {
+	buf, err := protojson.Marshal(s.Proto())
+	if err == nil {
+		return buf
+	}
+	// details might not be resolvable, retry without details.
+	buf, err = protojson.Marshal(&spb.Status{
+		Code:    int32(s.Code()),
+		Message: s.Message(),
+	})
+	if err != nil {
+		return []byte("{}")
+	}
+	return buf
+}
+
+// ProblemDocument is the RFC 7807 problem details object.
+type ProblemDocument struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+func marshalProblem(s *status.Status, statusCode int, routeIdent string) []byte {
+	buf, err := json.Marshal(&ProblemDocument{
+		Type:     "about:blank",
+		Title:    http.StatusText(statusCode),
+		Status:   statusCode,
+		Detail:   s.Message(),
+		Instance: routeIdent,
+	})
+	if err != nil {
+		return []byte("{}")
+	}
+	return buf
+}
+
+// WriteRouteError writes err as response of route identified by routeIdent.
+// The routeIdent is used as `instance` of problem document and can be empty
+// when no route is matched.
+func (ew *ErrorWriter) WriteRouteError(w http.ResponseWriter, r *http.Request, routeIdent string, err error) {
+	s := StatusFromError(err)
+	statusCode := ew.HTTPStatusFromError(err)
+	var buf []byte
+	var contentType string
+	if (ew != nil) && (ew.Encoding == ErrorEncodingProblemJSON) {
+		buf = marshalProblem(s, statusCode, routeIdent)
+		contentType = contentTypeProblemJSON
+	} else {
+		buf = marshalStatus(s)
+		contentType = contentTypeJSON
+	}
+	h := w.Header()
+	h.Del("Content-Length")
+	h.Set("Content-Type", contentType)
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(statusCode)
+	w.Write(buf)
+}
+
+// WriteError writes err as response.
+func (ew *ErrorWriter) WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	ew.WriteRouteError(w, r, "", err)
+}
+
+// WriteRouteNotFound writes response for request path without matched route.
+func (ew *ErrorWriter) WriteRouteNotFound(w http.ResponseWriter, r *http.Request) {
+	ew.WriteError(w, r, &HTTPError{
+		StatusCode: http.StatusNotFound,
+		Message:    "route not found: " + r.URL.Path,
+	})
+}
+
+// WriteMethodNotAllowed writes response for request method not supported
+// by matched route. The allowMethods will be set as Allow header.
+func (ew *ErrorWriter) WriteMethodNotAllowed(w http.ResponseWriter, r *http.Request, routeIdent, allowMethods string) {
+	w.Header().Set("Allow", allowMethods)
+	ew.WriteRouteError(w, r, routeIdent, &HTTPError{
+		StatusCode: http.StatusMethodNotAllowed,
+		Message:    "method not allowed: " + r.Method,
+	})
+}
+
+// HTTPStatusFromError returns HTTP status code for given error.
+func HTTPStatusFromError(err error) int {
+	return DefaultErrorWriter.HTTPStatusFromError(err)
+}
+
+// WriteError writes err as response with DefaultErrorWriter.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	DefaultErrorWriter.WriteError(w, r, err)
+}
+
+// WriteRouteNotFound writes route not found response with DefaultErrorWriter.
+func WriteRouteNotFound(w http.ResponseWriter, r *http.Request) {
+	DefaultErrorWriter.WriteRouteNotFound(w, r)
+}
+
+// WriteMethodNotAllowed writes method not allowed response with DefaultErrorWriter.
+func WriteMethodNotAllowed(w http.ResponseWriter, r *http.Request, allowMethods string) {
+	DefaultErrorWriter.WriteMethodNotAllowed(w, r, "", allowMethods)
+}
@@ -0,0 +1,129 @@
+package ghert
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+)
+
+const (
+	contentTypeFormURLEncoded = "application/x-www-form-urlencoded"
+	contentTypeMultipartForm  = "multipart/form-data"
+)
+
+// MaxFormValueSize limits the size of each non-file part of multipart form.
+// File parts are left to FormFileHandler.
+var MaxFormValueSize int64 = 1 << 20
+
+// FormFileHandler is invoked with each file part of multipart form.
+// Parts not consumed by the handler are discarded.
+type FormFileHandler func(name string, part io.Reader) error
+
+// IsFormRequest checks if request body is URL encoded or multipart form.
+func IsFormRequest(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	return (mediaType == contentTypeFormURLEncoded) || (mediaType == contentTypeMultipartForm)
+}
+
+func readBodyError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return &HTTPError{
+			StatusCode: http.StatusRequestEntityTooLarge,
+			Message:    "request body too large",
+			Err:        err,
+		}
+	}
+	return &HTTPError{
+		StatusCode: http.StatusBadRequest,
+		Message:    "cannot read request body",
+		Err:        err,
+	}
+}
+
+// ParseForm parses URL encoded or multipart form in request body.
+// Values of non-file parts are returned. File parts are passed to
+// fileHandler in the order of appearance.
+func ParseForm(r *http.Request, fileHandler FormFileHandler) (url.Values, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, &HTTPError{
+			StatusCode: http.StatusUnsupportedMediaType,
+			Message:    "invalid content type",
+			Err:        err,
+		}
+	}
+	if r.Body == nil {
+		return url.Values{}, nil
+	}
+	if mediaType == contentTypeFormURLEncoded {
+		buf, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, readBodyError(err)
+		}
+		form, err := url.ParseQuery(string(buf))
+		if err != nil {
+			return nil, &HTTPError{
+				StatusCode: http.StatusBadRequest,
+				Message:    "cannot parse form",
+				Err:        err,
+			}
+		}
+		return form, nil
+	}
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, &HTTPError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "cannot parse multipart form",
+			Err:        err,
+		}
+	}
+	form := make(url.Values)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, readBodyError(err)
+		}
+		name := part.FormName()
+		if part.FileName() == "" {
+			buf, err := io.ReadAll(io.LimitReader(part, MaxFormValueSize+1))
+			if err != nil {
+				part.Close()
+				return nil, readBodyError(err)
+			}
+			if int64(len(buf)) > MaxFormValueSize {
+				part.Close()
+				return nil, &HTTPError{
+					StatusCode: http.StatusRequestEntityTooLarge,
+					Message:    "form value too large: " + name,
+				}
+			}
+			form.Add(name, string(buf))
+		} else if fileHandler != nil {
+			if err = fileHandler(name, part); err != nil {
+				part.Close()
+				return nil, err
+			}
+		}
+		part.Close()
+	}
+	return form, nil
+}
+
+// ReadFormFile reads content of file part.
+func ReadFormFile(part io.Reader) ([]byte, error) {
+	buf, err := io.ReadAll(part)
+	if err != nil {
+		return nil, readBodyError(err)
+	}
+	return buf, nil
+}
@@ -0,0 +1,20 @@
+package ghert
+
+import (
+	"net/http"
+)
+
+// HeaderValues returns values of request header with canonical name.
+func HeaderValues(r *http.Request, name string) []string {
+	return r.Header[name]
+}
+
+// CookieValues returns values of request cookies with given name.
+func CookieValues(r *http.Request, name string) (values []string) {
+	for _, cookie := range r.Cookies() {
+		if cookie.Name == name {
+			values = append(values, cookie.Value)
+		}
+	}
+	return
+}
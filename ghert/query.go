@@ -0,0 +1,52 @@
+package ghert
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ErrMultipleQueryValues indicates multiple values are given to a query
+// parameter bound to a non-repeated field.
+var ErrMultipleQueryValues = errors.New("multiple values for non-repeated field")
+
+// ErrUnknownQueryParameter indicates the query parameter is not bound to
+// any field.
+var ErrUnknownQueryParameter = errors.New("unknown query parameter")
+
+// ParseQuery parses query string of request.
+// Malformed query string results in HTTPError with status 400.
+func ParseQuery(r *http.Request) (url.Values, error) {
+	query, err := url.ParseQuery(r.URL.RawQuery)
+	if err != nil {
+		return nil, &HTTPError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "cannot parse query string",
+			Err:        err,
+		}
+	}
+	return query, nil
+}
+
+// DecodeQueryString decodes unescaped query, header or cookie value into string.
+func DecodeQueryString(v string) (string, error) {
+	if !utf8.ValidString(v) {
+		return "", errors.New("invalid UTF-8 string")
+	}
+	return v, nil
+}
+
+// DecodeQueryBytes decodes unescaped base64 encoded query, header or cookie
+// value into bytes.
+func DecodeQueryBytes(v string) ([]byte, error) {
+	return decodeBase64(v)
+}
+
+// DecodeQueryEnum decodes unescaped query, header or cookie value of enum
+// value name or number into enum of type T.
+func DecodeQueryEnum[T ~int32](v string, desc protoreflect.EnumDescriptor) (T, error) {
+	return decodeEnumValue[T](v, desc)
+}
@@ -0,0 +1,51 @@
+// Package ghert contains runtime support routines for code generated by
+// protoc-gen-go-grpc-http-endpoint.
+package ghert
+
+import "strings"
+
+// CaptureLen returns the length of leading bytes in p which are acceptable
+// by the capture pattern in bit mask form (see ByteMapper of generator).
+func CaptureLen(p string, bits0, bits1 uint64) int {
+	for idx := 0; idx < len(p); idx++ {
+		ch := p[idx]
+		if ch >= 0x80 {
+			return idx
+		}
+		if ch < 0x40 {
+			if ((bits0 >> ch) & 1) == 0 {
+				return idx
+			}
+		} else if ((bits1 >> (ch - 0x40)) & 1) == 0 {
+			return idx
+		}
+	}
+	return len(p)
+}
+
+// MatchPathTemplate checks if captured value p matches template of
+// google.api.http variable such as `shelves/*` or `projects/*/files/**`.
+// The `*` wildcard matches one non-empty segment and the trailing `**`
+// matches the remaining segments.
+func MatchPathTemplate(p, tmpl string) bool {
+	for tmpl != "" {
+		tmplSegment, tmplRemain, _ := strings.Cut(tmpl, "/")
+		if tmplSegment == "**" {
+			return true
+		}
+		segment, remain, found := strings.Cut(p, "/")
+		switch {
+		case tmplSegment == "*":
+			if segment == "" {
+				return false
+			}
+		case segment != tmplSegment:
+			return false
+		}
+		if found != (tmplRemain != "") {
+			return (tmplRemain == "**") && !found
+		}
+		p, tmpl = remain, tmplRemain
+	}
+	return true
+}
@@ -0,0 +1,233 @@
+package ghert
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/textproto"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	contentTypeEventStream = "text/event-stream"
+	contentTypeNDJSON      = "application/x-ndjson"
+)
+
+// StreamFormat is a bit set of formats for streaming responses.
+type StreamFormat int
+
+const (
+	// Newline delimited JSON, one message per line.
+	StreamFormatNDJSON StreamFormat = 1 << iota
+
+	// Server-sent events, one event per message.
+	StreamFormatSSE
+
+	AllStreamFormats = StreamFormatNDJSON | StreamFormatSSE
+)
+
+// NegotiateStreamFormat selects format of streaming response from Accept
+// header. NDJSON is used when client does not express one.
+// Returns HTTPError with status 406 if none of accepted media types is allowed.
+func NegotiateStreamFormat(r *http.Request, allowed StreamFormat) (StreamFormat, error) {
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		if (allowed & StreamFormatNDJSON) != 0 {
+			return StreamFormatNDJSON, nil
+		}
+		return StreamFormatSSE, nil
+	}
+	for _, acceptRange := range parseAccept(accept) {
+		var format StreamFormat
+		switch acceptRange.mediaType {
+		case contentTypeEventStream, "text/*":
+			format = StreamFormatSSE
+		case contentTypeNDJSON, contentTypeJSON, "application/*":
+			format = StreamFormatNDJSON
+		case "*/*":
+			if (allowed & StreamFormatNDJSON) != 0 {
+				return StreamFormatNDJSON, nil
+			}
+			return StreamFormatSSE, nil
+		}
+		if (format & allowed) != 0 {
+			return format, nil
+		}
+	}
+	return 0, &HTTPError{
+		StatusCode: http.StatusNotAcceptable,
+		Message:    "not acceptable: " + accept,
+	}
+}
+
+// MessageStreamWriter writes messages as server-sent events or NDJSON
+// lines. Each message is flushed to client once written.
+type MessageStreamWriter struct {
+	w      http.ResponseWriter
+	rc     *http.ResponseController
+	codec  *MessageCodec
+	format StreamFormat
+
+	statusCode int
+	started    bool
+}
+
+// NewMessageStreamWriter creates MessageStreamWriter. Response header is
+// sent with statusCode when the first message is written.
+func NewMessageStreamWriter(w http.ResponseWriter, codec *MessageCodec, format StreamFormat, statusCode int) *MessageStreamWriter {
+	return &MessageStreamWriter{
+		w:          w,
+		rc:         http.NewResponseController(w),
+		codec:      codec,
+		format:     format,
+		statusCode: statusCode,
+	}
+}
+
+// Started checks if response header is sent.
+func (sw *MessageStreamWriter) Started() bool {
+	return sw.started
+}
+
+func (sw *MessageStreamWriter) start() {
+	if sw.started {
+		return
+	}
+	sw.started = true
+	h := sw.w.Header()
+	if sw.format == StreamFormatSSE {
+		h.Set("Content-Type", contentTypeEventStream)
+	} else {
+		h.Set("Content-Type", contentTypeNDJSON)
+	}
+	h.Set("Cache-Control", "no-cache")
+	h.Set("X-Accel-Buffering", "no")
+	sw.w.WriteHeader(sw.statusCode)
+}
+
+func (sw *MessageStreamWriter) writeFrame(event string, buf []byte) error {
+	sw.start()
+	var frame []byte
+	if sw.format == StreamFormatSSE {
+		if event != "" {
+			frame = append(frame, "event: "+event+"\n"...)
+		}
+		frame = append(frame, "data: "...)
+		frame = append(frame, buf...)
+		frame = append(frame, "\n\n"...)
+	} else {
+		frame = append(buf, '\n')
+	}
+	if _, err := sw.w.Write(frame); err != nil {
+		return err
+	}
+	return sw.flush()
+}
+
+// flush sends buffered response to client. Response writers which do not
+// support flushing are ignored.
+func (sw *MessageStreamWriter) flush() error {
+	if err := sw.rc.Flush(); (err != nil) && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// WriteMessage writes msg as a frame and flushes it.
+func (sw *MessageStreamWriter) WriteMessage(msg proto.Message) error {
+	buf, err := sw.codec.JSONMarshal.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return sw.writeFrame("", buf)
+}
+
+// WriteError writes err as the last frame of started stream.
+// SSE stream receives an `error` event and NDJSON stream receives a line
+// with `error` object.
+func (sw *MessageStreamWriter) WriteError(err error) {
+	buf := marshalStatus(StatusFromError(err))
+	if sw.format == StreamFormatSSE {
+		sw.writeFrame("error", buf)
+		return
+	}
+	sw.writeFrame("", append(append([]byte(`{"error":`), buf...), '}'))
+}
+
+// Finish sends response header if no message is written.
+func (sw *MessageStreamWriter) Finish() {
+	sw.start()
+}
+
+// setMetadataHeader adds metadata md into h as `Grpc-Metadata-` prefixed
+// headers.
+func setMetadataHeader(h http.Header, md metadata.MD) {
+	for k, vs := range md {
+		key := "Grpc-Metadata-" + textproto.CanonicalMIMEHeaderKey(k)
+		for _, v := range vs {
+			h.Add(key, v)
+		}
+	}
+}
+
+// ServerStream adapts MessageStreamWriter to the server side stream of
+// server-streaming RPC method which sends messages of type *T.
+type ServerStream[T any] struct {
+	*MessageStreamWriter
+
+	ctx context.Context
+}
+
+// NewServerStream creates ServerStream bound to request r.
+func NewServerStream[T any](w http.ResponseWriter, r *http.Request, codec *MessageCodec, format StreamFormat, statusCode int) *ServerStream[T] {
+	return &ServerStream[T]{
+		MessageStreamWriter: NewMessageStreamWriter(w, codec, format, statusCode),
+		ctx:                 r.Context(),
+	}
+}
+
+func (s *ServerStream[T]) Send(m *T) error {
+	return s.SendMsg(m)
+}
+
+// SetHeader adds metadata as `Grpc-Metadata-` prefixed response headers.
+// Returns error if the response header is already sent.
+func (s *ServerStream[T]) SetHeader(md metadata.MD) error {
+	if s.started {
+		return errors.New("header already sent")
+	}
+	setMetadataHeader(s.w.Header(), md)
+	return nil
+}
+
+func (s *ServerStream[T]) SendHeader(md metadata.MD) error {
+	if err := s.SetHeader(md); err != nil {
+		return err
+	}
+	s.start()
+	return s.flush()
+}
+
+// SetTrailer is not supported and the trailer is discarded.
+func (s *ServerStream[T]) SetTrailer(md metadata.MD) {}
+
+func (s *ServerStream[T]) Context() context.Context {
+	return s.ctx
+}
+
+func (s *ServerStream[T]) SendMsg(m any) error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return errors.New("message is not proto.Message")
+	}
+	return s.WriteMessage(msg)
+}
+
+func (s *ServerStream[T]) RecvMsg(m any) error {
+	return errors.New("receive is not supported on server-streaming method")
+}
@@ -0,0 +1,680 @@
+package ghert
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const webSocketAcceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+const (
+	webSocketOpContinuation = 0x0
+	webSocketOpText         = 0x1
+	webSocketOpBinary       = 0x2
+	webSocketOpClose        = 0x8
+	webSocketOpPing         = 0x9
+	webSocketOpPong         = 0xA
+)
+
+// WebSocket close codes.
+const (
+	WebSocketCloseNormal          = 1000
+	WebSocketCloseGoingAway       = 1001
+	WebSocketCloseProtocolError   = 1002
+	WebSocketCloseUnsupportedData = 1003
+	WebSocketCloseNoStatus        = 1005
+	WebSocketCloseInvalidPayload  = 1007
+	WebSocketCloseMessageTooBig   = 1009
+	WebSocketCloseInternalError   = 1011
+
+	// WebSocketCloseStatusBase is added to gRPC status code to form close
+	// code of the private use range (4000-4999).
+	WebSocketCloseStatusBase = 4000
+)
+
+// Sub-protocols for selecting encoding of outgoing messages.
+// Text frames are always decoded as JSON and binary frames as protobuf.
+const (
+	WebSocketProtocolJSON     = "json"
+	WebSocketProtocolProtobuf = "protobuf"
+)
+
+// DefaultWebSocketMessageSize is the size limit of incoming messages when
+// limit is not given.
+const DefaultWebSocketMessageSize = 4 << 20
+
+const webSocketCloseTimeout = time.Second
+
+// WebSocketCodeFromStatus maps gRPC status code to WebSocket close code.
+func WebSocketCodeFromStatus(code codes.Code) int {
+	if code == codes.OK {
+		return WebSocketCloseNormal
+	}
+	return WebSocketCloseStatusBase + int(code)
+}
+
+// StatusFromWebSocketClose maps close code and reason sent by client to
+// gRPC status.
+func StatusFromWebSocketClose(closeCode int, reason string) *status.Status {
+	switch closeCode {
+	case WebSocketCloseNormal, WebSocketCloseNoStatus:
+		return status.New(codes.OK, reason)
+	case WebSocketCloseGoingAway:
+		return status.New(codes.Canceled, reason)
+	case WebSocketCloseProtocolError, WebSocketCloseUnsupportedData, WebSocketCloseInvalidPayload:
+		return status.New(codes.InvalidArgument, reason)
+	case WebSocketCloseMessageTooBig:
+		return status.New(codes.ResourceExhausted, reason)
+	}
+	if (closeCode > WebSocketCloseStatusBase) && (closeCode <= WebSocketCloseStatusBase+int(codes.Unauthenticated)) {
+		return status.New(codes.Code(closeCode-WebSocketCloseStatusBase), reason)
+	}
+	return status.New(codes.Unknown, reason)
+}
+
+// isValidWebSocketCloseCode checks if closeCode can be sent in close frame.
+// Codes 1005, 1006 and 1015 are reserved for reporting local conditions.
+func isValidWebSocketCloseCode(closeCode int) bool {
+	switch {
+	case (closeCode >= 1000) && (closeCode <= 1003):
+		return true
+	case (closeCode >= 1007) && (closeCode <= 1014):
+		return true
+	case (closeCode >= 3000) && (closeCode <= 4999):
+		return true
+	}
+	return false
+}
+
+// webSocketCloseError is a close frame received or to be sent.
+type webSocketCloseError struct {
+	closeCode int
+	reason    string
+
+	// received is set for close frame sent by client.
+	received bool
+}
+
+func (e *webSocketCloseError) Error() string {
+	return "websocket closed: " + strconv.FormatInt(int64(e.closeCode), 10) + " " + e.reason
+}
+
+func headerHasToken(h http.Header, name, token string) bool {
+	for _, v := range h.Values(name) {
+		for _, t := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsWebSocketUpgrade checks if r is a WebSocket opening handshake.
+func IsWebSocketUpgrade(r *http.Request) bool {
+	return (r.Method == http.MethodGet) &&
+		headerHasToken(r.Header, "Connection", "upgrade") &&
+		headerHasToken(r.Header, "Upgrade", "websocket")
+}
+
+// CheckWebSocketOrigin accepts handshake without Origin header or with
+// Origin of the same host as r.Host. Cross-origin handshakes are rejected
+// as the cookies of the site would be sent along by browsers.
+func CheckWebSocketOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, r.Host)
+}
+
+func webSocketAcceptKey(key string) string {
+	h := sha1.Sum([]byte(key + webSocketAcceptGUID))
+	return base64.StdEncoding.EncodeToString(h[:])
+}
+
+// selectWebSocketEncoding selects encoding of outgoing messages from
+// sub-protocols requested by client.
+func selectWebSocketEncoding(r *http.Request, allowed Encoding) (enc Encoding, protocol string, err error) {
+	requested := false
+	for _, v := range r.Header.Values("Sec-WebSocket-Protocol") {
+		for _, p := range strings.Split(v, ",") {
+			p = strings.TrimSpace(p)
+			requested = true
+			switch {
+			case (p == WebSocketProtocolJSON) && ((allowed & EncodingJSON) != 0):
+				return EncodingJSON, p, nil
+			case (p == WebSocketProtocolProtobuf) && ((allowed & EncodingProtobuf) != 0):
+				return EncodingProtobuf, p, nil
+			}
+		}
+	}
+	if requested {
+		return 0, "", &HTTPError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "unsupported websocket sub-protocol: " + strings.Join(r.Header.Values("Sec-WebSocket-Protocol"), ", "),
+		}
+	}
+	if (allowed & EncodingJSON) != 0 {
+		return EncodingJSON, "", nil
+	}
+	return EncodingProtobuf, "", nil
+}
+
+type webSocketMessage struct {
+	opcode  byte
+	payload []byte
+}
+
+// WebSocketConn is a server side WebSocket connection which carries
+// messages of streaming RPC method.
+type WebSocketConn struct {
+	conn  net.Conn
+	br    *bufio.Reader
+	codec *MessageCodec
+	enc   Encoding
+
+	maxMessageSize int64
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	messages chan *webSocketMessage
+	readErr  error
+	readDone chan struct{}
+
+	writeLock sync.Mutex
+	closeSent bool
+}
+
+// UpgradeWebSocket completes WebSocket opening handshake of r and takes
+// over the connection. Encoding of outgoing messages is selected by
+// `json` or `protobuf` sub-protocol, JSON is used if none requested.
+// Incoming messages larger than maxMessageSize are rejected,
+// DefaultWebSocketMessageSize is used if maxMessageSize is not positive.
+// Origin of the handshake is checked with checkOrigin, or with
+// CheckWebSocketOrigin if checkOrigin is nil.
+//
+// Returns HTTPError if the handshake is not acceptable. Response writer w
+// must not be used once the connection is upgraded.
+func UpgradeWebSocket(w http.ResponseWriter, r *http.Request, codec *MessageCodec, allowed Encoding, maxMessageSize int64, checkOrigin func(r *http.Request) bool) (*WebSocketConn, error) {
+	if !IsWebSocketUpgrade(r) {
+		w.Header().Set("Upgrade", "websocket")
+		return nil, &HTTPError{
+			StatusCode: http.StatusUpgradeRequired,
+			Message:    "websocket upgrade required",
+		}
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		return nil, &HTTPError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "unsupported websocket version: " + r.Header.Get("Sec-WebSocket-Version"),
+		}
+	}
+	if checkOrigin == nil {
+		checkOrigin = CheckWebSocketOrigin
+	}
+	if !checkOrigin(r) {
+		return nil, &HTTPError{
+			StatusCode: http.StatusForbidden,
+			Message:    "websocket origin not allowed: " + r.Header.Get("Origin"),
+		}
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		return nil, &HTTPError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "missing websocket key",
+		}
+	}
+	enc, protocol, err := selectWebSocketEncoding(r, allowed)
+	if err != nil {
+		return nil, err
+	}
+	conn, brw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		return nil, &HTTPError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "cannot take over connection",
+			Err:        err,
+		}
+	}
+	conn.SetDeadline(time.Time{})
+	resp := "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: " + webSocketAcceptKey(key) + "\r\n"
+	if protocol != "" {
+		resp += "Sec-WebSocket-Protocol: " + protocol + "\r\n"
+	}
+	brw.WriteString(resp + "\r\n")
+	if err = brw.Flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if maxMessageSize <= 0 {
+		maxMessageSize = DefaultWebSocketMessageSize
+	}
+	ctx, cancel := context.WithCancel(r.Context())
+	c := &WebSocketConn{
+		conn:           conn,
+		br:             brw.Reader,
+		codec:          codec,
+		enc:            enc,
+		maxMessageSize: maxMessageSize,
+		ctx:            ctx,
+		cancel:         cancel,
+		messages:       make(chan *webSocketMessage),
+		readDone:       make(chan struct{}),
+	}
+	go c.readLoop()
+	return c, nil
+}
+
+// Context returns context of the connection. The context is canceled when
+// the connection is closed or aborted by client.
+func (c *WebSocketConn) Context() context.Context {
+	return c.ctx
+}
+
+func (c *WebSocketConn) writeFrame(opcode byte, payload []byte) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	if c.closeSent {
+		return status.Error(codes.Canceled, "websocket closed")
+	}
+	if opcode == webSocketOpClose {
+		c.closeSent = true
+	}
+	frame := make([]byte, 0, len(payload)+10)
+	frame = append(frame, 0x80|opcode)
+	switch l := len(payload); {
+	case l < 126:
+		frame = append(frame, byte(l))
+	case l <= 0xFFFF:
+		frame = append(frame, 126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(l))
+	default:
+		frame = append(frame, 127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(l))
+	}
+	frame = append(frame, payload...)
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+func (c *WebSocketConn) writeClose(closeCode int, reason string) error {
+	// Control frame payload is limited to 125 bytes.
+	for len(reason) > 123 {
+		_, size := utf8.DecodeLastRuneInString(reason)
+		reason = reason[:len(reason)-size]
+	}
+	payload := binary.BigEndian.AppendUint16(nil, uint16(closeCode))
+	return c.writeFrame(webSocketOpClose, append(payload, reason...))
+}
+
+func (c *WebSocketConn) readFrame() (fin bool, opcode byte, payload []byte, err error) {
+	var hdr [8]byte
+	if _, err = io.ReadFull(c.br, hdr[:2]); err != nil {
+		return
+	}
+	fin = (hdr[0] & 0x80) != 0
+	opcode = hdr[0] & 0x0F
+	if (hdr[0] & 0x70) != 0 {
+		err = &webSocketCloseError{closeCode: WebSocketCloseProtocolError, reason: "reserved bits set"}
+		return
+	}
+	if (hdr[1] & 0x80) == 0 {
+		err = &webSocketCloseError{closeCode: WebSocketCloseProtocolError, reason: "frame from client must be masked"}
+		return
+	}
+	length := int64(hdr[1] & 0x7F)
+	switch length {
+	case 126:
+		if _, err = io.ReadFull(c.br, hdr[:2]); err != nil {
+			return
+		}
+		length = int64(binary.BigEndian.Uint16(hdr[:2]))
+	case 127:
+		if _, err = io.ReadFull(c.br, hdr[:8]); err != nil {
+			return
+		}
+		length = int64(binary.BigEndian.Uint64(hdr[:8]) & 0x7FFFFFFFFFFFFFFF)
+	}
+	if (opcode >= webSocketOpClose) && (!fin || (length > 125)) {
+		err = &webSocketCloseError{closeCode: WebSocketCloseProtocolError, reason: "invalid control frame"}
+		return
+	}
+	if length > c.maxMessageSize {
+		err = &webSocketCloseError{closeCode: WebSocketCloseMessageTooBig, reason: "message too big"}
+		return
+	}
+	var maskKey [4]byte
+	if _, err = io.ReadFull(c.br, maskKey[:]); err != nil {
+		return
+	}
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(c.br, payload); err != nil {
+		return
+	}
+	for idx := range payload {
+		payload[idx] ^= maskKey[idx&3]
+	}
+	return
+}
+
+// parseWebSocketClose parses payload of close frame sent by client.
+// Malformed payload is reported as protocol violation.
+func parseWebSocketClose(payload []byte) *webSocketCloseError {
+	switch {
+	case len(payload) == 0:
+		return &webSocketCloseError{closeCode: WebSocketCloseNoStatus, received: true}
+	case len(payload) == 1:
+		return &webSocketCloseError{closeCode: WebSocketCloseProtocolError, reason: "invalid close frame"}
+	}
+	closeCode := int(binary.BigEndian.Uint16(payload))
+	if !isValidWebSocketCloseCode(closeCode) {
+		return &webSocketCloseError{closeCode: WebSocketCloseProtocolError, reason: "invalid close code"}
+	}
+	if !utf8.Valid(payload[2:]) {
+		return &webSocketCloseError{closeCode: WebSocketCloseInvalidPayload, reason: "invalid UTF-8 close reason"}
+	}
+	return &webSocketCloseError{closeCode: closeCode, reason: string(payload[2:]), received: true}
+}
+
+// readMessage reads next data message. Control frames are handled in place.
+// Returns webSocketCloseError when close frame is received or the client
+// violates the protocol.
+func (c *WebSocketConn) readMessage() (*webSocketMessage, error) {
+	var msg *webSocketMessage
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+		switch opcode {
+		case webSocketOpPing:
+			c.writeFrame(webSocketOpPong, payload)
+			continue
+		case webSocketOpPong:
+			continue
+		case webSocketOpClose:
+			return nil, parseWebSocketClose(payload)
+		case webSocketOpContinuation:
+			if msg == nil {
+				return nil, &webSocketCloseError{closeCode: WebSocketCloseProtocolError, reason: "unexpected continuation frame"}
+			}
+			if int64(len(msg.payload)+len(payload)) > c.maxMessageSize {
+				return nil, &webSocketCloseError{closeCode: WebSocketCloseMessageTooBig, reason: "message too big"}
+			}
+			msg.payload = append(msg.payload, payload...)
+		case webSocketOpText, webSocketOpBinary:
+			if msg != nil {
+				return nil, &webSocketCloseError{closeCode: WebSocketCloseProtocolError, reason: "expecting continuation frame"}
+			}
+			msg = &webSocketMessage{opcode: opcode, payload: payload}
+		default:
+			return nil, &webSocketCloseError{closeCode: WebSocketCloseProtocolError, reason: "unknown opcode"}
+		}
+		if fin {
+			if (msg.opcode == webSocketOpText) && !utf8.Valid(msg.payload) {
+				return nil, &webSocketCloseError{closeCode: WebSocketCloseInvalidPayload, reason: "invalid UTF-8 text"}
+			}
+			return msg, nil
+		}
+	}
+}
+
+// readLoop reads messages until close frame or error. Messages read after
+// the context is canceled are discarded.
+func (c *WebSocketConn) readLoop() {
+	defer close(c.readDone)
+	defer close(c.messages)
+	for {
+		msg, err := c.readMessage()
+		if err != nil {
+			c.readErr = c.handleReadError(err)
+			return
+		}
+		select {
+		case c.messages <- msg:
+		case <-c.ctx.Done():
+		}
+	}
+}
+
+// handleReadError answers close frame or protocol violation of client and
+// converts err into the error returned from Recv.
+func (c *WebSocketConn) handleReadError(err error) error {
+	var closeErr *webSocketCloseError
+	if !errors.As(err, &closeErr) {
+		c.cancel()
+		return status.Error(codes.Canceled, "websocket connection lost: "+err.Error())
+	}
+	if !closeErr.received {
+		// Protocol violation of client.
+		c.writeClose(closeErr.closeCode, closeErr.reason)
+		c.cancel()
+		return StatusFromWebSocketClose(closeErr.closeCode, closeErr.reason).Err()
+	}
+	s := StatusFromWebSocketClose(closeErr.closeCode, closeErr.reason)
+	if s.Code() == codes.OK {
+		// Normal closure is taken as half-close. Close frame is answered
+		// once the method finished sending.
+		return io.EOF
+	}
+	// Echo close code of client.
+	c.writeClose(closeErr.closeCode, "")
+	c.cancel()
+	return s.Err()
+}
+
+// ReadMessage reads next message into msg. Text frames are decoded as JSON
+// and binary frames as protobuf.
+// Returns io.EOF when client closed the connection normally.
+func (c *WebSocketConn) ReadMessage(msg proto.Message) error {
+	select {
+	case m, ok := <-c.messages:
+		if !ok {
+			return c.readErr
+		}
+		var err error
+		if m.opcode == webSocketOpBinary {
+			err = proto.Unmarshal(m.payload, msg)
+		} else {
+			err = c.codec.JSONUnmarshal.Unmarshal(m.payload, msg)
+		}
+		if err != nil {
+			return status.Error(codes.InvalidArgument, "cannot decode websocket message: "+err.Error())
+		}
+		return nil
+	case <-c.ctx.Done():
+		select {
+		case <-c.readDone:
+			return c.readErr
+		default:
+		}
+		return status.FromContextError(c.ctx.Err()).Err()
+	}
+}
+
+// WriteMessage writes msg as text frame of JSON or binary frame of protobuf
+// by the negotiated sub-protocol.
+func (c *WebSocketConn) WriteMessage(msg proto.Message) error {
+	if err := c.ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	if c.enc == EncodingProtobuf {
+		buf, err := proto.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		return c.writeFrame(webSocketOpBinary, buf)
+	}
+	buf, err := c.codec.JSONMarshal.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return c.writeFrame(webSocketOpText, buf)
+}
+
+// Close sends close frame with code mapped from gRPC status of err and
+// closes the connection. Normal closure is sent if err is nil.
+func (c *WebSocketConn) Close(err error) {
+	closeCode, reason := WebSocketCloseNormal, ""
+	if err != nil {
+		s := StatusFromError(err)
+		closeCode, reason = WebSocketCodeFromStatus(s.Code()), s.Message()
+	}
+	c.writeClose(closeCode, reason)
+	c.cancel()
+	// Wait for close frame of client.
+	c.conn.SetReadDeadline(time.Now().Add(webSocketCloseTimeout))
+	<-c.readDone
+	c.conn.Close()
+}
+
+// BidiStream adapts WebSocketConn to the server side stream of
+// bidirectional streaming RPC method which receives messages of type *T and
+// sends messages of type *R.
+//
+// Fields set in template are merged into the first received message, or
+// into every received message when mergeAll is set.
+type BidiStream[T, R any] struct {
+	*WebSocketConn
+
+	template proto.Message
+	mergeAll bool
+	received int
+}
+
+// NewBidiStream creates BidiStream on conn.
+func NewBidiStream[T, R any](conn *WebSocketConn, template *T, mergeAll bool) *BidiStream[T, R] {
+	s := &BidiStream[T, R]{
+		WebSocketConn: conn,
+		mergeAll:      mergeAll,
+	}
+	if template != nil {
+		s.template = any(template).(proto.Message)
+	}
+	return s
+}
+
+func (s *BidiStream[T, R]) Recv() (*T, error) {
+	m := new(T)
+	if err := s.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (s *BidiStream[T, R]) Send(m *R) error {
+	return s.SendMsg(m)
+}
+
+// SetHeader is not supported as the response header is sent on upgrade.
+func (s *BidiStream[T, R]) SetHeader(md metadata.MD) error {
+	return errors.New("header already sent")
+}
+
+func (s *BidiStream[T, R]) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+// SetTrailer is not supported and the trailer is discarded.
+func (s *BidiStream[T, R]) SetTrailer(md metadata.MD) {}
+
+func (s *BidiStream[T, R]) SendMsg(m any) error {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return errors.New("message is not proto.Message")
+	}
+	return s.WriteMessage(msg)
+}
+
+func (s *BidiStream[T, R]) RecvMsg(m any) error {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return errors.New("message is not proto.Message")
+	}
+	if err := s.ReadMessage(msg); err != nil {
+		return err
+	}
+	if (s.template != nil) && (s.mergeAll || (s.received == 0)) {
+		proto.Merge(msg, s.template)
+	}
+	s.received++
+	return nil
+}
+
+// BidiStreamClient is the client side stream of bidirectional streaming
+// RPC method.
+type BidiStreamClient[T, R any] interface {
+	Send(*T) error
+	Recv() (*R, error)
+	CloseSend() error
+}
+
+// RelayBidiStream relays messages between stream and client until client
+// finishes. Client must be created with the context of stream.
+func RelayBidiStream[T, R any](stream *BidiStream[T, R], client BidiStreamClient[T, R]) error {
+	var recvErr error
+	recvDone := make(chan struct{})
+	go func() {
+		defer close(recvDone)
+		for {
+			m, err := stream.Recv()
+			if err == io.EOF {
+				client.CloseSend()
+				return
+			}
+			if err != nil {
+				recvErr = err
+				stream.cancel()
+				return
+			}
+			if err = client.Send(m); err != nil {
+				return
+			}
+		}
+	}()
+	for {
+		m, err := client.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			select {
+			case <-recvDone:
+				if recvErr != nil {
+					return recvErr
+				}
+			default:
+			}
+			return err
+		}
+		if err = stream.Send(m); err != nil {
+			return err
+		}
+	}
+}
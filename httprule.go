@@ -0,0 +1,290 @@
+package protocgenghe
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/yinyin/protoc-gen-go-grpc-http-endpoint/ghegen"
+)
+
+// GetGoogleAPIHTTPRule returns google.api.http rule of given method or nil
+// if not defined.
+func GetGoogleAPIHTTPRule(desc protoreflect.MethodDescriptor) *annotations.HttpRule {
+	if m := getExtensionMessage(desc.Options(), annotations.E_Http); m != nil {
+		return m.(*annotations.HttpRule)
+	}
+	return nil
+}
+
+// escapeURLPathLiteral escapes bytes which have special meaning in
+// ParseURLPath grammar.
+func escapeURLPathLiteral(literal string) string {
+	var b strings.Builder
+	for idx := 0; idx < len(literal); idx++ {
+		switch ch := literal[idx]; ch {
+		case '\\', '{', '}':
+			b.WriteByte('\\')
+			b.WriteByte(ch)
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return b.String()
+}
+
+// splitHTTPRuleTemplate splits path template of HttpRule at slashes and
+// verb colon which are not enclosed in braces.
+func splitHTTPRuleTemplate(tmpl string) (segments []string, verb string, err error) {
+	depth := 0
+	start := 0
+	for idx := 0; idx < len(tmpl); idx++ {
+		switch tmpl[idx] {
+		case '{':
+			if depth++; depth > 1 {
+				return nil, "", errors.New("nested variable")
+			}
+		case '}':
+			if depth--; depth < 0 {
+				return nil, "", errors.New("unbalanced braces")
+			}
+		case '/':
+			if depth == 0 {
+				segments = append(segments, tmpl[start:idx])
+				start = idx + 1
+			}
+		case ':':
+			if depth == 0 {
+				verb = tmpl[idx+1:]
+				if verb == "" {
+					return nil, "", errors.New("empty verb")
+				}
+				segments = append(segments, tmpl[start:idx])
+				return
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, "", errors.New("unbalanced braces")
+	}
+	segments = append(segments, tmpl[start:])
+	return
+}
+
+// Patterns of translated variables. Colon is excluded so captures stop
+// before the verb and routes with and without verb can share the same
+// capture node.
+const (
+	httpRuleSegmentPattern      = "!-.0-9;-~"
+	httpRuleMultiSegmentPattern = "!-9;-~"
+)
+
+// checkHTTPRuleSubTemplate checks template of variable such as
+// `shelves/*` or `projects/*/files/**`. The `**` wildcard is only allowed
+// as the last segment.
+func checkHTTPRuleSubTemplate(subTmpl string) error {
+	segments := strings.Split(subTmpl, "/")
+	for idx, segment := range segments {
+		switch {
+		case segment == "":
+			return errors.New("empty segment")
+		case segment == "**":
+			if idx != (len(segments) - 1) {
+				return errors.New("** must be the last segment")
+			}
+		case segment == "*":
+		case strings.ContainsAny(segment, "*{}:"):
+			return errors.New("invalid literal segment: " + segment)
+		}
+	}
+	return nil
+}
+
+// translateHTTPRuleVariable converts `{field}`, `{field=*}` or
+// `{field=**}` into capture of ParseURLPath grammar. Other templates,
+// such as `{name=shelves/*}`, are captured as multiple segments and
+// returned as subTmpl to be checked against the captured value.
+func translateHTTPRuleVariable(segment string) (capture, fieldPath, subTmpl string, err error) {
+	body := segment[1 : len(segment)-1]
+	fieldPath, subTmpl, hasSubTmpl := strings.Cut(body, "=")
+	fieldPath = strings.TrimSpace(fieldPath)
+	if fieldPath == "" {
+		return "", "", "", fmt.Errorf("empty field path in variable: [%s]", segment)
+	}
+	if !hasSubTmpl {
+		subTmpl = "*"
+	}
+	switch subTmpl = strings.TrimSpace(subTmpl); subTmpl {
+	case "*":
+		return "{" + httpRuleSegmentPattern + ", " + fieldPath + "}", fieldPath, "", nil
+	case "**":
+		return "{" + httpRuleMultiSegmentPattern + ", " + fieldPath + "}", fieldPath, "", nil
+	case "":
+		return "", "", "", fmt.Errorf("empty template in variable: [%s]", segment)
+	}
+	if err = checkHTTPRuleSubTemplate(subTmpl); err != nil {
+		return "", "", "", fmt.Errorf("invalid template in variable [%s]: %w", segment, err)
+	}
+	return "{" + httpRuleMultiSegmentPattern + ", " + fieldPath + "}", fieldPath, subTmpl, nil
+}
+
+// TranslateHTTPRuleTemplate converts path template of google.api.http rule,
+// for example `/v1/shelves/{shelf}/books:publish`, into ParseURLPath
+// grammar. The leading slash is removed.
+//
+// Templates of variables which cannot be expressed by capture pattern,
+// such as `shelves/*` of `{name=shelves/*}`, are returned in
+// captureTemplates keyed by field path.
+func TranslateHTTPRuleTemplate(tmpl string) (urlPathPart string, captureTemplates map[string]string, err error) {
+	if !strings.HasPrefix(tmpl, "/") {
+		return "", nil, fmt.Errorf("path template must start with slash: [%s]", tmpl)
+	}
+	segments, verb, err := splitHTTPRuleTemplate(tmpl[1:])
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid path template [%s]: %w", tmpl, err)
+	}
+	translated := make([]string, len(segments))
+	for idx, segment := range segments {
+		switch {
+		case segment == "":
+			return "", nil, fmt.Errorf("empty segment in path template: [%s]", tmpl)
+		case (segment == "*") || (segment == "**"):
+			return "", nil, fmt.Errorf("wildcard without variable is not supported: [%s]", tmpl)
+		case segment[0] == '{':
+			if segment[len(segment)-1] != '}' {
+				return "", nil, fmt.Errorf("variable must be a whole segment: [%s]", tmpl)
+			}
+			var fieldPath, subTmpl string
+			if translated[idx], fieldPath, subTmpl, err = translateHTTPRuleVariable(segment); err != nil {
+				return "", nil, err
+			}
+			if subTmpl == "" {
+				continue
+			}
+			if captureTemplates == nil {
+				captureTemplates = make(map[string]string)
+			}
+			captureTemplates[fieldPath] = subTmpl
+		case strings.ContainsAny(segment, "{}"):
+			return "", nil, fmt.Errorf("variable must be a whole segment: [%s]", tmpl)
+		default:
+			translated[idx] = escapeURLPathLiteral(segment)
+		}
+	}
+	urlPathPart = strings.Join(translated, "/")
+	if urlPathPart[0] == '=' {
+		// Leading `=` refers path of other HTTP method in GHE options.
+		urlPathPart = "\\" + urlPathPart
+	}
+	if verb != "" {
+		urlPathPart += ":" + escapeURLPathLiteral(verb)
+	}
+	return urlPathPart, captureTemplates, nil
+}
+
+func httpRulePattern(rule *annotations.HttpRule) (method, path string, err error) {
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return http.MethodGet, pattern.Get, nil
+	case *annotations.HttpRule_Post:
+		return http.MethodPost, pattern.Post, nil
+	case *annotations.HttpRule_Put:
+		return http.MethodPut, pattern.Put, nil
+	case *annotations.HttpRule_Delete:
+		return http.MethodDelete, pattern.Delete, nil
+	case *annotations.HttpRule_Patch:
+		return http.MethodPatch, pattern.Patch, nil
+	case *annotations.HttpRule_Custom:
+		method = strings.ToUpper(strings.TrimSpace(pattern.Custom.GetKind()))
+		switch method {
+		case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodPatch:
+			return method, pattern.Custom.GetPath(), nil
+		}
+		return "", "", fmt.Errorf("unsupported custom method: [%s]", pattern.Custom.GetKind())
+	}
+	return "", "", errors.New("missing pattern")
+}
+
+// httpRuleMethodSlot returns pointer to path option of given HTTP method.
+func httpRuleMethodSlot(opts *ghegen.GHEMethodOptions, method string) *string {
+	switch method {
+	case http.MethodGet:
+		return &opts.Get
+	case http.MethodPost:
+		return &opts.Post
+	case http.MethodPut:
+		return &opts.Put
+	case http.MethodDelete:
+		return &opts.Delete
+	case http.MethodPatch:
+		return &opts.Patch
+	}
+	return nil
+}
+
+// HTTPRuleMethodOptions is set of GHE method options translated from
+// bindings of google.api.http rule.
+type HTTPRuleMethodOptions struct {
+	Options *ghegen.GHEMethodOptions
+
+	// CaptureTemplates maps field paths to templates of variables which
+	// captured values must match, for example `shelves/*`.
+	CaptureTemplates map[string]string
+}
+
+// HTTPRuleToMethodOptions translates google.api.http rule and its
+// additional bindings into GHE method options. Path templates of rules are
+// absolute and the translated paths must not be prefixed with URL path of
+// service. Fields which are neither captured nor mapped from request body
+// are bound from query parameters.
+//
+// Bindings are merged into one options message unless the HTTP method is
+// already bound or the body options or capture templates differ, in which
+// case the binding is placed into the next options message.
+func HTTPRuleToMethodOptions(rule *annotations.HttpRule) (result []*HTTPRuleMethodOptions, err error) {
+	bindings := append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...)
+	for idx, binding := range bindings {
+		if (idx > 0) && (len(binding.GetAdditionalBindings()) != 0) {
+			return nil, errors.New("nested additional_bindings is not allowed")
+		}
+		method, path, err := httpRulePattern(binding)
+		if err != nil {
+			return nil, err
+		}
+		urlPathPart, captureTemplates, err := TranslateHTTPRuleTemplate(path)
+		if err != nil {
+			return nil, err
+		}
+		body := binding.GetBody()
+		if (body != "") && ((method == http.MethodGet) || (method == http.MethodDelete)) {
+			return nil, fmt.Errorf("body is not allowed for %s: [%s]", method, path)
+		}
+		var target *HTTPRuleMethodOptions
+		for _, ruleOpts := range result {
+			opts := ruleOpts.Options
+			if (*httpRuleMethodSlot(opts, method) == "") && (opts.Body == body) && (opts.ResponseBody == binding.GetResponseBody()) &&
+				maps.Equal(ruleOpts.CaptureTemplates, captureTemplates) {
+				target = ruleOpts
+				break
+			}
+		}
+		if target == nil {
+			target = &HTTPRuleMethodOptions{
+				Options: &ghegen.GHEMethodOptions{
+					Body:         body,
+					ResponseBody: binding.GetResponseBody(),
+					BindQuery:    body != "*",
+				},
+				CaptureTemplates: captureTemplates,
+			}
+			result = append(result, target)
+		}
+		*httpRuleMethodSlot(target.Options, method) = urlPathPart
+	}
+	return result, nil
+}
@@ -0,0 +1,167 @@
+package protocgenghe
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+
+	"github.com/yinyin/protoc-gen-go-grpc-http-endpoint/ghert"
+)
+
+func TestSplitHTTPRuleTemplate(t *testing.T) {
+	tests := []struct {
+		tmpl     string
+		segments []string
+		verb     string
+		hasErr   bool
+	}{
+		{"v1/shelves", []string{"v1", "shelves"}, "", false},
+		{"v1/shelves/{shelf}", []string{"v1", "shelves", "{shelf}"}, "", false},
+		{"v1/{name=shelves/*}/books", []string{"v1", "{name=shelves/*}", "books"}, "", false},
+		{"v1/{name=**}:publish", []string{"v1", "{name=**}"}, "publish", false},
+		{"v1/{name=a:b}:publish", []string{"v1", "{name=a:b}"}, "publish", false},
+		{"v1/shelves:", nil, "", true},
+		{"v1/{a{b}}", nil, "", true},
+		{"v1/{name", nil, "", true},
+		{"v1/name}", nil, "", true},
+	}
+	for _, tt := range tests {
+		segments, verb, err := splitHTTPRuleTemplate(tt.tmpl)
+		if tt.hasErr {
+			if err == nil {
+				t.Errorf("%s: expecting error, got %q %q", tt.tmpl, segments, verb)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.tmpl, err)
+			continue
+		}
+		if !reflect.DeepEqual(segments, tt.segments) || (verb != tt.verb) {
+			t.Errorf("%s: got %q %q, want %q %q", tt.tmpl, segments, verb, tt.segments, tt.verb)
+		}
+	}
+}
+
+func TestTranslateHTTPRuleTemplate(t *testing.T) {
+	tests := []struct {
+		tmpl             string
+		urlPathPart      string
+		captureTemplates map[string]string
+		hasErr           bool
+	}{
+		{"/v1/shelves", "v1/shelves", nil, false},
+		{"/v1/shelves/{shelf}", "v1/shelves/{!-.0-9;-~, shelf}", nil, false},
+		{"/v1/shelves/{shelf=*}/books/{book.id}", "v1/shelves/{!-.0-9;-~, shelf}/books/{!-.0-9;-~, book.id}", nil, false},
+		{"/v1/files/{path=**}", "v1/files/{!-9;-~, path}", nil, false},
+		{"/v1/books/{name=**}:publish", "v1/books/{!-9;-~, name}:publish", nil, false},
+		{"/v1/{name=shelves/*}", "v1/{!-9;-~, name}", map[string]string{"name": "shelves/*"}, false},
+		{"/v1/{name=shelves/*/books/**}/raw", "v1/{!-9;-~, name}/raw", map[string]string{"name": "shelves/*/books/**"}, false},
+		{"/v1/{name=files/**}:download", "v1/{!-9;-~, name}:download", map[string]string{"name": "files/**"}, false},
+		{"/=get/{id}", "\\=get/{!-.0-9;-~, id}", nil, false},
+		{"/v1/{a}b/c", "", nil, true},
+		{"v1/shelves", "", nil, true},
+		{"/v1//shelves", "", nil, true},
+		{"/v1/*", "", nil, true},
+		{"/v1/x{id}", "", nil, true},
+		{"/v1/{=*}", "", nil, true},
+		{"/v1/{id=}", "", nil, true},
+		{"/v1/{name=**/shelves}", "", nil, true},
+		{"/v1/{name=shelves//*}", "", nil, true},
+		{"/v1/{name=shel*}", "", nil, true},
+	}
+	for _, tt := range tests {
+		urlPathPart, captureTemplates, err := TranslateHTTPRuleTemplate(tt.tmpl)
+		if tt.hasErr {
+			if err == nil {
+				t.Errorf("%s: expecting error, got %q", tt.tmpl, urlPathPart)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.tmpl, err)
+			continue
+		}
+		if urlPathPart != tt.urlPathPart {
+			t.Errorf("%s: got %q, want %q", tt.tmpl, urlPathPart, tt.urlPathPart)
+		}
+		if !reflect.DeepEqual(captureTemplates, tt.captureTemplates) {
+			t.Errorf("%s: capture templates %v, want %v", tt.tmpl, captureTemplates, tt.captureTemplates)
+		}
+		if _, err = ParseURLPath(urlPathPart); err != nil {
+			t.Errorf("%s: translated path %q cannot be parsed: %v", tt.tmpl, urlPathPart, err)
+		}
+	}
+}
+
+func TestHTTPRuleToMethodOptions(t *testing.T) {
+	rule := &annotations.HttpRule{
+		Pattern: &annotations.HttpRule_Get{Get: "/v1/{name=shelves/*}"},
+		AdditionalBindings: []*annotations.HttpRule{
+			{Pattern: &annotations.HttpRule_Get{Get: "/v2/{name=shelves/*}"}},
+			{Pattern: &annotations.HttpRule_Post{Post: "/v1/{name=shelves/*}:move"}, Body: "*"},
+			{Pattern: &annotations.HttpRule_Put{Put: "/v1/{name=authors/*}"}},
+			{Pattern: &annotations.HttpRule_Delete{Delete: "/v1/{name=shelves/*}"}},
+		},
+	}
+	result, err := HTTPRuleToMethodOptions(rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	type optionsSummary struct {
+		Get, Post, Put, Delete string
+		Body                   string
+		BindQuery              bool
+		CaptureTemplates       map[string]string
+	}
+	var got []optionsSummary
+	for _, ruleOpts := range result {
+		opts := ruleOpts.Options
+		got = append(got, optionsSummary{
+			Get:              opts.Get,
+			Post:             opts.Post,
+			Put:              opts.Put,
+			Delete:           opts.Delete,
+			Body:             opts.Body,
+			BindQuery:        opts.BindQuery,
+			CaptureTemplates: ruleOpts.CaptureTemplates,
+		})
+	}
+	shelvesTemplates := map[string]string{"name": "shelves/*"}
+	want := []optionsSummary{
+		{Get: "v1/{!-9;-~, name}", Delete: "v1/{!-9;-~, name}", BindQuery: true, CaptureTemplates: shelvesTemplates},
+		{Get: "v2/{!-9;-~, name}", BindQuery: true, CaptureTemplates: shelvesTemplates},
+		{Post: "v1/{!-9;-~, name}:move", Body: "*", CaptureTemplates: shelvesTemplates},
+		{Put: "v1/{!-9;-~, name}", BindQuery: true, CaptureTemplates: map[string]string{"name": "authors/*"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got:\n%+v\nwant:\n%+v", got, want)
+	}
+}
+
+func TestMatchPathTemplate(t *testing.T) {
+	tests := []struct {
+		p, tmpl string
+		matched bool
+	}{
+		{"shelves/s1", "shelves/*", true},
+		{"shelves/", "shelves/*", false},
+		{"shelves", "shelves/*", false},
+		{"shelves/s1/books", "shelves/*", false},
+		{"authors/a1", "shelves/*", false},
+		{"shelves/s1/books/b1", "shelves/*/books/*", true},
+		{"shelves/s1/authors/b1", "shelves/*/books/*", false},
+		{"files", "files/**", true},
+		{"files/a", "files/**", true},
+		{"files/a/b/c", "files/**", true},
+		{"filesx/a", "files/**", false},
+		{"projects/p1/files/a/b", "projects/*/files/**", true},
+		{"projects//files/a", "projects/*/files/**", false},
+	}
+	for _, tt := range tests {
+		if matched := ghert.MatchPathTemplate(tt.p, tt.tmpl); matched != tt.matched {
+			t.Errorf("MatchPathTemplate(%q, %q) = %v, want %v", tt.p, tt.tmpl, matched, tt.matched)
+		}
+	}
+}
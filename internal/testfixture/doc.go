@@ -0,0 +1,18 @@
+// Package testfixture holds code generated from proto files in this folder
+// for tests of the generator and the runtime. RelayService is generated in
+// client handler mode and the other services in server handler mode.
+// Functions named in endpoint options, such as setter functions and custom
+// handlers, are defined in handler.go.
+//
+// Code of protoc-gen-go and protoc-gen-go-grpc is generated from the root of
+// repository with:
+//
+//	protoc -I . -I idl-protos -I PATH_TO_GOOGLEAPIS \
+//		--go_out=. --go_opt=module=github.com/yinyin/protoc-gen-go-grpc-http-endpoint \
+//		--go-grpc_out=. --go-grpc_opt=module=github.com/yinyin/protoc-gen-go-grpc-http-endpoint \
+//		internal/testfixture/*.proto
+//
+// Files ending with _ghe.pb.go and openapi.yaml are golden files of the
+// generator and are updated with `go test -run 'TestGenerate.*Fixture' -update`
+// in the root of repository.
+package testfixture
@@ -0,0 +1,46 @@
+package testfixture
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// setShelfSize is setter function bound to X-Size header of GetNote.
+func setShelfSize(in *HandlerRequest, v int32) error {
+	if v < 0 {
+		return errors.New("negative shelf size")
+	}
+	if in.Shelf == nil {
+		in.Shelf = new(Shelf)
+	}
+	in.Shelf.Size = v
+	return nil
+}
+
+// setNoteFromFile is setter function bound to note_file part of UploadShelf.
+func setNoteFromFile(in *HandlerRequest, part io.Reader) error {
+	buf, err := io.ReadAll(part)
+	if err != nil {
+		return err
+	}
+	in.Note = string(buf)
+	return nil
+}
+
+// extractReplyStatus replies 202 for requests noted as queued and falls
+// back to success_status of the method for the others.
+func extractReplyStatus(out *HandlerReply) int {
+	if out.GetRequest().GetNote() == "queued" {
+		return http.StatusAccepted
+	}
+	return 0
+}
+
+// servePing is custom handler of Ping extra endpoint. Writes the captured
+// name and the value of X-Count header.
+func servePing(w http.ResponseWriter, r *http.Request, pingName string, count int32) {
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte(pingName + ":" + strconv.FormatInt(int64(count), 10)))
+}
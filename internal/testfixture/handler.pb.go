@@ -0,0 +1,495 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v5.26.1
+// source: internal/testfixture/handler.proto
+
+package testfixture
+
+import (
+	_ "github.com/yinyin/protoc-gen-go-grpc-http-endpoint/ghegen"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Shelf is mapped from request body or written as response body.
+type Shelf struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Size int32  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+}
+
+func (x *Shelf) Reset() {
+	*x = Shelf{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_testfixture_handler_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Shelf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Shelf) ProtoMessage() {}
+
+func (x *Shelf) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_testfixture_handler_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Shelf.ProtoReflect.Descriptor instead.
+func (*Shelf) Descriptor() ([]byte, []int) {
+	return file_internal_testfixture_handler_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Shelf) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Shelf) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// HandlerRequest receives values bound from HTTP request.
+type HandlerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Shelf   *Shelf   `protobuf:"bytes,2,opt,name=shelf,proto3" json:"shelf,omitempty"`
+	Note    string   `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	Tags    []string `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	Content []byte   `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *HandlerRequest) Reset() {
+	*x = HandlerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_testfixture_handler_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HandlerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HandlerRequest) ProtoMessage() {}
+
+func (x *HandlerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_testfixture_handler_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HandlerRequest.ProtoReflect.Descriptor instead.
+func (*HandlerRequest) Descriptor() ([]byte, []int) {
+	return file_internal_testfixture_handler_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HandlerRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *HandlerRequest) GetShelf() *Shelf {
+	if x != nil {
+		return x.Shelf
+	}
+	return nil
+}
+
+func (x *HandlerRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *HandlerRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *HandlerRequest) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+// HandlerReply carries the invoked method and the received request.
+type HandlerReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Method    string          `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Request   *HandlerRequest `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"`
+	ItemCount int32           `protobuf:"varint,3,opt,name=item_count,json=itemCount,proto3" json:"item_count,omitempty"`
+}
+
+func (x *HandlerReply) Reset() {
+	*x = HandlerReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_testfixture_handler_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HandlerReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HandlerReply) ProtoMessage() {}
+
+func (x *HandlerReply) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_testfixture_handler_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HandlerReply.ProtoReflect.Descriptor instead.
+func (*HandlerReply) Descriptor() ([]byte, []int) {
+	return file_internal_testfixture_handler_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *HandlerReply) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *HandlerReply) GetRequest() *HandlerRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *HandlerReply) GetItemCount() int32 {
+	if x != nil {
+		return x.ItemCount
+	}
+	return 0
+}
+
+var File_internal_testfixture_handler_proto protoreflect.FileDescriptor
+
+var file_internal_testfixture_handler_proto_rawDesc = []byte{
+	0x0a, 0x22, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x66,
+	0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2f, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72,
+	0x65, 0x1a, 0x11, 0x67, 0x68, 0x65, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x2f, 0x0a, 0x05, 0x53, 0x68, 0x65, 0x6c, 0x66, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x8c, 0x01, 0x0a, 0x0e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x68, 0x65, 0x6c,
+	0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69,
+	0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x53, 0x68, 0x65, 0x6c, 0x66, 0x52, 0x05, 0x73, 0x68, 0x65,
+	0x6c, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x22, 0x7c, 0x0a, 0x0c, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x35, 0x0a, 0x07,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64,
+	0x6c, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x74, 0x65, 0x6d, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x69, 0x74, 0x65, 0x6d, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x32, 0xb1, 0x0d, 0x0a, 0x0e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x62, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
+	0x68, 0x65, 0x6c, 0x66, 0x12, 0x1b, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75,
+	0x72, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x19, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e,
+	0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x1b, 0x92, 0xb5,
+	0x18, 0x17, 0x12, 0x0c, 0x73, 0x68, 0x65, 0x6c, 0x76, 0x65, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d,
+	0x5a, 0x05, 0x73, 0x68, 0x65, 0x6c, 0x66, 0x68, 0x01, 0x12, 0x5c, 0x0a, 0x0b, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x53, 0x68, 0x65, 0x6c, 0x66, 0x12, 0x1b, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66,
+	0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74,
+	0x75, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x15, 0x92, 0xb5, 0x18, 0x11, 0x1a, 0x0c, 0x73, 0x68, 0x65, 0x6c, 0x76, 0x65, 0x73, 0x2f,
+	0x7b, 0x69, 0x64, 0x7d, 0x5a, 0x01, 0x2a, 0x12, 0x65, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x53, 0x68,
+	0x65, 0x6c, 0x66, 0x12, 0x1b, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72,
+	0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x19, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x48,
+	0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x21, 0x92, 0xb5, 0x18,
+	0x1d, 0x0a, 0x0c, 0x73, 0x68, 0x65, 0x6c, 0x76, 0x65, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x62,
+	0x0d, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x73, 0x68, 0x65, 0x6c, 0x66, 0x12, 0x56,
+	0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x68, 0x65, 0x6c, 0x76, 0x65, 0x73, 0x12, 0x1b, 0x2e,
+	0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64,
+	0x6c, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x68, 0x65,
+	0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x0f, 0x92, 0xb5, 0x18, 0x0b, 0x0a, 0x07, 0x73, 0x68, 0x65,
+	0x6c, 0x76, 0x65, 0x73, 0x68, 0x01, 0x12, 0x59, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x53, 0x68, 0x65, 0x6c, 0x76, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69,
+	0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75,
+	0x72, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x10, 0x92, 0xb5, 0x18, 0x0c, 0x0a, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x68, 0x01, 0x70,
+	0x01, 0x12, 0xac, 0x01, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x74, 0x65, 0x12, 0x1b, 0x2e,
+	0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64,
+	0x6c, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x68, 0x65,
+	0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x69, 0x92, 0xb5, 0x18, 0x65, 0x0a, 0x0a, 0x6e, 0x6f, 0x74,
+	0x65, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x7a, 0x0e, 0x58, 0x2d, 0x4e, 0x6f, 0x74, 0x65, 0x3a,
+	0x20, 0x7b, 0x6e, 0x6f, 0x74, 0x65, 0x7d, 0x7a, 0x0d, 0x58, 0x2d, 0x54, 0x61, 0x67, 0x3a, 0x20,
+	0x7b, 0x74, 0x61, 0x67, 0x73, 0x7d, 0x7a, 0x1d, 0x58, 0x2d, 0x53, 0x69, 0x7a, 0x65, 0x3a, 0x20,
+	0x7b, 0x73, 0x65, 0x74, 0x53, 0x68, 0x65, 0x6c, 0x66, 0x53, 0x69, 0x7a, 0x65, 0x28, 0x69, 0x6e,
+	0x74, 0x33, 0x32, 0x29, 0x7d, 0x82, 0x01, 0x18, 0x73, 0x68, 0x65, 0x6c, 0x66, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x3a, 0x20, 0x7b, 0x73, 0x68, 0x65, 0x6c, 0x66, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x7d,
+	0x12, 0x5d, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x68, 0x65, 0x6c, 0x66, 0x12,
+	0x1b, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x48, 0x61,
+	0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67,
+	0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c,
+	0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x16, 0x92, 0xb5, 0x18, 0x12, 0x22, 0x0c, 0x73,
+	0x68, 0x65, 0x6c, 0x76, 0x65, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x88, 0x01, 0xcc, 0x01, 0x12,
+	0x77, 0x0a, 0x09, 0x4d, 0x6f, 0x76, 0x65, 0x53, 0x68, 0x65, 0x6c, 0x66, 0x12, 0x1b, 0x2e, 0x67,
+	0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c,
+	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x68, 0x65, 0x2e,
+	0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x32, 0x92, 0xb5, 0x18, 0x2e, 0x12, 0x11, 0x73, 0x68, 0x65, 0x6c,
+	0x76, 0x65, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x6d, 0x6f, 0x76, 0x65, 0x42, 0x12, 0x65,
+	0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x5a, 0x01, 0x2a, 0x88, 0x01, 0xc9, 0x01, 0x12, 0x6b, 0x0a, 0x0a, 0x50, 0x61, 0x74, 0x63,
+	0x68, 0x53, 0x68, 0x65, 0x6c, 0x66, 0x12, 0x1b, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78,
+	0x74, 0x75, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72,
+	0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x25,
+	0x92, 0xb5, 0x18, 0x21, 0x2a, 0x0c, 0x73, 0x68, 0x65, 0x6c, 0x76, 0x65, 0x73, 0x2f, 0x7b, 0x69,
+	0x64, 0x7d, 0x5a, 0x05, 0x73, 0x68, 0x65, 0x6c, 0x66, 0x92, 0x01, 0x04, 0x6a, 0x73, 0x6f, 0x6e,
+	0x9a, 0x01, 0x02, 0x20, 0x00, 0x12, 0x62, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x53, 0x68, 0x65, 0x6c,
+	0x66, 0x52, 0x61, 0x77, 0x12, 0x1b, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75,
+	0x72, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x19, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e,
+	0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x1b, 0x92, 0xb5,
+	0x18, 0x17, 0x0a, 0x10, 0x73, 0x68, 0x65, 0x6c, 0x76, 0x65, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d,
+	0x2f, 0x72, 0x61, 0x77, 0x9a, 0x01, 0x02, 0x08, 0x01, 0x12, 0xa9, 0x01, 0x0a, 0x0b, 0x55, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x53, 0x68, 0x65, 0x6c, 0x66, 0x12, 0x1b, 0x2e, 0x67, 0x68, 0x65, 0x2e,
+	0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78,
+	0x74, 0x75, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x62, 0x92, 0xb5, 0x18, 0x5e, 0x12, 0x13, 0x73, 0x68, 0x65, 0x6c, 0x76, 0x65, 0x73,
+	0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5a, 0x01, 0x2a, 0xa0,
+	0x01, 0x01, 0xaa, 0x01, 0x12, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x3a, 0x20, 0x7b, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x7d, 0xaa, 0x01, 0x27, 0x6e, 0x6f, 0x74, 0x65, 0x5f, 0x66,
+	0x69, 0x6c, 0x65, 0x3a, 0x20, 0x7b, 0x73, 0x65, 0x74, 0x4e, 0x6f, 0x74, 0x65, 0x46, 0x72, 0x6f,
+	0x6d, 0x46, 0x69, 0x6c, 0x65, 0x28, 0x69, 0x6f, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x65, 0x72, 0x29,
+	0x7d, 0xb0, 0x01, 0x80, 0x08, 0x12, 0x60, 0x0a, 0x0a, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x68,
+	0x65, 0x6c, 0x66, 0x12, 0x1b, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72,
+	0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x19, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x48,
+	0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x18, 0x92, 0xb5, 0x18,
+	0x14, 0x0a, 0x12, 0x73, 0x68, 0x65, 0x6c, 0x76, 0x65, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f,
+	0x77, 0x61, 0x74, 0x63, 0x68, 0x30, 0x01, 0x12, 0x79, 0x0a, 0x0e, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x53, 0x68, 0x65, 0x6c, 0x76, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x67, 0x68, 0x65, 0x2e,
+	0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78,
+	0x74, 0x75, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x2d, 0x92, 0xb5, 0x18, 0x29, 0x12, 0x14, 0x73, 0x68, 0x65, 0x6c, 0x76, 0x65, 0x73,
+	0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x5a, 0x01, 0x2a,
+	0x7a, 0x0e, 0x58, 0x2d, 0x4e, 0x6f, 0x74, 0x65, 0x3a, 0x20, 0x7b, 0x6e, 0x6f, 0x74, 0x65, 0x7d,
+	0x28, 0x01, 0x12, 0x85, 0x01, 0x0a, 0x11, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x46, 0x69,
+	0x72, 0x73, 0x74, 0x53, 0x68, 0x65, 0x6c, 0x66, 0x12, 0x1b, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66,
+	0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74,
+	0x75, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x36, 0x92, 0xb5, 0x18, 0x32, 0x12, 0x1a, 0x73, 0x68, 0x65, 0x6c, 0x76, 0x65, 0x73, 0x2f,
+	0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x2d, 0x66, 0x69, 0x72,
+	0x73, 0x74, 0x5a, 0x01, 0x2a, 0x7a, 0x0e, 0x58, 0x2d, 0x4e, 0x6f, 0x74, 0x65, 0x3a, 0x20, 0x7b,
+	0x6e, 0x6f, 0x74, 0x65, 0x7d, 0xc0, 0x01, 0x01, 0x28, 0x01, 0x1a, 0x5a, 0x92, 0xb5, 0x18, 0x56,
+	0x0a, 0x07, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x1a, 0x47, 0x0a, 0x1c, 0x70, 0x69, 0x6e,
+	0x67, 0x2f, 0x7b, 0x6e, 0x61, 0x6d, 0x65, 0x3a, 0x20, 0x70, 0x69, 0x6e, 0x67, 0x4e, 0x61, 0x6d,
+	0x65, 0x20, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x7d, 0x4a, 0x04, 0x50, 0x69, 0x6e, 0x67, 0x52,
+	0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x50, 0x69, 0x6e, 0x67, 0x7a, 0x16, 0x58, 0x2d, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x3a, 0x20, 0x7b, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x20, 0x69, 0x6e, 0x74, 0x33,
+	0x32, 0x7d, 0x2a, 0x02, 0x10, 0x01, 0x32, 0x85, 0x01, 0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x62, 0x6c,
+	0x65, 0x6d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x56, 0x0a, 0x0a, 0x47, 0x65, 0x74,
+	0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x12, 0x1b, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69,
+	0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75,
+	0x72, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x10, 0x92, 0xb5, 0x18, 0x0c, 0x0a, 0x0a, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x2f, 0x7b, 0x69, 0x64,
+	0x7d, 0x1a, 0x1b, 0x92, 0xb5, 0x18, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d,
+	0x22, 0x0c, 0x70, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x42, 0x61,
+	0x92, 0xb5, 0x18, 0x14, 0x22, 0x0e, 0x0a, 0x09, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e,
+	0x44, 0x10, 0x9a, 0x03, 0x32, 0x02, 0x20, 0x01, 0x5a, 0x47, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x79, 0x69, 0x6e, 0x79, 0x69, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x67, 0x6f, 0x2d, 0x67, 0x72, 0x70, 0x63, 0x2d, 0x68,
+	0x74, 0x74, 0x70, 0x2d, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72,
+	0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_internal_testfixture_handler_proto_rawDescOnce sync.Once
+	file_internal_testfixture_handler_proto_rawDescData = file_internal_testfixture_handler_proto_rawDesc
+)
+
+func file_internal_testfixture_handler_proto_rawDescGZIP() []byte {
+	file_internal_testfixture_handler_proto_rawDescOnce.Do(func() {
+		file_internal_testfixture_handler_proto_rawDescData = protoimpl.X.CompressGZIP(file_internal_testfixture_handler_proto_rawDescData)
+	})
+	return file_internal_testfixture_handler_proto_rawDescData
+}
+
+var file_internal_testfixture_handler_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_internal_testfixture_handler_proto_goTypes = []interface{}{
+	(*Shelf)(nil),          // 0: ghe.fixture.Shelf
+	(*HandlerRequest)(nil), // 1: ghe.fixture.HandlerRequest
+	(*HandlerReply)(nil),   // 2: ghe.fixture.HandlerReply
+}
+var file_internal_testfixture_handler_proto_depIdxs = []int32{
+	0,  // 0: ghe.fixture.HandlerRequest.shelf:type_name -> ghe.fixture.Shelf
+	1,  // 1: ghe.fixture.HandlerReply.request:type_name -> ghe.fixture.HandlerRequest
+	1,  // 2: ghe.fixture.HandlerService.CreateShelf:input_type -> ghe.fixture.HandlerRequest
+	1,  // 3: ghe.fixture.HandlerService.UpdateShelf:input_type -> ghe.fixture.HandlerRequest
+	1,  // 4: ghe.fixture.HandlerService.GetShelf:input_type -> ghe.fixture.HandlerRequest
+	1,  // 5: ghe.fixture.HandlerService.ListShelves:input_type -> ghe.fixture.HandlerRequest
+	1,  // 6: ghe.fixture.HandlerService.SearchShelves:input_type -> ghe.fixture.HandlerRequest
+	1,  // 7: ghe.fixture.HandlerService.GetNote:input_type -> ghe.fixture.HandlerRequest
+	1,  // 8: ghe.fixture.HandlerService.DeleteShelf:input_type -> ghe.fixture.HandlerRequest
+	1,  // 9: ghe.fixture.HandlerService.MoveShelf:input_type -> ghe.fixture.HandlerRequest
+	1,  // 10: ghe.fixture.HandlerService.PatchShelf:input_type -> ghe.fixture.HandlerRequest
+	1,  // 11: ghe.fixture.HandlerService.GetShelfRaw:input_type -> ghe.fixture.HandlerRequest
+	1,  // 12: ghe.fixture.HandlerService.UploadShelf:input_type -> ghe.fixture.HandlerRequest
+	1,  // 13: ghe.fixture.HandlerService.WatchShelf:input_type -> ghe.fixture.HandlerRequest
+	1,  // 14: ghe.fixture.HandlerService.CollectShelves:input_type -> ghe.fixture.HandlerRequest
+	1,  // 15: ghe.fixture.HandlerService.CollectFirstShelf:input_type -> ghe.fixture.HandlerRequest
+	1,  // 16: ghe.fixture.ProblemService.GetProblem:input_type -> ghe.fixture.HandlerRequest
+	2,  // 17: ghe.fixture.HandlerService.CreateShelf:output_type -> ghe.fixture.HandlerReply
+	2,  // 18: ghe.fixture.HandlerService.UpdateShelf:output_type -> ghe.fixture.HandlerReply
+	2,  // 19: ghe.fixture.HandlerService.GetShelf:output_type -> ghe.fixture.HandlerReply
+	2,  // 20: ghe.fixture.HandlerService.ListShelves:output_type -> ghe.fixture.HandlerReply
+	2,  // 21: ghe.fixture.HandlerService.SearchShelves:output_type -> ghe.fixture.HandlerReply
+	2,  // 22: ghe.fixture.HandlerService.GetNote:output_type -> ghe.fixture.HandlerReply
+	2,  // 23: ghe.fixture.HandlerService.DeleteShelf:output_type -> ghe.fixture.HandlerReply
+	2,  // 24: ghe.fixture.HandlerService.MoveShelf:output_type -> ghe.fixture.HandlerReply
+	2,  // 25: ghe.fixture.HandlerService.PatchShelf:output_type -> ghe.fixture.HandlerReply
+	2,  // 26: ghe.fixture.HandlerService.GetShelfRaw:output_type -> ghe.fixture.HandlerReply
+	2,  // 27: ghe.fixture.HandlerService.UploadShelf:output_type -> ghe.fixture.HandlerReply
+	2,  // 28: ghe.fixture.HandlerService.WatchShelf:output_type -> ghe.fixture.HandlerReply
+	2,  // 29: ghe.fixture.HandlerService.CollectShelves:output_type -> ghe.fixture.HandlerReply
+	2,  // 30: ghe.fixture.HandlerService.CollectFirstShelf:output_type -> ghe.fixture.HandlerReply
+	2,  // 31: ghe.fixture.ProblemService.GetProblem:output_type -> ghe.fixture.HandlerReply
+	17, // [17:32] is the sub-list for method output_type
+	2,  // [2:17] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_internal_testfixture_handler_proto_init() }
+func file_internal_testfixture_handler_proto_init() {
+	if File_internal_testfixture_handler_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_internal_testfixture_handler_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Shelf); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_testfixture_handler_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HandlerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_testfixture_handler_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HandlerReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_internal_testfixture_handler_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_internal_testfixture_handler_proto_goTypes,
+		DependencyIndexes: file_internal_testfixture_handler_proto_depIdxs,
+		MessageInfos:      file_internal_testfixture_handler_proto_msgTypes,
+	}.Build()
+	File_internal_testfixture_handler_proto = out.File
+	file_internal_testfixture_handler_proto_rawDesc = nil
+	file_internal_testfixture_handler_proto_goTypes = nil
+	file_internal_testfixture_handler_proto_depIdxs = nil
+}
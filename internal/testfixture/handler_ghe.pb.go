@@ -0,0 +1,1207 @@
+// Code generated by protoc-gen-go-grpc-http-endpoint. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc-http-endpoint v0.0.1
+// - protoc                           (unknown)
+// source: internal/testfixture/handler.proto
+
+package testfixture
+
+import (
+	ghert "github.com/yinyin/protoc-gen-go-grpc-http-endpoint/ghert"
+	codes "google.golang.org/grpc/codes"
+	protojson "google.golang.org/protobuf/encoding/protojson"
+	io "io"
+	http "net/http"
+	strings "strings"
+)
+
+// HandlerServiceHTTPEndpoint serves HTTP endpoints of HandlerService.
+type HandlerServiceHTTPEndpoint struct {
+	srv HandlerServiceServer
+}
+
+// NewHandlerServiceHTTPEndpoint creates HandlerServiceHTTPEndpoint which invokes methods of srv.
+func NewHandlerServiceHTTPEndpoint(srv HandlerServiceServer) *HandlerServiceHTTPEndpoint {
+	return &HandlerServiceHTTPEndpoint{
+		srv: srv,
+	}
+}
+
+// _HandlerService_HTTPErrorWriter writes error responses of HandlerServiceHTTPEndpoint.
+var _HandlerService_HTTPErrorWriter = &ghert.ErrorWriter{
+	StatusCodes: map[codes.Code]int{
+		codes.NotFound: 410,
+	},
+}
+
+// _HandlerService_GetNote_HTTPCodec marshals messages of GetNote.
+var _HandlerService_GetNote_HTTPCodec = &ghert.MessageCodec{
+	JSONMarshal: protojson.MarshalOptions{
+		EmitUnpopulated: true,
+	},
+	JSONUnmarshal: protojson.UnmarshalOptions{
+		DiscardUnknown: true,
+	},
+}
+
+// _HandlerService_SearchShelves_HTTPCodec marshals messages of SearchShelves.
+var _HandlerService_SearchShelves_HTTPCodec = &ghert.MessageCodec{
+	JSONMarshal: protojson.MarshalOptions{
+		EmitUnpopulated: true,
+	},
+	JSONUnmarshal: protojson.UnmarshalOptions{
+		DiscardUnknown: true,
+	},
+}
+
+// _HandlerService_CollectFirstShelf_HTTPCodec marshals messages of CollectFirstShelf.
+var _HandlerService_CollectFirstShelf_HTTPCodec = &ghert.MessageCodec{
+	JSONMarshal: protojson.MarshalOptions{
+		EmitUnpopulated: true,
+	},
+	JSONUnmarshal: protojson.UnmarshalOptions{
+		DiscardUnknown: true,
+	},
+}
+
+// _HandlerService_CollectShelves_HTTPCodec marshals messages of CollectShelves.
+var _HandlerService_CollectShelves_HTTPCodec = &ghert.MessageCodec{
+	JSONMarshal: protojson.MarshalOptions{
+		EmitUnpopulated: true,
+	},
+	JSONUnmarshal: protojson.UnmarshalOptions{
+		DiscardUnknown: true,
+	},
+}
+
+// _HandlerService_MoveShelf_HTTPCodec marshals messages of MoveShelf.
+var _HandlerService_MoveShelf_HTTPCodec = &ghert.MessageCodec{
+	JSONMarshal: protojson.MarshalOptions{
+		EmitUnpopulated: true,
+	},
+	JSONUnmarshal: protojson.UnmarshalOptions{
+		DiscardUnknown: true,
+	},
+}
+
+// _HandlerService_GetShelfRaw_HTTPCodec marshals messages of GetShelfRaw.
+var _HandlerService_GetShelfRaw_HTTPCodec = &ghert.MessageCodec{
+	JSONMarshal: protojson.MarshalOptions{
+		UseProtoNames:   true,
+		EmitUnpopulated: true,
+	},
+	JSONUnmarshal: protojson.UnmarshalOptions{
+		DiscardUnknown: true,
+	},
+}
+
+// _HandlerService_UploadShelf_HTTPCodec marshals messages of UploadShelf.
+var _HandlerService_UploadShelf_HTTPCodec = &ghert.MessageCodec{
+	JSONMarshal: protojson.MarshalOptions{
+		EmitUnpopulated: true,
+	},
+	JSONUnmarshal: protojson.UnmarshalOptions{
+		DiscardUnknown: true,
+	},
+}
+
+// _HandlerService_WatchShelf_HTTPCodec marshals messages of WatchShelf.
+var _HandlerService_WatchShelf_HTTPCodec = &ghert.MessageCodec{
+	JSONMarshal: protojson.MarshalOptions{
+		EmitUnpopulated: true,
+	},
+	JSONUnmarshal: protojson.UnmarshalOptions{
+		DiscardUnknown: true,
+	},
+}
+
+// _HandlerService_GetShelf_HTTPCodec marshals messages of GetShelf.
+var _HandlerService_GetShelf_HTTPCodec = &ghert.MessageCodec{
+	JSONMarshal: protojson.MarshalOptions{
+		EmitUnpopulated: true,
+	},
+	JSONUnmarshal: protojson.UnmarshalOptions{
+		DiscardUnknown: true,
+	},
+}
+
+// _HandlerService_CreateShelf_HTTPCodec marshals messages of CreateShelf.
+var _HandlerService_CreateShelf_HTTPCodec = &ghert.MessageCodec{
+	JSONMarshal: protojson.MarshalOptions{
+		EmitUnpopulated: true,
+	},
+	JSONUnmarshal: protojson.UnmarshalOptions{
+		DiscardUnknown: true,
+	},
+}
+
+// _HandlerService_UpdateShelf_HTTPCodec marshals messages of UpdateShelf.
+var _HandlerService_UpdateShelf_HTTPCodec = &ghert.MessageCodec{
+	JSONMarshal: protojson.MarshalOptions{
+		EmitUnpopulated: true,
+	},
+	JSONUnmarshal: protojson.UnmarshalOptions{
+		DiscardUnknown: true,
+	},
+}
+
+// _HandlerService_DeleteShelf_HTTPCodec marshals messages of DeleteShelf.
+var _HandlerService_DeleteShelf_HTTPCodec = &ghert.MessageCodec{
+	JSONMarshal: protojson.MarshalOptions{
+		EmitUnpopulated: true,
+	},
+	JSONUnmarshal: protojson.UnmarshalOptions{
+		DiscardUnknown: true,
+	},
+}
+
+// _HandlerService_PatchShelf_HTTPCodec marshals messages of PatchShelf.
+var _HandlerService_PatchShelf_HTTPCodec = &ghert.MessageCodec{
+	JSONMarshal: protojson.MarshalOptions{
+		EmitUnpopulated: true,
+	},
+}
+
+// _HandlerService_ListShelves_HTTPCodec marshals messages of ListShelves.
+var _HandlerService_ListShelves_HTTPCodec = &ghert.MessageCodec{
+	JSONMarshal: protojson.MarshalOptions{
+		EmitUnpopulated: true,
+	},
+	JSONUnmarshal: protojson.UnmarshalOptions{
+		DiscardUnknown: true,
+	},
+}
+
+// ServeHTTP implements http.Handler interface.
+func (hnd *HandlerServiceHTTPEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p0 := r.URL.EscapedPath()
+	for (len(p0) != 0) && (p0[0] == '/') {
+		p0 = p0[1:]
+	}
+	// handler/
+	if strings.HasPrefix(p0, "handler/") {
+		p1 := p0[8:]
+		if len(p1) != 0 {
+			switch p1[0] {
+			case 'n':
+				// notes/
+				if strings.HasPrefix(p1, "notes/") {
+					p2 := p1[6:]
+					// {{capture: 0xFFFF7FFF00000000 0x7FFFFFFFFFFFFFFF}}
+					if c0 := p2[:ghert.CaptureLen(p2, 0xffff7fff00000000, 0x7fffffffffffffff)]; len(c0) != 0 {
+						p3 := p2[len(c0):]
+						if len(p3) == 0 {
+							switch r.Method {
+							case http.MethodGet:
+								hnd.serveHandlerServiceGetNoteByGet(w, r, c0)
+								return
+							}
+							_HandlerService_HTTPErrorWriter.WriteMethodNotAllowed(w, r, "HandlerServiceGetNote", "GET")
+							return
+						}
+					}
+				}
+			case 'p':
+				// ping/
+				if strings.HasPrefix(p1, "ping/") {
+					p2 := p1[5:]
+					// {{capture: 0xFFFF7FFF00000000 0x7FFFFFFFFFFFFFFF}}
+					if c0 := p2[:ghert.CaptureLen(p2, 0xffff7fff00000000, 0x7fffffffffffffff)]; len(c0) != 0 {
+						p3 := p2[len(c0):]
+						if len(p3) == 0 {
+							switch r.Method {
+							case http.MethodGet:
+								hnd.serveHandlerServicePingByGet(w, r, c0)
+								return
+							}
+							_HandlerService_HTTPErrorWriter.WriteMethodNotAllowed(w, r, "HandlerServicePing", "GET")
+							return
+						}
+					}
+				}
+			case 's':
+				// s
+				if strings.HasPrefix(p1, "s") {
+					p2 := p1[1:]
+					if len(p2) != 0 {
+						switch p2[0] {
+						case 'e':
+							// earch
+							if strings.HasPrefix(p2, "earch") {
+								p3 := p2[5:]
+								if len(p3) == 0 {
+									switch r.Method {
+									case http.MethodGet:
+										hnd.serveHandlerServiceSearchShelvesByGet(w, r)
+										return
+									}
+									_HandlerService_HTTPErrorWriter.WriteMethodNotAllowed(w, r, "HandlerServiceSearchShelves", "GET")
+									return
+								}
+							}
+						case 'h':
+							// helves
+							if strings.HasPrefix(p2, "helves") {
+								p3 := p2[6:]
+								if len(p3) == 0 {
+									switch r.Method {
+									case http.MethodGet:
+										hnd.serveHandlerServiceListShelvesByGet(w, r)
+										return
+									}
+									_HandlerService_HTTPErrorWriter.WriteMethodNotAllowed(w, r, "HandlerServiceListShelves", "GET")
+									return
+								}
+								// /
+								if strings.HasPrefix(p3, "/") {
+									p4 := p3[1:]
+									// {{capture: 0xFFFF7FFF00000000 0x7FFFFFFFFFFFFFFF}}
+									if c0 := p4[:ghert.CaptureLen(p4, 0xffff7fff00000000, 0x7fffffffffffffff)]; len(c0) != 0 {
+										p5 := p4[len(c0):]
+										if len(p5) == 0 {
+											switch r.Method {
+											case http.MethodGet:
+												hnd.serveHandlerServiceGetShelfByGet(w, r, c0)
+												return
+											case http.MethodPost:
+												hnd.serveHandlerServiceCreateShelfByPost(w, r, c0)
+												return
+											case http.MethodPut:
+												hnd.serveHandlerServiceUpdateShelfByPut(w, r, c0)
+												return
+											case http.MethodDelete:
+												hnd.serveHandlerServiceDeleteShelfByDelete(w, r, c0)
+												return
+											case http.MethodPatch:
+												hnd.serveHandlerServicePatchShelfByPatch(w, r, c0)
+												return
+											}
+											_HandlerService_HTTPErrorWriter.WriteMethodNotAllowed(w, r, "", "GET, POST, PUT, DELETE, PATCH")
+											return
+										}
+										// /
+										if strings.HasPrefix(p5, "/") {
+											p6 := p5[1:]
+											if len(p6) != 0 {
+												switch p6[0] {
+												case 'c':
+													// collect
+													if strings.HasPrefix(p6, "collect") {
+														p7 := p6[7:]
+														if len(p7) == 0 {
+															switch r.Method {
+															case http.MethodPost:
+																hnd.serveHandlerServiceCollectShelvesByPost(w, r, c0)
+																return
+															}
+															_HandlerService_HTTPErrorWriter.WriteMethodNotAllowed(w, r, "HandlerServiceCollectShelves", "POST")
+															return
+														}
+														// -first
+														if strings.HasPrefix(p7, "-first") {
+															p8 := p7[6:]
+															if len(p8) == 0 {
+																switch r.Method {
+																case http.MethodPost:
+																	hnd.serveHandlerServiceCollectFirstShelfByPost(w, r, c0)
+																	return
+																}
+																_HandlerService_HTTPErrorWriter.WriteMethodNotAllowed(w, r, "HandlerServiceCollectFirstShelf", "POST")
+																return
+															}
+														}
+													}
+												case 'm':
+													// move
+													if strings.HasPrefix(p6, "move") {
+														p7 := p6[4:]
+														if len(p7) == 0 {
+															switch r.Method {
+															case http.MethodPost:
+																hnd.serveHandlerServiceMoveShelfByPost(w, r, c0)
+																return
+															}
+															_HandlerService_HTTPErrorWriter.WriteMethodNotAllowed(w, r, "HandlerServiceMoveShelf", "POST")
+															return
+														}
+													}
+												case 'r':
+													// raw
+													if strings.HasPrefix(p6, "raw") {
+														p7 := p6[3:]
+														if len(p7) == 0 {
+															switch r.Method {
+															case http.MethodGet:
+																hnd.serveHandlerServiceGetShelfRawByGet(w, r, c0)
+																return
+															}
+															_HandlerService_HTTPErrorWriter.WriteMethodNotAllowed(w, r, "HandlerServiceGetShelfRaw", "GET")
+															return
+														}
+													}
+												case 'u':
+													// upload
+													if strings.HasPrefix(p6, "upload") {
+														p7 := p6[6:]
+														if len(p7) == 0 {
+															switch r.Method {
+															case http.MethodPost:
+																hnd.serveHandlerServiceUploadShelfByPost(w, r, c0)
+																return
+															}
+															_HandlerService_HTTPErrorWriter.WriteMethodNotAllowed(w, r, "HandlerServiceUploadShelf", "POST")
+															return
+														}
+													}
+												case 'w':
+													// watch
+													if strings.HasPrefix(p6, "watch") {
+														p7 := p6[5:]
+														if len(p7) == 0 {
+															switch r.Method {
+															case http.MethodGet:
+																hnd.serveHandlerServiceWatchShelfByGet(w, r, c0)
+																return
+															}
+															_HandlerService_HTTPErrorWriter.WriteMethodNotAllowed(w, r, "HandlerServiceWatchShelf", "GET")
+															return
+														}
+													}
+												}
+											}
+										}
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	_HandlerService_HTTPErrorWriter.WriteRouteNotFound(w, r)
+}
+
+// serveHandlerServiceGetNoteByGet handles GET request on `handler/notes/{id}`.
+func (hnd *HandlerServiceHTTPEndpoint) serveHandlerServiceGetNoteByGet(w http.ResponseWriter, r *http.Request, captureId string) {
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.AllEncodings)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceGetNote", err)
+		return
+	}
+	in := new(HandlerRequest)
+	if values := ghert.HeaderValues(r, "X-Note"); len(values) != 0 {
+		value := values[0]
+		v, err := ghert.DecodeQueryString(value)
+		if err != nil {
+			_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceGetNote", ghert.NewDecodeError("header X-Note", value, err))
+			return
+		}
+		in.Note = v
+	}
+	for _, value := range ghert.HeaderValues(r, "X-Tag") {
+		v, err := ghert.DecodeQueryString(value)
+		if err != nil {
+			_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceGetNote", ghert.NewDecodeError("header X-Tag", value, err))
+			return
+		}
+		in.Tags = append(in.Tags, v)
+	}
+	if values := ghert.HeaderValues(r, "X-Size"); len(values) != 0 {
+		v, err := ghert.DecodeInt32(values[0])
+		if err == nil {
+			err = setShelfSize(in, v)
+		}
+		if err != nil {
+			_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceGetNote", ghert.NewDecodeError("header X-Size", values[0], err))
+			return
+		}
+	}
+	if values := ghert.CookieValues(r, "shelf_name"); len(values) != 0 {
+		value := values[0]
+		v, err := ghert.DecodeQueryString(value)
+		if err != nil {
+			_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceGetNote", ghert.NewDecodeError("cookie shelf_name", value, err))
+			return
+		}
+		if in.Shelf == nil {
+			in.Shelf = new(Shelf)
+		}
+		in.Shelf.Name = v
+	}
+	{
+		v, err := ghert.DecodeString(captureId)
+		if err != nil {
+			_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceGetNote", ghert.NewDecodeError("id", captureId, err))
+			return
+		}
+		in.Id = v
+	}
+	out, err := hnd.srv.GetNote(r.Context(), in)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceGetNote", err)
+		return
+	}
+	_HandlerService_GetNote_HTTPCodec.WriteResponse(w, r, _HandlerService_HTTPErrorWriter, "HandlerServiceGetNote", respEncoding, http.StatusOK, out)
+}
+
+// serveHandlerServicePingByGet handles GET request on `handler/ping/{name: pingName string}`.
+func (hnd *HandlerServiceHTTPEndpoint) serveHandlerServicePingByGet(w http.ResponseWriter, r *http.Request, captureName string) {
+	param0, err := ghert.DecodeString(captureName)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServicePing", ghert.NewDecodeError("name", captureName, err))
+		return
+	}
+	var param1 int32
+	if values := ghert.HeaderValues(r, "X-Count"); len(values) != 0 {
+		v, err := ghert.DecodeInt32(values[0])
+		if err != nil {
+			_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServicePing", ghert.NewDecodeError("header X-Count", values[0], err))
+			return
+		}
+		param1 = v
+	}
+	servePing(w, r, param0, param1)
+}
+
+// serveHandlerServiceSearchShelvesByGet handles GET request on `handler/search`.
+func (hnd *HandlerServiceHTTPEndpoint) serveHandlerServiceSearchShelvesByGet(w http.ResponseWriter, r *http.Request) {
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.AllEncodings)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceSearchShelves", err)
+		return
+	}
+	in := new(HandlerRequest)
+	{
+		query, err := ghert.ParseQuery(r)
+		if err != nil {
+			_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceSearchShelves", err)
+			return
+		}
+		for key, values := range query {
+			switch key {
+			case "id":
+				if len(values) > 1 {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceSearchShelves", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeQueryString(value)
+				if err != nil {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceSearchShelves", ghert.NewDecodeError("id", value, err))
+					return
+				}
+				in.Id = v
+			case "shelf.name":
+				if len(values) > 1 {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceSearchShelves", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeQueryString(value)
+				if err != nil {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceSearchShelves", ghert.NewDecodeError("shelf.name", value, err))
+					return
+				}
+				if in.Shelf == nil {
+					in.Shelf = new(Shelf)
+				}
+				in.Shelf.Name = v
+			case "shelf.size":
+				if len(values) > 1 {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceSearchShelves", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeInt32(value)
+				if err != nil {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceSearchShelves", ghert.NewDecodeError("shelf.size", value, err))
+					return
+				}
+				if in.Shelf == nil {
+					in.Shelf = new(Shelf)
+				}
+				in.Shelf.Size = v
+			case "note":
+				if len(values) > 1 {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceSearchShelves", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeQueryString(value)
+				if err != nil {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceSearchShelves", ghert.NewDecodeError("note", value, err))
+					return
+				}
+				in.Note = v
+			case "tags":
+				for _, value := range values {
+					v, err := ghert.DecodeQueryString(value)
+					if err != nil {
+						_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceSearchShelves", ghert.NewDecodeError("tags", value, err))
+						return
+					}
+					in.Tags = append(in.Tags, v)
+				}
+			case "content":
+				if len(values) > 1 {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceSearchShelves", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeQueryBytes(value)
+				if err != nil {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceSearchShelves", ghert.NewDecodeError("content", value, err))
+					return
+				}
+				in.Content = v
+			default:
+				_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceSearchShelves", ghert.NewDecodeError(key, values[0], ghert.ErrUnknownQueryParameter))
+				return
+			}
+		}
+	}
+	out, err := hnd.srv.SearchShelves(r.Context(), in)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceSearchShelves", err)
+		return
+	}
+	_HandlerService_SearchShelves_HTTPCodec.WriteResponse(w, r, _HandlerService_HTTPErrorWriter, "HandlerServiceSearchShelves", respEncoding, http.StatusOK, out)
+}
+
+// serveHandlerServiceCollectFirstShelfByPost handles POST request on `handler/shelves/{id}/collect-first`.
+func (hnd *HandlerServiceHTTPEndpoint) serveHandlerServiceCollectFirstShelfByPost(w http.ResponseWriter, r *http.Request, captureId string) {
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.AllEncodings)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceCollectFirstShelf", err)
+		return
+	}
+	reader, err := ghert.NewMessageStreamReader(r, _HandlerService_CollectFirstShelf_HTTPCodec, ghert.AllEncodings)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceCollectFirstShelf", err)
+		return
+	}
+	in := new(HandlerRequest)
+	if values := ghert.HeaderValues(r, "X-Note"); len(values) != 0 {
+		value := values[0]
+		v, err := ghert.DecodeQueryString(value)
+		if err != nil {
+			_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceCollectFirstShelf", ghert.NewDecodeError("header X-Note", value, err))
+			return
+		}
+		in.Note = v
+	}
+	{
+		v, err := ghert.DecodeString(captureId)
+		if err != nil {
+			_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceCollectFirstShelf", ghert.NewDecodeError("id", captureId, err))
+			return
+		}
+		in.Id = v
+	}
+	stream := ghert.NewClientStream[HandlerRequest, HandlerReply](w, r, reader, in, false)
+	if err = hnd.srv.CollectFirstShelf(stream); err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceCollectFirstShelf", err)
+		return
+	}
+	out, err := stream.Reply()
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceCollectFirstShelf", err)
+		return
+	}
+	_HandlerService_CollectFirstShelf_HTTPCodec.WriteResponse(w, r, _HandlerService_HTTPErrorWriter, "HandlerServiceCollectFirstShelf", respEncoding, http.StatusOK, out)
+}
+
+// serveHandlerServiceCollectShelvesByPost handles POST request on `handler/shelves/{id}/collect`.
+func (hnd *HandlerServiceHTTPEndpoint) serveHandlerServiceCollectShelvesByPost(w http.ResponseWriter, r *http.Request, captureId string) {
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.AllEncodings)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceCollectShelves", err)
+		return
+	}
+	reader, err := ghert.NewMessageStreamReader(r, _HandlerService_CollectShelves_HTTPCodec, ghert.AllEncodings)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceCollectShelves", err)
+		return
+	}
+	in := new(HandlerRequest)
+	if values := ghert.HeaderValues(r, "X-Note"); len(values) != 0 {
+		value := values[0]
+		v, err := ghert.DecodeQueryString(value)
+		if err != nil {
+			_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceCollectShelves", ghert.NewDecodeError("header X-Note", value, err))
+			return
+		}
+		in.Note = v
+	}
+	{
+		v, err := ghert.DecodeString(captureId)
+		if err != nil {
+			_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceCollectShelves", ghert.NewDecodeError("id", captureId, err))
+			return
+		}
+		in.Id = v
+	}
+	stream := ghert.NewClientStream[HandlerRequest, HandlerReply](w, r, reader, in, true)
+	if err = hnd.srv.CollectShelves(stream); err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceCollectShelves", err)
+		return
+	}
+	out, err := stream.Reply()
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceCollectShelves", err)
+		return
+	}
+	_HandlerService_CollectShelves_HTTPCodec.WriteResponse(w, r, _HandlerService_HTTPErrorWriter, "HandlerServiceCollectShelves", respEncoding, http.StatusOK, out)
+}
+
+// serveHandlerServiceMoveShelfByPost handles POST request on `handler/shelves/{id}/move`.
+func (hnd *HandlerServiceHTTPEndpoint) serveHandlerServiceMoveShelfByPost(w http.ResponseWriter, r *http.Request, captureId string) {
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.AllEncodings)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceMoveShelf", err)
+		return
+	}
+	in := new(HandlerRequest)
+	if err := _HandlerService_MoveShelf_HTTPCodec.DecodeRequest(r, ghert.AllEncodings, in); err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceMoveShelf", err)
+		return
+	}
+	{
+		v, err := ghert.DecodeString(captureId)
+		if err != nil {
+			_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceMoveShelf", ghert.NewDecodeError("id", captureId, err))
+			return
+		}
+		in.Id = v
+	}
+	out, err := hnd.srv.MoveShelf(r.Context(), in)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceMoveShelf", err)
+		return
+	}
+	statusCode := extractReplyStatus(out)
+	if statusCode == 0 {
+		statusCode = 201
+	}
+	_HandlerService_MoveShelf_HTTPCodec.WriteResponse(w, r, _HandlerService_HTTPErrorWriter, "HandlerServiceMoveShelf", respEncoding, statusCode, out)
+}
+
+// serveHandlerServiceGetShelfRawByGet handles GET request on `handler/shelves/{id}/raw`.
+func (hnd *HandlerServiceHTTPEndpoint) serveHandlerServiceGetShelfRawByGet(w http.ResponseWriter, r *http.Request, captureId string) {
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.AllEncodings)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceGetShelfRaw", err)
+		return
+	}
+	in := new(HandlerRequest)
+	{
+		v, err := ghert.DecodeString(captureId)
+		if err != nil {
+			_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceGetShelfRaw", ghert.NewDecodeError("id", captureId, err))
+			return
+		}
+		in.Id = v
+	}
+	out, err := hnd.srv.GetShelfRaw(r.Context(), in)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceGetShelfRaw", err)
+		return
+	}
+	_HandlerService_GetShelfRaw_HTTPCodec.WriteResponse(w, r, _HandlerService_HTTPErrorWriter, "HandlerServiceGetShelfRaw", respEncoding, http.StatusOK, out)
+}
+
+// serveHandlerServiceUploadShelfByPost handles POST request on `handler/shelves/{id}/upload`.
+func (hnd *HandlerServiceHTTPEndpoint) serveHandlerServiceUploadShelfByPost(w http.ResponseWriter, r *http.Request, captureId string) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1024)
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.AllEncodings)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceUploadShelf", err)
+		return
+	}
+	in := new(HandlerRequest)
+	if ghert.IsFormRequest(r) {
+		form, err := ghert.ParseForm(r, func(name string, part io.Reader) error {
+			switch name {
+			case "content":
+				v, err := ghert.ReadFormFile(part)
+				if err != nil {
+					return err
+				}
+				in.Content = v
+			case "note_file":
+				if err := setNoteFromFile(in, part); err != nil {
+					return ghert.NewDecodeError("form file note_file", "", err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceUploadShelf", err)
+			return
+		}
+		for key, values := range form {
+			switch key {
+			case "shelf.name":
+				if len(values) > 1 {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceUploadShelf", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeQueryString(value)
+				if err != nil {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceUploadShelf", ghert.NewDecodeError("shelf.name", value, err))
+					return
+				}
+				if in.Shelf == nil {
+					in.Shelf = new(Shelf)
+				}
+				in.Shelf.Name = v
+			case "shelf.size":
+				if len(values) > 1 {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceUploadShelf", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeInt32(value)
+				if err != nil {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceUploadShelf", ghert.NewDecodeError("shelf.size", value, err))
+					return
+				}
+				if in.Shelf == nil {
+					in.Shelf = new(Shelf)
+				}
+				in.Shelf.Size = v
+			case "note":
+				if len(values) > 1 {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceUploadShelf", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeQueryString(value)
+				if err != nil {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceUploadShelf", ghert.NewDecodeError("note", value, err))
+					return
+				}
+				in.Note = v
+			case "tags":
+				for _, value := range values {
+					v, err := ghert.DecodeQueryString(value)
+					if err != nil {
+						_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceUploadShelf", ghert.NewDecodeError("tags", value, err))
+						return
+					}
+					in.Tags = append(in.Tags, v)
+				}
+			}
+		}
+	} else {
+		if err := _HandlerService_UploadShelf_HTTPCodec.DecodeRequest(r, ghert.AllEncodings, in); err != nil {
+			_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceUploadShelf", err)
+			return
+		}
+	}
+	{
+		v, err := ghert.DecodeString(captureId)
+		if err != nil {
+			_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceUploadShelf", ghert.NewDecodeError("id", captureId, err))
+			return
+		}
+		in.Id = v
+	}
+	out, err := hnd.srv.UploadShelf(r.Context(), in)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceUploadShelf", err)
+		return
+	}
+	_HandlerService_UploadShelf_HTTPCodec.WriteResponse(w, r, _HandlerService_HTTPErrorWriter, "HandlerServiceUploadShelf", respEncoding, http.StatusOK, out)
+}
+
+// serveHandlerServiceWatchShelfByGet handles GET request on `handler/shelves/{id}/watch`.
+func (hnd *HandlerServiceHTTPEndpoint) serveHandlerServiceWatchShelfByGet(w http.ResponseWriter, r *http.Request, captureId string) {
+	streamFormat, err := ghert.NegotiateStreamFormat(r, ghert.AllStreamFormats)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceWatchShelf", err)
+		return
+	}
+	in := new(HandlerRequest)
+	{
+		v, err := ghert.DecodeString(captureId)
+		if err != nil {
+			_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceWatchShelf", ghert.NewDecodeError("id", captureId, err))
+			return
+		}
+		in.Id = v
+	}
+	stream := ghert.NewServerStream[HandlerReply](w, r, _HandlerService_WatchShelf_HTTPCodec, streamFormat, http.StatusOK)
+	if err = hnd.srv.WatchShelf(in, stream); err != nil {
+		if stream.Started() {
+			stream.WriteError(err)
+			return
+		}
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceWatchShelf", err)
+		return
+	}
+	stream.Finish()
+}
+
+// serveHandlerServiceGetShelfByGet handles GET request on `handler/shelves/{id}`.
+func (hnd *HandlerServiceHTTPEndpoint) serveHandlerServiceGetShelfByGet(w http.ResponseWriter, r *http.Request, captureId string) {
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.AllEncodings)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceGetShelf", err)
+		return
+	}
+	in := new(HandlerRequest)
+	{
+		v, err := ghert.DecodeString(captureId)
+		if err != nil {
+			_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceGetShelf", ghert.NewDecodeError("id", captureId, err))
+			return
+		}
+		in.Id = v
+	}
+	out, err := hnd.srv.GetShelf(r.Context(), in)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceGetShelf", err)
+		return
+	}
+	_HandlerService_GetShelf_HTTPCodec.WriteResponse(w, r, _HandlerService_HTTPErrorWriter, "HandlerServiceGetShelf", respEncoding, http.StatusOK, out.GetRequest().GetShelf())
+}
+
+// serveHandlerServiceCreateShelfByPost handles POST request on `handler/shelves/{id}`.
+func (hnd *HandlerServiceHTTPEndpoint) serveHandlerServiceCreateShelfByPost(w http.ResponseWriter, r *http.Request, captureId string) {
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.AllEncodings)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceCreateShelf", err)
+		return
+	}
+	in := new(HandlerRequest)
+	if in.Shelf == nil {
+		in.Shelf = new(Shelf)
+	}
+	if err := _HandlerService_CreateShelf_HTTPCodec.DecodeRequest(r, ghert.AllEncodings, in.Shelf); err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceCreateShelf", err)
+		return
+	}
+	{
+		query, err := ghert.ParseQuery(r)
+		if err != nil {
+			_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceCreateShelf", err)
+			return
+		}
+		for key, values := range query {
+			switch key {
+			case "note":
+				if len(values) > 1 {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceCreateShelf", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeQueryString(value)
+				if err != nil {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceCreateShelf", ghert.NewDecodeError("note", value, err))
+					return
+				}
+				in.Note = v
+			case "tags":
+				for _, value := range values {
+					v, err := ghert.DecodeQueryString(value)
+					if err != nil {
+						_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceCreateShelf", ghert.NewDecodeError("tags", value, err))
+						return
+					}
+					in.Tags = append(in.Tags, v)
+				}
+			case "content":
+				if len(values) > 1 {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceCreateShelf", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeQueryBytes(value)
+				if err != nil {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceCreateShelf", ghert.NewDecodeError("content", value, err))
+					return
+				}
+				in.Content = v
+			}
+		}
+	}
+	{
+		v, err := ghert.DecodeString(captureId)
+		if err != nil {
+			_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceCreateShelf", ghert.NewDecodeError("id", captureId, err))
+			return
+		}
+		in.Id = v
+	}
+	out, err := hnd.srv.CreateShelf(r.Context(), in)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceCreateShelf", err)
+		return
+	}
+	_HandlerService_CreateShelf_HTTPCodec.WriteResponse(w, r, _HandlerService_HTTPErrorWriter, "HandlerServiceCreateShelf", respEncoding, http.StatusOK, out)
+}
+
+// serveHandlerServiceUpdateShelfByPut handles PUT request on `handler/shelves/{id}`.
+func (hnd *HandlerServiceHTTPEndpoint) serveHandlerServiceUpdateShelfByPut(w http.ResponseWriter, r *http.Request, captureId string) {
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.AllEncodings)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceUpdateShelf", err)
+		return
+	}
+	in := new(HandlerRequest)
+	if err := _HandlerService_UpdateShelf_HTTPCodec.DecodeRequest(r, ghert.AllEncodings, in); err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceUpdateShelf", err)
+		return
+	}
+	{
+		v, err := ghert.DecodeString(captureId)
+		if err != nil {
+			_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceUpdateShelf", ghert.NewDecodeError("id", captureId, err))
+			return
+		}
+		in.Id = v
+	}
+	out, err := hnd.srv.UpdateShelf(r.Context(), in)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceUpdateShelf", err)
+		return
+	}
+	_HandlerService_UpdateShelf_HTTPCodec.WriteResponse(w, r, _HandlerService_HTTPErrorWriter, "HandlerServiceUpdateShelf", respEncoding, http.StatusOK, out)
+}
+
+// serveHandlerServiceDeleteShelfByDelete handles DELETE request on `handler/shelves/{id}`.
+func (hnd *HandlerServiceHTTPEndpoint) serveHandlerServiceDeleteShelfByDelete(w http.ResponseWriter, r *http.Request, captureId string) {
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.AllEncodings)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceDeleteShelf", err)
+		return
+	}
+	in := new(HandlerRequest)
+	{
+		v, err := ghert.DecodeString(captureId)
+		if err != nil {
+			_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceDeleteShelf", ghert.NewDecodeError("id", captureId, err))
+			return
+		}
+		in.Id = v
+	}
+	out, err := hnd.srv.DeleteShelf(r.Context(), in)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceDeleteShelf", err)
+		return
+	}
+	_HandlerService_DeleteShelf_HTTPCodec.WriteResponse(w, r, _HandlerService_HTTPErrorWriter, "HandlerServiceDeleteShelf", respEncoding, 204, out)
+}
+
+// serveHandlerServicePatchShelfByPatch handles PATCH request on `handler/shelves/{id}`.
+func (hnd *HandlerServiceHTTPEndpoint) serveHandlerServicePatchShelfByPatch(w http.ResponseWriter, r *http.Request, captureId string) {
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.EncodingJSON)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServicePatchShelf", err)
+		return
+	}
+	in := new(HandlerRequest)
+	if in.Shelf == nil {
+		in.Shelf = new(Shelf)
+	}
+	if err := _HandlerService_PatchShelf_HTTPCodec.DecodeRequest(r, ghert.EncodingJSON, in.Shelf); err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServicePatchShelf", err)
+		return
+	}
+	{
+		v, err := ghert.DecodeString(captureId)
+		if err != nil {
+			_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServicePatchShelf", ghert.NewDecodeError("id", captureId, err))
+			return
+		}
+		in.Id = v
+	}
+	out, err := hnd.srv.PatchShelf(r.Context(), in)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServicePatchShelf", err)
+		return
+	}
+	_HandlerService_PatchShelf_HTTPCodec.WriteResponse(w, r, _HandlerService_HTTPErrorWriter, "HandlerServicePatchShelf", respEncoding, http.StatusOK, out)
+}
+
+// serveHandlerServiceListShelvesByGet handles GET request on `handler/shelves`.
+func (hnd *HandlerServiceHTTPEndpoint) serveHandlerServiceListShelvesByGet(w http.ResponseWriter, r *http.Request) {
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.AllEncodings)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceListShelves", err)
+		return
+	}
+	in := new(HandlerRequest)
+	{
+		query, err := ghert.ParseQuery(r)
+		if err != nil {
+			_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceListShelves", err)
+			return
+		}
+		for key, values := range query {
+			switch key {
+			case "id":
+				if len(values) > 1 {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceListShelves", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeQueryString(value)
+				if err != nil {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceListShelves", ghert.NewDecodeError("id", value, err))
+					return
+				}
+				in.Id = v
+			case "shelf.name":
+				if len(values) > 1 {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceListShelves", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeQueryString(value)
+				if err != nil {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceListShelves", ghert.NewDecodeError("shelf.name", value, err))
+					return
+				}
+				if in.Shelf == nil {
+					in.Shelf = new(Shelf)
+				}
+				in.Shelf.Name = v
+			case "shelf.size":
+				if len(values) > 1 {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceListShelves", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeInt32(value)
+				if err != nil {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceListShelves", ghert.NewDecodeError("shelf.size", value, err))
+					return
+				}
+				if in.Shelf == nil {
+					in.Shelf = new(Shelf)
+				}
+				in.Shelf.Size = v
+			case "note":
+				if len(values) > 1 {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceListShelves", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeQueryString(value)
+				if err != nil {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceListShelves", ghert.NewDecodeError("note", value, err))
+					return
+				}
+				in.Note = v
+			case "tags":
+				for _, value := range values {
+					v, err := ghert.DecodeQueryString(value)
+					if err != nil {
+						_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceListShelves", ghert.NewDecodeError("tags", value, err))
+						return
+					}
+					in.Tags = append(in.Tags, v)
+				}
+			case "content":
+				if len(values) > 1 {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceListShelves", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeQueryBytes(value)
+				if err != nil {
+					_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceListShelves", ghert.NewDecodeError("content", value, err))
+					return
+				}
+				in.Content = v
+			}
+		}
+	}
+	out, err := hnd.srv.ListShelves(r.Context(), in)
+	if err != nil {
+		_HandlerService_HTTPErrorWriter.WriteRouteError(w, r, "HandlerServiceListShelves", err)
+		return
+	}
+	_HandlerService_ListShelves_HTTPCodec.WriteResponse(w, r, _HandlerService_HTTPErrorWriter, "HandlerServiceListShelves", respEncoding, http.StatusOK, out)
+}
+
+// ProblemServiceHTTPEndpoint serves HTTP endpoints of ProblemService.
+type ProblemServiceHTTPEndpoint struct {
+	srv ProblemServiceServer
+}
+
+// NewProblemServiceHTTPEndpoint creates ProblemServiceHTTPEndpoint which invokes methods of srv.
+func NewProblemServiceHTTPEndpoint(srv ProblemServiceServer) *ProblemServiceHTTPEndpoint {
+	return &ProblemServiceHTTPEndpoint{
+		srv: srv,
+	}
+}
+
+// _ProblemService_HTTPErrorWriter writes error responses of ProblemServiceHTTPEndpoint.
+var _ProblemService_HTTPErrorWriter = &ghert.ErrorWriter{
+	Encoding: ghert.ErrorEncodingProblemJSON,
+	StatusCodes: map[codes.Code]int{
+		codes.NotFound: 410,
+	},
+}
+
+// _ProblemService_GetProblem_HTTPCodec marshals messages of GetProblem.
+var _ProblemService_GetProblem_HTTPCodec = &ghert.MessageCodec{
+	JSONUnmarshal: protojson.UnmarshalOptions{
+		DiscardUnknown: true,
+	},
+}
+
+// ServeHTTP implements http.Handler interface.
+func (hnd *ProblemServiceHTTPEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p0 := r.URL.EscapedPath()
+	for (len(p0) != 0) && (p0[0] == '/') {
+		p0 = p0[1:]
+	}
+	// problem/items/
+	if strings.HasPrefix(p0, "problem/items/") {
+		p1 := p0[14:]
+		// {{capture: 0xFFFF7FFF00000000 0x7FFFFFFFFFFFFFFF}}
+		if c0 := p1[:ghert.CaptureLen(p1, 0xffff7fff00000000, 0x7fffffffffffffff)]; len(c0) != 0 {
+			p2 := p1[len(c0):]
+			if len(p2) == 0 {
+				switch r.Method {
+				case http.MethodGet:
+					hnd.serveProblemServiceGetProblemByGet(w, r, c0)
+					return
+				}
+				_ProblemService_HTTPErrorWriter.WriteMethodNotAllowed(w, r, "ProblemServiceGetProblem", "GET")
+				return
+			}
+		}
+	}
+	_ProblemService_HTTPErrorWriter.WriteRouteNotFound(w, r)
+}
+
+// serveProblemServiceGetProblemByGet handles GET request on `problem/items/{id}`.
+func (hnd *ProblemServiceHTTPEndpoint) serveProblemServiceGetProblemByGet(w http.ResponseWriter, r *http.Request, captureId string) {
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.AllEncodings)
+	if err != nil {
+		_ProblemService_HTTPErrorWriter.WriteRouteError(w, r, "ProblemServiceGetProblem", err)
+		return
+	}
+	in := new(HandlerRequest)
+	{
+		v, err := ghert.DecodeString(captureId)
+		if err != nil {
+			_ProblemService_HTTPErrorWriter.WriteRouteError(w, r, "ProblemServiceGetProblem", ghert.NewDecodeError("id", captureId, err))
+			return
+		}
+		in.Id = v
+	}
+	out, err := hnd.srv.GetProblem(r.Context(), in)
+	if err != nil {
+		_ProblemService_HTTPErrorWriter.WriteRouteError(w, r, "ProblemServiceGetProblem", err)
+		return
+	}
+	_ProblemService_GetProblem_HTTPCodec.WriteResponse(w, r, _ProblemService_HTTPErrorWriter, "ProblemServiceGetProblem", respEncoding, http.StatusOK, out)
+}
@@ -0,0 +1,707 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.26.1
+// source: internal/testfixture/handler.proto
+
+package testfixture
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	HandlerService_CreateShelf_FullMethodName       = "/ghe.fixture.HandlerService/CreateShelf"
+	HandlerService_UpdateShelf_FullMethodName       = "/ghe.fixture.HandlerService/UpdateShelf"
+	HandlerService_GetShelf_FullMethodName          = "/ghe.fixture.HandlerService/GetShelf"
+	HandlerService_ListShelves_FullMethodName       = "/ghe.fixture.HandlerService/ListShelves"
+	HandlerService_SearchShelves_FullMethodName     = "/ghe.fixture.HandlerService/SearchShelves"
+	HandlerService_GetNote_FullMethodName           = "/ghe.fixture.HandlerService/GetNote"
+	HandlerService_DeleteShelf_FullMethodName       = "/ghe.fixture.HandlerService/DeleteShelf"
+	HandlerService_MoveShelf_FullMethodName         = "/ghe.fixture.HandlerService/MoveShelf"
+	HandlerService_PatchShelf_FullMethodName        = "/ghe.fixture.HandlerService/PatchShelf"
+	HandlerService_GetShelfRaw_FullMethodName       = "/ghe.fixture.HandlerService/GetShelfRaw"
+	HandlerService_UploadShelf_FullMethodName       = "/ghe.fixture.HandlerService/UploadShelf"
+	HandlerService_WatchShelf_FullMethodName        = "/ghe.fixture.HandlerService/WatchShelf"
+	HandlerService_CollectShelves_FullMethodName    = "/ghe.fixture.HandlerService/CollectShelves"
+	HandlerService_CollectFirstShelf_FullMethodName = "/ghe.fixture.HandlerService/CollectFirstShelf"
+)
+
+// HandlerServiceClient is the client API for HandlerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type HandlerServiceClient interface {
+	CreateShelf(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (*HandlerReply, error)
+	UpdateShelf(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (*HandlerReply, error)
+	GetShelf(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (*HandlerReply, error)
+	ListShelves(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (*HandlerReply, error)
+	SearchShelves(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (*HandlerReply, error)
+	GetNote(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (*HandlerReply, error)
+	DeleteShelf(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (*HandlerReply, error)
+	MoveShelf(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (*HandlerReply, error)
+	PatchShelf(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (*HandlerReply, error)
+	GetShelfRaw(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (*HandlerReply, error)
+	UploadShelf(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (*HandlerReply, error)
+	WatchShelf(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[HandlerReply], error)
+	CollectShelves(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[HandlerRequest, HandlerReply], error)
+	CollectFirstShelf(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[HandlerRequest, HandlerReply], error)
+}
+
+type handlerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHandlerServiceClient(cc grpc.ClientConnInterface) HandlerServiceClient {
+	return &handlerServiceClient{cc}
+}
+
+func (c *handlerServiceClient) CreateShelf(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (*HandlerReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HandlerReply)
+	err := c.cc.Invoke(ctx, HandlerService_CreateShelf_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handlerServiceClient) UpdateShelf(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (*HandlerReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HandlerReply)
+	err := c.cc.Invoke(ctx, HandlerService_UpdateShelf_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handlerServiceClient) GetShelf(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (*HandlerReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HandlerReply)
+	err := c.cc.Invoke(ctx, HandlerService_GetShelf_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handlerServiceClient) ListShelves(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (*HandlerReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HandlerReply)
+	err := c.cc.Invoke(ctx, HandlerService_ListShelves_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handlerServiceClient) SearchShelves(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (*HandlerReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HandlerReply)
+	err := c.cc.Invoke(ctx, HandlerService_SearchShelves_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handlerServiceClient) GetNote(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (*HandlerReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HandlerReply)
+	err := c.cc.Invoke(ctx, HandlerService_GetNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handlerServiceClient) DeleteShelf(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (*HandlerReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HandlerReply)
+	err := c.cc.Invoke(ctx, HandlerService_DeleteShelf_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handlerServiceClient) MoveShelf(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (*HandlerReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HandlerReply)
+	err := c.cc.Invoke(ctx, HandlerService_MoveShelf_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handlerServiceClient) PatchShelf(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (*HandlerReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HandlerReply)
+	err := c.cc.Invoke(ctx, HandlerService_PatchShelf_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handlerServiceClient) GetShelfRaw(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (*HandlerReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HandlerReply)
+	err := c.cc.Invoke(ctx, HandlerService_GetShelfRaw_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handlerServiceClient) UploadShelf(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (*HandlerReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HandlerReply)
+	err := c.cc.Invoke(ctx, HandlerService_UploadShelf_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handlerServiceClient) WatchShelf(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[HandlerReply], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HandlerService_ServiceDesc.Streams[0], HandlerService_WatchShelf_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[HandlerRequest, HandlerReply]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HandlerService_WatchShelfClient = grpc.ServerStreamingClient[HandlerReply]
+
+func (c *handlerServiceClient) CollectShelves(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[HandlerRequest, HandlerReply], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HandlerService_ServiceDesc.Streams[1], HandlerService_CollectShelves_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[HandlerRequest, HandlerReply]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HandlerService_CollectShelvesClient = grpc.ClientStreamingClient[HandlerRequest, HandlerReply]
+
+func (c *handlerServiceClient) CollectFirstShelf(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[HandlerRequest, HandlerReply], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HandlerService_ServiceDesc.Streams[2], HandlerService_CollectFirstShelf_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[HandlerRequest, HandlerReply]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HandlerService_CollectFirstShelfClient = grpc.ClientStreamingClient[HandlerRequest, HandlerReply]
+
+// HandlerServiceServer is the server API for HandlerService service.
+// All implementations must embed UnimplementedHandlerServiceServer
+// for forward compatibility.
+type HandlerServiceServer interface {
+	CreateShelf(context.Context, *HandlerRequest) (*HandlerReply, error)
+	UpdateShelf(context.Context, *HandlerRequest) (*HandlerReply, error)
+	GetShelf(context.Context, *HandlerRequest) (*HandlerReply, error)
+	ListShelves(context.Context, *HandlerRequest) (*HandlerReply, error)
+	SearchShelves(context.Context, *HandlerRequest) (*HandlerReply, error)
+	GetNote(context.Context, *HandlerRequest) (*HandlerReply, error)
+	DeleteShelf(context.Context, *HandlerRequest) (*HandlerReply, error)
+	MoveShelf(context.Context, *HandlerRequest) (*HandlerReply, error)
+	PatchShelf(context.Context, *HandlerRequest) (*HandlerReply, error)
+	GetShelfRaw(context.Context, *HandlerRequest) (*HandlerReply, error)
+	UploadShelf(context.Context, *HandlerRequest) (*HandlerReply, error)
+	WatchShelf(*HandlerRequest, grpc.ServerStreamingServer[HandlerReply]) error
+	CollectShelves(grpc.ClientStreamingServer[HandlerRequest, HandlerReply]) error
+	CollectFirstShelf(grpc.ClientStreamingServer[HandlerRequest, HandlerReply]) error
+	mustEmbedUnimplementedHandlerServiceServer()
+}
+
+// UnimplementedHandlerServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedHandlerServiceServer struct{}
+
+func (UnimplementedHandlerServiceServer) CreateShelf(context.Context, *HandlerRequest) (*HandlerReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateShelf not implemented")
+}
+func (UnimplementedHandlerServiceServer) UpdateShelf(context.Context, *HandlerRequest) (*HandlerReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateShelf not implemented")
+}
+func (UnimplementedHandlerServiceServer) GetShelf(context.Context, *HandlerRequest) (*HandlerReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShelf not implemented")
+}
+func (UnimplementedHandlerServiceServer) ListShelves(context.Context, *HandlerRequest) (*HandlerReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShelves not implemented")
+}
+func (UnimplementedHandlerServiceServer) SearchShelves(context.Context, *HandlerRequest) (*HandlerReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchShelves not implemented")
+}
+func (UnimplementedHandlerServiceServer) GetNote(context.Context, *HandlerRequest) (*HandlerReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNote not implemented")
+}
+func (UnimplementedHandlerServiceServer) DeleteShelf(context.Context, *HandlerRequest) (*HandlerReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteShelf not implemented")
+}
+func (UnimplementedHandlerServiceServer) MoveShelf(context.Context, *HandlerRequest) (*HandlerReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveShelf not implemented")
+}
+func (UnimplementedHandlerServiceServer) PatchShelf(context.Context, *HandlerRequest) (*HandlerReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchShelf not implemented")
+}
+func (UnimplementedHandlerServiceServer) GetShelfRaw(context.Context, *HandlerRequest) (*HandlerReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShelfRaw not implemented")
+}
+func (UnimplementedHandlerServiceServer) UploadShelf(context.Context, *HandlerRequest) (*HandlerReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadShelf not implemented")
+}
+func (UnimplementedHandlerServiceServer) WatchShelf(*HandlerRequest, grpc.ServerStreamingServer[HandlerReply]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchShelf not implemented")
+}
+func (UnimplementedHandlerServiceServer) CollectShelves(grpc.ClientStreamingServer[HandlerRequest, HandlerReply]) error {
+	return status.Errorf(codes.Unimplemented, "method CollectShelves not implemented")
+}
+func (UnimplementedHandlerServiceServer) CollectFirstShelf(grpc.ClientStreamingServer[HandlerRequest, HandlerReply]) error {
+	return status.Errorf(codes.Unimplemented, "method CollectFirstShelf not implemented")
+}
+func (UnimplementedHandlerServiceServer) mustEmbedUnimplementedHandlerServiceServer() {}
+func (UnimplementedHandlerServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeHandlerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HandlerServiceServer will
+// result in compilation errors.
+type UnsafeHandlerServiceServer interface {
+	mustEmbedUnimplementedHandlerServiceServer()
+}
+
+func RegisterHandlerServiceServer(s grpc.ServiceRegistrar, srv HandlerServiceServer) {
+	// If the following call pancis, it indicates UnimplementedHandlerServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&HandlerService_ServiceDesc, srv)
+}
+
+func _HandlerService_CreateShelf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandlerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandlerServiceServer).CreateShelf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandlerService_CreateShelf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandlerServiceServer).CreateShelf(ctx, req.(*HandlerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandlerService_UpdateShelf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandlerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandlerServiceServer).UpdateShelf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandlerService_UpdateShelf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandlerServiceServer).UpdateShelf(ctx, req.(*HandlerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandlerService_GetShelf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandlerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandlerServiceServer).GetShelf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandlerService_GetShelf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandlerServiceServer).GetShelf(ctx, req.(*HandlerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandlerService_ListShelves_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandlerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandlerServiceServer).ListShelves(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandlerService_ListShelves_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandlerServiceServer).ListShelves(ctx, req.(*HandlerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandlerService_SearchShelves_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandlerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandlerServiceServer).SearchShelves(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandlerService_SearchShelves_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandlerServiceServer).SearchShelves(ctx, req.(*HandlerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandlerService_GetNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandlerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandlerServiceServer).GetNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandlerService_GetNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandlerServiceServer).GetNote(ctx, req.(*HandlerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandlerService_DeleteShelf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandlerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandlerServiceServer).DeleteShelf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandlerService_DeleteShelf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandlerServiceServer).DeleteShelf(ctx, req.(*HandlerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandlerService_MoveShelf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandlerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandlerServiceServer).MoveShelf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandlerService_MoveShelf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandlerServiceServer).MoveShelf(ctx, req.(*HandlerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandlerService_PatchShelf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandlerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandlerServiceServer).PatchShelf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandlerService_PatchShelf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandlerServiceServer).PatchShelf(ctx, req.(*HandlerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandlerService_GetShelfRaw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandlerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandlerServiceServer).GetShelfRaw(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandlerService_GetShelfRaw_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandlerServiceServer).GetShelfRaw(ctx, req.(*HandlerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandlerService_UploadShelf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandlerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandlerServiceServer).UploadShelf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HandlerService_UploadShelf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandlerServiceServer).UploadShelf(ctx, req.(*HandlerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandlerService_WatchShelf_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(HandlerRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HandlerServiceServer).WatchShelf(m, &grpc.GenericServerStream[HandlerRequest, HandlerReply]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HandlerService_WatchShelfServer = grpc.ServerStreamingServer[HandlerReply]
+
+func _HandlerService_CollectShelves_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(HandlerServiceServer).CollectShelves(&grpc.GenericServerStream[HandlerRequest, HandlerReply]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HandlerService_CollectShelvesServer = grpc.ClientStreamingServer[HandlerRequest, HandlerReply]
+
+func _HandlerService_CollectFirstShelf_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(HandlerServiceServer).CollectFirstShelf(&grpc.GenericServerStream[HandlerRequest, HandlerReply]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HandlerService_CollectFirstShelfServer = grpc.ClientStreamingServer[HandlerRequest, HandlerReply]
+
+// HandlerService_ServiceDesc is the grpc.ServiceDesc for HandlerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HandlerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ghe.fixture.HandlerService",
+	HandlerType: (*HandlerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateShelf",
+			Handler:    _HandlerService_CreateShelf_Handler,
+		},
+		{
+			MethodName: "UpdateShelf",
+			Handler:    _HandlerService_UpdateShelf_Handler,
+		},
+		{
+			MethodName: "GetShelf",
+			Handler:    _HandlerService_GetShelf_Handler,
+		},
+		{
+			MethodName: "ListShelves",
+			Handler:    _HandlerService_ListShelves_Handler,
+		},
+		{
+			MethodName: "SearchShelves",
+			Handler:    _HandlerService_SearchShelves_Handler,
+		},
+		{
+			MethodName: "GetNote",
+			Handler:    _HandlerService_GetNote_Handler,
+		},
+		{
+			MethodName: "DeleteShelf",
+			Handler:    _HandlerService_DeleteShelf_Handler,
+		},
+		{
+			MethodName: "MoveShelf",
+			Handler:    _HandlerService_MoveShelf_Handler,
+		},
+		{
+			MethodName: "PatchShelf",
+			Handler:    _HandlerService_PatchShelf_Handler,
+		},
+		{
+			MethodName: "GetShelfRaw",
+			Handler:    _HandlerService_GetShelfRaw_Handler,
+		},
+		{
+			MethodName: "UploadShelf",
+			Handler:    _HandlerService_UploadShelf_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchShelf",
+			Handler:       _HandlerService_WatchShelf_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CollectShelves",
+			Handler:       _HandlerService_CollectShelves_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "CollectFirstShelf",
+			Handler:       _HandlerService_CollectFirstShelf_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "internal/testfixture/handler.proto",
+}
+
+const (
+	ProblemService_GetProblem_FullMethodName = "/ghe.fixture.ProblemService/GetProblem"
+)
+
+// ProblemServiceClient is the client API for ProblemService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ProblemServiceClient interface {
+	GetProblem(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (*HandlerReply, error)
+}
+
+type problemServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProblemServiceClient(cc grpc.ClientConnInterface) ProblemServiceClient {
+	return &problemServiceClient{cc}
+}
+
+func (c *problemServiceClient) GetProblem(ctx context.Context, in *HandlerRequest, opts ...grpc.CallOption) (*HandlerReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HandlerReply)
+	err := c.cc.Invoke(ctx, ProblemService_GetProblem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProblemServiceServer is the server API for ProblemService service.
+// All implementations must embed UnimplementedProblemServiceServer
+// for forward compatibility.
+type ProblemServiceServer interface {
+	GetProblem(context.Context, *HandlerRequest) (*HandlerReply, error)
+	mustEmbedUnimplementedProblemServiceServer()
+}
+
+// UnimplementedProblemServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedProblemServiceServer struct{}
+
+func (UnimplementedProblemServiceServer) GetProblem(context.Context, *HandlerRequest) (*HandlerReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProblem not implemented")
+}
+func (UnimplementedProblemServiceServer) mustEmbedUnimplementedProblemServiceServer() {}
+func (UnimplementedProblemServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeProblemServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ProblemServiceServer will
+// result in compilation errors.
+type UnsafeProblemServiceServer interface {
+	mustEmbedUnimplementedProblemServiceServer()
+}
+
+func RegisterProblemServiceServer(s grpc.ServiceRegistrar, srv ProblemServiceServer) {
+	// If the following call pancis, it indicates UnimplementedProblemServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ProblemService_ServiceDesc, srv)
+}
+
+func _ProblemService_GetProblem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandlerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProblemServiceServer).GetProblem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProblemService_GetProblem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProblemServiceServer).GetProblem(ctx, req.(*HandlerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ProblemService_ServiceDesc is the grpc.ServiceDesc for ProblemService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ProblemService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ghe.fixture.ProblemService",
+	HandlerType: (*ProblemServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetProblem",
+			Handler:    _ProblemService_GetProblem_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/testfixture/handler.proto",
+}
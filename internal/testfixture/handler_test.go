@@ -0,0 +1,739 @@
+package testfixture
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/yinyin/protoc-gen-go-grpc-http-endpoint/ghert"
+)
+
+type handlerServer struct {
+	UnimplementedHandlerServiceServer
+}
+
+func (handlerServer) CreateShelf(ctx context.Context, in *HandlerRequest) (*HandlerReply, error) {
+	return &HandlerReply{Method: "CreateShelf", Request: in}, nil
+}
+
+func (handlerServer) UpdateShelf(ctx context.Context, in *HandlerRequest) (*HandlerReply, error) {
+	return &HandlerReply{Method: "UpdateShelf", Request: in}, nil
+}
+
+func (handlerServer) GetShelf(ctx context.Context, in *HandlerRequest) (*HandlerReply, error) {
+	switch in.Id {
+	case "empty":
+		return &HandlerReply{Method: "GetShelf"}, nil
+	case "missing":
+		s, err := status.New(codes.NotFound, "missing shelf").WithDetails(&Shelf{Name: in.Id})
+		if err != nil {
+			return nil, err
+		}
+		return nil, s.Err()
+	}
+	in.Shelf = &Shelf{Name: "shelf-" + in.Id, Size: 1}
+	return &HandlerReply{Method: "GetShelf", Request: in}, nil
+}
+
+func (handlerServer) ListShelves(ctx context.Context, in *HandlerRequest) (*HandlerReply, error) {
+	return &HandlerReply{Method: "ListShelves", Request: in}, nil
+}
+
+func (handlerServer) SearchShelves(ctx context.Context, in *HandlerRequest) (*HandlerReply, error) {
+	return &HandlerReply{Method: "SearchShelves", Request: in}, nil
+}
+
+func (handlerServer) GetNote(ctx context.Context, in *HandlerRequest) (*HandlerReply, error) {
+	return &HandlerReply{Method: "GetNote", Request: in}, nil
+}
+
+func (handlerServer) DeleteShelf(ctx context.Context, in *HandlerRequest) (*HandlerReply, error) {
+	return &HandlerReply{Method: "DeleteShelf", Request: in}, nil
+}
+
+func (handlerServer) MoveShelf(ctx context.Context, in *HandlerRequest) (*HandlerReply, error) {
+	return &HandlerReply{Method: "MoveShelf", Request: in}, nil
+}
+
+func (handlerServer) PatchShelf(ctx context.Context, in *HandlerRequest) (*HandlerReply, error) {
+	return &HandlerReply{Method: "PatchShelf", Request: in}, nil
+}
+
+func (handlerServer) GetShelfRaw(ctx context.Context, in *HandlerRequest) (*HandlerReply, error) {
+	return &HandlerReply{Method: "GetShelfRaw", Request: in}, nil
+}
+
+func (handlerServer) UploadShelf(ctx context.Context, in *HandlerRequest) (*HandlerReply, error) {
+	return &HandlerReply{Method: "UploadShelf", Request: in}, nil
+}
+
+func (handlerServer) WatchShelf(in *HandlerRequest, stream HandlerService_WatchShelfServer) error {
+	if in.Id == "missing" {
+		return status.Error(codes.NotFound, "missing shelf")
+	}
+	for itemCount := int32(1); itemCount <= 2; itemCount++ {
+		if err := stream.Send(&HandlerReply{Method: "WatchShelf", ItemCount: itemCount}); err != nil {
+			return err
+		}
+		if in.Id == "broken" {
+			return status.Error(codes.DataLoss, "broken shelf")
+		}
+	}
+	return nil
+}
+
+// collectShelves replies with `id:note:shelf_name` of received messages
+// in tags.
+func collectShelves(method string, recv func() (*HandlerRequest, error)) (*HandlerReply, error) {
+	reply := &HandlerReply{
+		Method:  method,
+		Request: &HandlerRequest{},
+	}
+	for {
+		in, err := recv()
+		if err == io.EOF {
+			return reply, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		reply.Request.Tags = append(reply.Request.Tags, in.Id+":"+in.Note+":"+in.GetShelf().GetName())
+		reply.ItemCount++
+	}
+}
+
+func (handlerServer) CollectShelves(stream HandlerService_CollectShelvesServer) error {
+	reply, err := collectShelves("CollectShelves", stream.Recv)
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(reply)
+}
+
+func (handlerServer) CollectFirstShelf(stream HandlerService_CollectFirstShelfServer) error {
+	reply, err := collectShelves("CollectFirstShelf", stream.Recv)
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(reply)
+}
+
+// serveHandler sends request to HandlerService endpoint and records the
+// response.
+func serveHandler(method, target, contentType string, body io.Reader) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, body)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return serveHandlerRequest(req)
+}
+
+func serveHandlerRequest(req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	NewHandlerServiceHTTPEndpoint(handlerServer{}).ServeHTTP(rec, req)
+	return rec
+}
+
+func checkHandlerReply(t *testing.T, rec *httptest.ResponseRecorder, method string, request *HandlerRequest) {
+	t.Helper()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status code %d: %s", rec.Code, rec.Body.String())
+	}
+	reply := &HandlerReply{}
+	if err := protojson.Unmarshal(rec.Body.Bytes(), reply); err != nil {
+		t.Fatalf("cannot decode reply %q: %v", rec.Body.String(), err)
+	}
+	if reply.Method != method {
+		t.Errorf("method %s, want %s", reply.Method, method)
+	}
+	if !proto.Equal(reply.Request, request) {
+		t.Errorf("request %v, want %v", reply.Request, request)
+	}
+}
+
+func TestHandlerBodyField(t *testing.T) {
+	rec := serveHandler(http.MethodPost, "/handler/shelves/s1", "application/json", strings.NewReader(`{"name":"n1","size":3}`))
+	checkHandlerReply(t, rec, "CreateShelf", &HandlerRequest{
+		Id:    "s1",
+		Shelf: &Shelf{Name: "n1", Size: 3},
+	})
+}
+
+func TestHandlerBodyWholeMessage(t *testing.T) {
+	rec := serveHandler(http.MethodPut, "/handler/shelves/s1", "application/json", strings.NewReader(`{"id":"s2","shelf":{"name":"n1"},"note":"x"}`))
+	checkHandlerReply(t, rec, "UpdateShelf", &HandlerRequest{
+		Id:    "s1",
+		Shelf: &Shelf{Name: "n1"},
+		Note:  "x",
+	})
+}
+
+func TestHandlerBodyMalformed(t *testing.T) {
+	rec := serveHandler(http.MethodPost, "/handler/shelves/s1", "application/json", strings.NewReader(`{"name":`))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status code %d, want %d: %s", rec.Code, http.StatusBadRequest, rec.Body.String())
+	}
+}
+
+func TestHandlerResponseBody(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		shelf *Shelf
+	}{
+		{"field", "/handler/shelves/s1", &Shelf{Name: "shelf-s1", Size: 1}},
+		{"nil message on path", "/handler/shelves/empty", &Shelf{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveHandler(http.MethodGet, tt.path, "", nil)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status code %d: %s", rec.Code, rec.Body.String())
+			}
+			shelf := &Shelf{}
+			if err := protojson.Unmarshal(rec.Body.Bytes(), shelf); err != nil {
+				t.Fatalf("cannot decode response body %q: %v", rec.Body.String(), err)
+			}
+			if !proto.Equal(shelf, tt.shelf) {
+				t.Errorf("response body %v, want %v", shelf, tt.shelf)
+			}
+		})
+	}
+}
+
+func TestHandlerQuery(t *testing.T) {
+	rec := serveHandler(http.MethodGet, "/handler/shelves?shelf.name=n%201&shelf.size=2&note=x&tags=a&tags=b&other=1", "", nil)
+	checkHandlerReply(t, rec, "ListShelves", &HandlerRequest{
+		Shelf: &Shelf{Name: "n 1", Size: 2},
+		Note:  "x",
+		Tags:  []string{"a", "b"},
+	})
+}
+
+func TestHandlerQueryUnescapedOnce(t *testing.T) {
+	rec := serveHandler(http.MethodGet, "/handler/shelves?content=YQ%3D%3D&note=100%2541", "", nil)
+	checkHandlerReply(t, rec, "ListShelves", &HandlerRequest{
+		Note:    "100%41",
+		Content: []byte("a"),
+	})
+	rec = serveHandler(http.MethodGet, "/handler/shelves?content=YQ%253D%253D", "", nil)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status code %d, want %d: %s", rec.Code, http.StatusBadRequest, rec.Body.String())
+	}
+}
+
+func TestHandlerQueryExcludeBodyField(t *testing.T) {
+	rec := serveHandler(http.MethodPost, "/handler/shelves/s1?note=x&shelf.name=q", "application/json", strings.NewReader(`{"name":"n1"}`))
+	checkHandlerReply(t, rec, "CreateShelf", &HandlerRequest{
+		Id:    "s1",
+		Shelf: &Shelf{Name: "n1"},
+		Note:  "x",
+	})
+}
+
+func TestHandlerQueryError(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+	}{
+		{"multiple values", "/handler/shelves?note=x&note=y"},
+		{"malformed value", "/handler/shelves?shelf.size=x"},
+		{"unknown parameter", "/handler/search?other=1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveHandler(http.MethodGet, tt.target, "", nil)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("status code %d, want %d: %s", rec.Code, http.StatusBadRequest, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandlerHeaderAndCookie(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/handler/notes/n1", nil)
+	req.Header.Set("X-Note", "hello world")
+	req.Header.Add("X-Tag", "a")
+	req.Header.Add("X-Tag", "b")
+	req.Header.Set("X-Size", "4")
+	req.AddCookie(&http.Cookie{Name: "shelf_name", Value: "c1"})
+	checkHandlerReply(t, serveHandlerRequest(req), "GetNote", &HandlerRequest{
+		Id:    "n1",
+		Shelf: &Shelf{Name: "c1", Size: 4},
+		Note:  "hello world",
+		Tags:  []string{"a", "b"},
+	})
+}
+
+func TestHandlerHeaderSetterError(t *testing.T) {
+	for _, size := range []string{"x", "-1"} {
+		req := httptest.NewRequest(http.MethodGet, "/handler/notes/n1", nil)
+		req.Header.Set("X-Size", size)
+		if rec := serveHandlerRequest(req); rec.Code != http.StatusBadRequest {
+			t.Errorf("X-Size %q: status code %d, want %d: %s", size, rec.Code, http.StatusBadRequest, rec.Body.String())
+		}
+	}
+}
+
+func TestHandlerExtraEndpointParams(t *testing.T) {
+	tests := []struct {
+		name  string
+		count string
+		body  string
+	}{
+		{"header", "3", "p1:3"},
+		{"absent header", "", "p1:0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/handler/ping/p1", nil)
+			if tt.count != "" {
+				req.Header.Set("X-Count", tt.count)
+			}
+			rec := serveHandlerRequest(req)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status code %d: %s", rec.Code, rec.Body.String())
+			}
+			if body := rec.Body.String(); body != tt.body {
+				t.Errorf("body %q, want %q", body, tt.body)
+			}
+		})
+	}
+}
+
+func TestHandlerSuccessStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		statusCode int
+	}{
+		{"no content", http.MethodDelete, "/handler/shelves/s1", "", http.StatusNoContent},
+		{"success status", http.MethodPost, "/handler/shelves/s1/move", `{"note":"now"}`, http.StatusCreated},
+		{"extracted status", http.MethodPost, "/handler/shelves/s1/move", `{"note":"queued"}`, http.StatusAccepted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveHandler(tt.method, tt.target, "application/json", strings.NewReader(tt.body))
+			if rec.Code != tt.statusCode {
+				t.Fatalf("status code %d, want %d: %s", rec.Code, tt.statusCode, rec.Body.String())
+			}
+			if tt.statusCode == http.StatusNoContent {
+				if rec.Body.Len() != 0 {
+					t.Errorf("unexpected body: %q", rec.Body.String())
+				}
+				return
+			}
+			reply := &HandlerReply{}
+			if err := protojson.Unmarshal(rec.Body.Bytes(), reply); err != nil {
+				t.Fatalf("cannot decode reply %q: %v", rec.Body.String(), err)
+			}
+			if reply.Method != "MoveShelf" {
+				t.Errorf("method %s, want MoveShelf", reply.Method)
+			}
+		})
+	}
+}
+
+func TestHandlerStatusError(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		statusCode  int
+		code        codes.Code
+		detailShelf *Shelf
+	}{
+		{"overridden status code", http.MethodGet, "/handler/shelves/missing", http.StatusGone, codes.NotFound, &Shelf{Name: "missing"}},
+		{"route not found", http.MethodGet, "/handler/unknown", http.StatusNotFound, codes.NotFound, nil},
+		{"method not allowed", http.MethodPost, "/handler/search", http.StatusMethodNotAllowed, codes.Unimplemented, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveHandler(tt.method, tt.target, "", nil)
+			if rec.Code != tt.statusCode {
+				t.Fatalf("status code %d, want %d: %s", rec.Code, tt.statusCode, rec.Body.String())
+			}
+			if contentType := rec.Header().Get("Content-Type"); contentType != "application/json" {
+				t.Errorf("Content-Type %q, want application/json", contentType)
+			}
+			s := &spb.Status{}
+			if err := protojson.Unmarshal(rec.Body.Bytes(), s); err != nil {
+				t.Fatalf("cannot decode status %q: %v", rec.Body.String(), err)
+			}
+			if codes.Code(s.Code) != tt.code {
+				t.Errorf("code %v, want %v", codes.Code(s.Code), tt.code)
+			}
+			if tt.detailShelf == nil {
+				return
+			}
+			if len(s.Details) != 1 {
+				t.Fatalf("got %d details, want 1", len(s.Details))
+			}
+			shelf := &Shelf{}
+			if err := s.Details[0].UnmarshalTo(shelf); err != nil {
+				t.Fatal(err)
+			}
+			if !proto.Equal(shelf, tt.detailShelf) {
+				t.Errorf("detail %v, want %v", shelf, tt.detailShelf)
+			}
+		})
+	}
+}
+
+type problemServer struct {
+	UnimplementedProblemServiceServer
+}
+
+func (problemServer) GetProblem(ctx context.Context, in *HandlerRequest) (*HandlerReply, error) {
+	return nil, status.Error(codes.NotFound, "missing problem "+in.Id)
+}
+
+func TestProblemServiceError(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		target  string
+		problem map[string]any
+	}{
+		{"status error", http.MethodGet, "/problem/items/p1", map[string]any{
+			"type":     "about:blank",
+			"title":    "Gone",
+			"status":   float64(http.StatusGone),
+			"detail":   "missing problem p1",
+			"instance": "ProblemServiceGetProblem",
+		}},
+		{"method not allowed", http.MethodPost, "/problem/items/p1", map[string]any{
+			"type":     "about:blank",
+			"title":    "Method Not Allowed",
+			"status":   float64(http.StatusMethodNotAllowed),
+			"detail":   "method not allowed: POST",
+			"instance": "ProblemServiceGetProblem",
+		}},
+		{"route not found", http.MethodGet, "/problem/unknown", map[string]any{
+			"type":   "about:blank",
+			"title":  "Not Found",
+			"status": float64(http.StatusNotFound),
+			"detail": "route not found: /problem/unknown",
+		}},
+	}
+	hnd := NewProblemServiceHTTPEndpoint(problemServer{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			hnd.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, nil))
+			if statusCode := int(tt.problem["status"].(float64)); rec.Code != statusCode {
+				t.Errorf("status code %d, want %d", rec.Code, statusCode)
+			}
+			if contentType := rec.Header().Get("Content-Type"); contentType != "application/problem+json" {
+				t.Errorf("Content-Type %q, want application/problem+json", contentType)
+			}
+			var problem map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+				t.Fatalf("cannot decode problem %q: %v", rec.Body.String(), err)
+			}
+			if !reflect.DeepEqual(problem, tt.problem) {
+				t.Errorf("problem %v, want %v", problem, tt.problem)
+			}
+		})
+	}
+}
+
+func TestHandlerEncodingNegotiation(t *testing.T) {
+	shelf := &Shelf{Name: "n1", Size: 2}
+	shelfJSON, _ := protojson.Marshal(shelf)
+	requestJSON, _ := protojson.Marshal(&HandlerRequest{Shelf: shelf})
+	requestProtobuf, _ := proto.Marshal(&HandlerRequest{Shelf: shelf})
+	shelfProtobuf, _ := proto.Marshal(shelf)
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		body        []byte
+		accept      string
+		statusCode  int
+		replyType   string
+	}{
+		{"protobuf request", http.MethodPut, "application/x-protobuf", requestProtobuf, "", http.StatusOK, "application/x-protobuf"},
+		{"accept prototext", http.MethodPut, "application/x-protobuf", requestProtobuf, "application/x-prototext", http.StatusOK, "application/x-prototext"},
+		{"accept by quality", http.MethodPut, "application/json", requestJSON, "application/*;q=0.5, application/x-prototext", http.StatusOK, "application/x-prototext"},
+		{"accept wildcard", http.MethodPut, "application/x-protobuf", requestProtobuf, "*/*", http.StatusOK, "application/json"},
+		{"restricted json", http.MethodPatch, "application/json", shelfJSON, "", http.StatusOK, "application/json"},
+		{"restricted unsupported media type", http.MethodPatch, "application/x-protobuf", shelfProtobuf, "", http.StatusUnsupportedMediaType, ""},
+		{"restricted not acceptable", http.MethodPatch, "application/json", shelfJSON, "application/x-protobuf", http.StatusNotAcceptable, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/handler/shelves/s1", bytes.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := serveHandlerRequest(req)
+			if rec.Code != tt.statusCode {
+				t.Fatalf("status code %d, want %d: %s", rec.Code, tt.statusCode, rec.Body.String())
+			}
+			if tt.statusCode != http.StatusOK {
+				return
+			}
+			if contentType := rec.Header().Get("Content-Type"); contentType != tt.replyType {
+				t.Fatalf("Content-Type %q, want %q", contentType, tt.replyType)
+			}
+			reply := &HandlerReply{}
+			var err error
+			switch tt.replyType {
+			case "application/x-protobuf":
+				err = proto.Unmarshal(rec.Body.Bytes(), reply)
+			case "application/x-prototext":
+				err = prototext.Unmarshal(rec.Body.Bytes(), reply)
+			default:
+				err = protojson.Unmarshal(rec.Body.Bytes(), reply)
+			}
+			if err != nil {
+				t.Fatalf("cannot decode reply %q: %v", rec.Body.String(), err)
+			}
+			if !proto.Equal(reply.Request.GetShelf(), shelf) {
+				t.Errorf("shelf %v, want %v", reply.Request.GetShelf(), shelf)
+			}
+		})
+	}
+}
+
+func TestHandlerJSONOptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		statusCode int
+		reply      map[string]any
+	}{
+		{"service option", http.MethodPost, "/handler/shelves/s1/move", http.StatusCreated, map[string]any{
+			"method":    "MoveShelf",
+			"request":   map[string]any{"id": "s1", "shelf": nil, "note": "", "tags": []any{}, "content": ""},
+			"itemCount": float64(0),
+		}},
+		{"method option", http.MethodGet, "/handler/shelves/s1/raw", http.StatusOK, map[string]any{
+			"method":     "GetShelfRaw",
+			"request":    map[string]any{"id": "s1", "shelf": nil, "note": "", "tags": []any{}, "content": ""},
+			"item_count": float64(0),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveHandler(tt.method, tt.target, "application/json", strings.NewReader("{}"))
+			if rec.Code != tt.statusCode {
+				t.Fatalf("status code %d, want %d: %s", rec.Code, tt.statusCode, rec.Body.String())
+			}
+			var reply map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &reply); err != nil {
+				t.Fatalf("cannot decode reply %q: %v", rec.Body.String(), err)
+			}
+			if !reflect.DeepEqual(reply, tt.reply) {
+				t.Errorf("reply %v, want %v", reply, tt.reply)
+			}
+		})
+	}
+}
+
+func TestHandlerJSONDiscardUnknown(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		statusCode int
+	}{
+		{"file option", http.MethodPut, `{"note":"x","unknown":1}`, http.StatusOK},
+		{"method option", http.MethodPatch, `{"name":"n1","unknown":1}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveHandler(tt.method, "/handler/shelves/s1", "application/json", strings.NewReader(tt.body))
+			if rec.Code != tt.statusCode {
+				t.Errorf("status code %d, want %d: %s", rec.Code, tt.statusCode, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandlerFormURLEncoded(t *testing.T) {
+	rec := serveHandler(http.MethodPost, "/handler/shelves/s1/upload", "application/x-www-form-urlencoded",
+		strings.NewReader("shelf.name=n+1&shelf.size=2&tags=a&tags=b&id=s2"))
+	checkHandlerReply(t, rec, "UploadShelf", &HandlerRequest{
+		Id:    "s1",
+		Shelf: &Shelf{Name: "n 1", Size: 2},
+		Tags:  []string{"a", "b"},
+	})
+}
+
+func TestHandlerFormMultipart(t *testing.T) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	mw.WriteField("shelf.name", "n1")
+	mw.WriteField("tags", "a")
+	fw, _ := mw.CreateFormFile("content", "content.bin")
+	fw.Write([]byte{0x00, 0x01, 0x02})
+	fw, _ = mw.CreateFormFile("note_file", "note.txt")
+	fw.Write([]byte("note from file"))
+	fw, _ = mw.CreateFormFile("ignored", "ignored.txt")
+	fw.Write([]byte("ignored"))
+	mw.Close()
+	rec := serveHandler(http.MethodPost, "/handler/shelves/s1/upload", mw.FormDataContentType(), &body)
+	checkHandlerReply(t, rec, "UploadShelf", &HandlerRequest{
+		Id:      "s1",
+		Shelf:   &Shelf{Name: "n1"},
+		Note:    "note from file",
+		Tags:    []string{"a"},
+		Content: []byte{0x00, 0x01, 0x02},
+	})
+}
+
+func TestHandlerFormJSONBody(t *testing.T) {
+	rec := serveHandler(http.MethodPost, "/handler/shelves/s1/upload", "application/json", strings.NewReader(`{"note":"x"}`))
+	checkHandlerReply(t, rec, "UploadShelf", &HandlerRequest{
+		Id:   "s1",
+		Note: "x",
+	})
+}
+
+func TestHandlerFormTooLarge(t *testing.T) {
+	rec := serveHandler(http.MethodPost, "/handler/shelves/s1/upload", "application/x-www-form-urlencoded",
+		strings.NewReader("note="+strings.Repeat("x", 1024)))
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status code %d, want %d: %s", rec.Code, http.StatusRequestEntityTooLarge, rec.Body.String())
+	}
+}
+
+func TestHandlerFormValueTooLarge(t *testing.T) {
+	defer func(limit int64) { ghert.MaxFormValueSize = limit }(ghert.MaxFormValueSize)
+	ghert.MaxFormValueSize = 8
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	mw.WriteField("tags", "12345678")
+	mw.WriteField("shelf.name", "123456789")
+	mw.Close()
+	rec := serveHandler(http.MethodPost, "/handler/shelves/s1/upload", mw.FormDataContentType(), &body)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status code %d, want %d: %s", rec.Code, http.StatusRequestEntityTooLarge, rec.Body.String())
+	}
+}
+
+func TestHandlerServerStream(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		accept      string
+		statusCode  int
+		contentType string
+		body        string
+	}{
+		{"ndjson", "/handler/shelves/s1/watch", "", http.StatusOK, "application/x-ndjson",
+			`{"method":"WatchShelf","request":null,"itemCount":1}` + "\n" +
+				`{"method":"WatchShelf","request":null,"itemCount":2}` + "\n"},
+		{"sse", "/handler/shelves/s1/watch", "text/event-stream", http.StatusOK, "text/event-stream",
+			`data: {"method":"WatchShelf","request":null,"itemCount":1}` + "\n\n" +
+				`data: {"method":"WatchShelf","request":null,"itemCount":2}` + "\n\n"},
+		{"sse error after message", "/handler/shelves/broken/watch", "text/event-stream", http.StatusOK, "text/event-stream",
+			`data: {"method":"WatchShelf","request":null,"itemCount":1}` + "\n\n" +
+				"event: error\n" + `data: {"code":15,"message":"broken shelf"}` + "\n\n"},
+		{"ndjson error after message", "/handler/shelves/broken/watch", "application/x-ndjson", http.StatusOK, "application/x-ndjson",
+			`{"method":"WatchShelf","request":null,"itemCount":1}` + "\n" +
+				`{"error":{"code":15,"message":"broken shelf"}}` + "\n"},
+		{"error before message", "/handler/shelves/missing/watch", "", http.StatusGone, "application/json", ""},
+		{"not acceptable", "/handler/shelves/s1/watch", "application/x-protobuf", http.StatusNotAcceptable, "application/json", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := serveHandlerRequest(req)
+			if rec.Code != tt.statusCode {
+				t.Fatalf("status code %d, want %d: %s", rec.Code, tt.statusCode, rec.Body.String())
+			}
+			if contentType := rec.Header().Get("Content-Type"); contentType != tt.contentType {
+				t.Errorf("Content-Type %q, want %q", contentType, tt.contentType)
+			}
+			if tt.body == "" {
+				return
+			}
+			// protojson randomizes spaces in output.
+			if body := strings.ReplaceAll(rec.Body.String(), " ", ""); body != strings.ReplaceAll(tt.body, " ", "") {
+				t.Errorf("body %q, want %q", rec.Body.String(), tt.body)
+			}
+		})
+	}
+}
+
+// lengthPrefixedProtobuf encodes messages as varint length-prefixed binary
+// protobuf.
+func lengthPrefixedProtobuf(msgs ...proto.Message) []byte {
+	var buf []byte
+	for _, msg := range msgs {
+		b, _ := proto.Marshal(msg)
+		buf = binary.AppendUvarint(buf, uint64(len(b)))
+		buf = append(buf, b...)
+	}
+	return buf
+}
+
+func TestHandlerClientStream(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+		body        []byte
+		statusCode  int
+		rpcName     string
+		tags        []string
+	}{
+		{"ndjson", "/handler/shelves/s1/collect", "application/x-ndjson",
+			[]byte("{\"shelf\":{\"name\":\"a\"}}\n\n{\"shelf\":{\"name\":\"b\"}}\n"),
+			http.StatusOK, "CollectShelves", []string{"s1:n:a", "s1:n:b"}},
+		{"length-prefixed protobuf", "/handler/shelves/s1/collect", "application/x-protobuf",
+			lengthPrefixedProtobuf(&HandlerRequest{Shelf: &Shelf{Name: "a"}}, &HandlerRequest{Id: "s2", Shelf: &Shelf{Name: "b"}}),
+			http.StatusOK, "CollectShelves", []string{"s1:n:a", "s1:n:b"}},
+		{"captures first message only", "/handler/shelves/s1/collect-first", "application/x-ndjson",
+			[]byte("{\"shelf\":{\"name\":\"a\"}}\n{\"shelf\":{\"name\":\"b\"}}\n"),
+			http.StatusOK, "CollectFirstShelf", []string{"s1:n:a", "::b"}},
+		{"empty stream", "/handler/shelves/s1/collect", "application/x-ndjson", nil,
+			http.StatusOK, "CollectShelves", nil},
+		{"malformed message", "/handler/shelves/s1/collect", "application/x-ndjson",
+			[]byte("{\"shelf\":{\"name\":\"a\"}}\n{\"shelf\":\n"),
+			http.StatusBadRequest, "", nil},
+		{"unsupported media type", "/handler/shelves/s1/collect", "application/x-prototext", nil,
+			http.StatusUnsupportedMediaType, "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, bytes.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			req.Header.Set("Accept", "application/json")
+			req.Header.Set("X-Note", "n")
+			rec := serveHandlerRequest(req)
+			if rec.Code != tt.statusCode {
+				t.Fatalf("status code %d, want %d: %s", rec.Code, tt.statusCode, rec.Body.String())
+			}
+			if tt.statusCode != http.StatusOK {
+				return
+			}
+			checkHandlerReply(t, rec, tt.rpcName, &HandlerRequest{Tags: tt.tags})
+		})
+	}
+}
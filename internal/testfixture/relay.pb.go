@@ -0,0 +1,106 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v5.26.1
+// source: internal/testfixture/relay.proto
+
+package testfixture
+
+import (
+	_ "github.com/yinyin/protoc-gen-go-grpc-http-endpoint/ghegen"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var File_internal_testfixture_relay_proto protoreflect.FileDescriptor
+
+var file_internal_testfixture_relay_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x66,
+	0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x0b, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x1a,
+	0x11, 0x67, 0x68, 0x65, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x20, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x65, 0x73,
+	0x74, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2f, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x32, 0xeb, 0x02, 0x0a, 0x0c, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4b, 0x0a, 0x04, 0x45, 0x63, 0x68, 0x6f, 0x12, 0x19, 0x2e,
+	0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66,
+	0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x0f, 0x92, 0xb5, 0x18, 0x0b, 0x12, 0x09, 0x65, 0x63, 0x68, 0x6f, 0x2f, 0x7b, 0x69,
+	0x64, 0x7d, 0x12, 0x58, 0x0a, 0x05, 0x57, 0x61, 0x74, 0x63, 0x68, 0x12, 0x19, 0x2e, 0x67, 0x68,
+	0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78,
+	0x74, 0x75, 0x72, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x19, 0x92, 0xb5, 0x18, 0x15, 0x0a, 0x0a, 0x77, 0x61, 0x74, 0x63, 0x68, 0x2f, 0x7b, 0x69, 0x64,
+	0x7d, 0xba, 0x01, 0x06, 0x6e, 0x64, 0x6a, 0x73, 0x6f, 0x6e, 0x30, 0x01, 0x12, 0x56, 0x0a, 0x07,
+	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x12, 0x19, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69,
+	0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65,
+	0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x15, 0x92, 0xb5, 0x18,
+	0x11, 0x12, 0x0c, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x5a,
+	0x01, 0x2a, 0x28, 0x01, 0x12, 0x4f, 0x0a, 0x04, 0x43, 0x68, 0x61, 0x74, 0x12, 0x19, 0x2e, 0x67,
+	0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69,
+	0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x0f, 0x92, 0xb5, 0x18, 0x0b, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x74, 0x2f, 0x7b, 0x69, 0x64,
+	0x7d, 0x28, 0x01, 0x30, 0x01, 0x1a, 0x0b, 0x92, 0xb5, 0x18, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x6c,
+	0x61, 0x79, 0x42, 0x49, 0x5a, 0x47, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x79, 0x69, 0x6e, 0x79, 0x69, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67,
+	0x65, 0x6e, 0x2d, 0x67, 0x6f, 0x2d, 0x67, 0x72, 0x70, 0x63, 0x2d, 0x68, 0x74, 0x74, 0x70, 0x2d,
+	0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61,
+	0x6c, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var file_internal_testfixture_relay_proto_goTypes = []interface{}{
+	(*RouteRequest)(nil), // 0: ghe.fixture.RouteRequest
+	(*RouteReply)(nil),   // 1: ghe.fixture.RouteReply
+}
+var file_internal_testfixture_relay_proto_depIdxs = []int32{
+	0, // 0: ghe.fixture.RelayService.Echo:input_type -> ghe.fixture.RouteRequest
+	0, // 1: ghe.fixture.RelayService.Watch:input_type -> ghe.fixture.RouteRequest
+	0, // 2: ghe.fixture.RelayService.Collect:input_type -> ghe.fixture.RouteRequest
+	0, // 3: ghe.fixture.RelayService.Chat:input_type -> ghe.fixture.RouteRequest
+	1, // 4: ghe.fixture.RelayService.Echo:output_type -> ghe.fixture.RouteReply
+	1, // 5: ghe.fixture.RelayService.Watch:output_type -> ghe.fixture.RouteReply
+	1, // 6: ghe.fixture.RelayService.Collect:output_type -> ghe.fixture.RouteReply
+	1, // 7: ghe.fixture.RelayService.Chat:output_type -> ghe.fixture.RouteReply
+	4, // [4:8] is the sub-list for method output_type
+	0, // [0:4] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_internal_testfixture_relay_proto_init() }
+func file_internal_testfixture_relay_proto_init() {
+	if File_internal_testfixture_relay_proto != nil {
+		return
+	}
+	file_internal_testfixture_route_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_internal_testfixture_relay_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_internal_testfixture_relay_proto_goTypes,
+		DependencyIndexes: file_internal_testfixture_relay_proto_depIdxs,
+	}.Build()
+	File_internal_testfixture_relay_proto = out.File
+	file_internal_testfixture_relay_proto_rawDesc = nil
+	file_internal_testfixture_relay_proto_goTypes = nil
+	file_internal_testfixture_relay_proto_depIdxs = nil
+}
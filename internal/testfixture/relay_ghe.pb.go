@@ -0,0 +1,274 @@
+// Code generated by protoc-gen-go-grpc-http-endpoint. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc-http-endpoint v0.0.1
+// - protoc                           (unknown)
+// source: internal/testfixture/relay.proto
+
+package testfixture
+
+import (
+	ghert "github.com/yinyin/protoc-gen-go-grpc-http-endpoint/ghert"
+	grpc "google.golang.org/grpc"
+	io "io"
+	http "net/http"
+	strings "strings"
+)
+
+// RelayServiceHTTPEndpoint serves HTTP endpoints of RelayService.
+type RelayServiceHTTPEndpoint struct {
+	client RelayServiceClient
+
+	// CheckWebSocketOrigin decides if WebSocket handshake of r is accepted.
+	// ghert.CheckWebSocketOrigin is used when nil.
+	CheckWebSocketOrigin func(r *http.Request) bool
+}
+
+// NewRelayServiceHTTPEndpoint creates RelayServiceHTTPEndpoint which forwards requests to gRPC server over cc.
+func NewRelayServiceHTTPEndpoint(cc grpc.ClientConnInterface) *RelayServiceHTTPEndpoint {
+	return &RelayServiceHTTPEndpoint{
+		client: NewRelayServiceClient(cc),
+	}
+}
+
+// _RelayService_HTTPErrorWriter writes error responses of RelayServiceHTTPEndpoint.
+var _RelayService_HTTPErrorWriter = &ghert.ErrorWriter{}
+
+// ServeHTTP implements http.Handler interface.
+func (hnd *RelayServiceHTTPEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p0 := r.URL.EscapedPath()
+	for (len(p0) != 0) && (p0[0] == '/') {
+		p0 = p0[1:]
+	}
+	// relay/
+	if strings.HasPrefix(p0, "relay/") {
+		p1 := p0[6:]
+		if len(p1) != 0 {
+			switch p1[0] {
+			case 'c':
+				// c
+				if strings.HasPrefix(p1, "c") {
+					p2 := p1[1:]
+					if len(p2) != 0 {
+						switch p2[0] {
+						case 'h':
+							// hat/
+							if strings.HasPrefix(p2, "hat/") {
+								p3 := p2[4:]
+								// {{capture: 0xFFFF7FFF00000000 0x7FFFFFFFFFFFFFFF}}
+								if c0 := p3[:ghert.CaptureLen(p3, 0xffff7fff00000000, 0x7fffffffffffffff)]; len(c0) != 0 {
+									p4 := p3[len(c0):]
+									if len(p4) == 0 {
+										switch r.Method {
+										case http.MethodGet:
+											hnd.serveRelayServiceChatByGet(w, r, c0)
+											return
+										}
+										_RelayService_HTTPErrorWriter.WriteMethodNotAllowed(w, r, "RelayServiceChat", "GET")
+										return
+									}
+								}
+							}
+						case 'o':
+							// ollect/
+							if strings.HasPrefix(p2, "ollect/") {
+								p3 := p2[7:]
+								// {{capture: 0xFFFF7FFF00000000 0x7FFFFFFFFFFFFFFF}}
+								if c0 := p3[:ghert.CaptureLen(p3, 0xffff7fff00000000, 0x7fffffffffffffff)]; len(c0) != 0 {
+									p4 := p3[len(c0):]
+									if len(p4) == 0 {
+										switch r.Method {
+										case http.MethodPost:
+											hnd.serveRelayServiceCollectByPost(w, r, c0)
+											return
+										}
+										_RelayService_HTTPErrorWriter.WriteMethodNotAllowed(w, r, "RelayServiceCollect", "POST")
+										return
+									}
+								}
+							}
+						}
+					}
+				}
+			case 'e':
+				// echo/
+				if strings.HasPrefix(p1, "echo/") {
+					p2 := p1[5:]
+					// {{capture: 0xFFFF7FFF00000000 0x7FFFFFFFFFFFFFFF}}
+					if c0 := p2[:ghert.CaptureLen(p2, 0xffff7fff00000000, 0x7fffffffffffffff)]; len(c0) != 0 {
+						p3 := p2[len(c0):]
+						if len(p3) == 0 {
+							switch r.Method {
+							case http.MethodPost:
+								hnd.serveRelayServiceEchoByPost(w, r, c0)
+								return
+							}
+							_RelayService_HTTPErrorWriter.WriteMethodNotAllowed(w, r, "RelayServiceEcho", "POST")
+							return
+						}
+					}
+				}
+			case 'w':
+				// watch/
+				if strings.HasPrefix(p1, "watch/") {
+					p2 := p1[6:]
+					// {{capture: 0xFFFF7FFF00000000 0x7FFFFFFFFFFFFFFF}}
+					if c0 := p2[:ghert.CaptureLen(p2, 0xffff7fff00000000, 0x7fffffffffffffff)]; len(c0) != 0 {
+						p3 := p2[len(c0):]
+						if len(p3) == 0 {
+							switch r.Method {
+							case http.MethodGet:
+								hnd.serveRelayServiceWatchByGet(w, r, c0)
+								return
+							}
+							_RelayService_HTTPErrorWriter.WriteMethodNotAllowed(w, r, "RelayServiceWatch", "GET")
+							return
+						}
+					}
+				}
+			}
+		}
+	}
+	_RelayService_HTTPErrorWriter.WriteRouteNotFound(w, r)
+}
+
+// serveRelayServiceChatByGet handles GET request on `relay/chat/{id}`.
+func (hnd *RelayServiceHTTPEndpoint) serveRelayServiceChatByGet(w http.ResponseWriter, r *http.Request, captureId string) {
+	in := new(RouteRequest)
+	{
+		v, err := ghert.DecodeString(captureId)
+		if err != nil {
+			_RelayService_HTTPErrorWriter.WriteRouteError(w, r, "RelayServiceChat", ghert.NewDecodeError("id", captureId, err))
+			return
+		}
+		in.Id = v
+	}
+	conn, err := ghert.UpgradeWebSocket(w, r, ghert.DefaultMessageCodec, ghert.AllEncodings, 0, hnd.CheckWebSocketOrigin)
+	if err != nil {
+		_RelayService_HTTPErrorWriter.WriteRouteError(w, r, "RelayServiceChat", err)
+		return
+	}
+	stream := ghert.NewBidiStream[RouteRequest, RouteReply](conn, in, true)
+	client, err := hnd.client.Chat(conn.Context())
+	if err != nil {
+		conn.Close(err)
+		return
+	}
+	conn.Close(ghert.RelayBidiStream(stream, client))
+}
+
+// serveRelayServiceCollectByPost handles POST request on `relay/collect/{id}`.
+func (hnd *RelayServiceHTTPEndpoint) serveRelayServiceCollectByPost(w http.ResponseWriter, r *http.Request, captureId string) {
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.AllEncodings)
+	if err != nil {
+		_RelayService_HTTPErrorWriter.WriteRouteError(w, r, "RelayServiceCollect", err)
+		return
+	}
+	reader, err := ghert.NewMessageStreamReader(r, ghert.DefaultMessageCodec, ghert.AllEncodings)
+	if err != nil {
+		_RelayService_HTTPErrorWriter.WriteRouteError(w, r, "RelayServiceCollect", err)
+		return
+	}
+	in := new(RouteRequest)
+	{
+		v, err := ghert.DecodeString(captureId)
+		if err != nil {
+			_RelayService_HTTPErrorWriter.WriteRouteError(w, r, "RelayServiceCollect", ghert.NewDecodeError("id", captureId, err))
+			return
+		}
+		in.Id = v
+	}
+	stream := ghert.NewClientStream[RouteRequest, RouteReply](w, r, reader, in, true)
+	client, err := hnd.client.Collect(r.Context())
+	if err != nil {
+		_RelayService_HTTPErrorWriter.WriteRouteError(w, r, "RelayServiceCollect", err)
+		return
+	}
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			_RelayService_HTTPErrorWriter.WriteRouteError(w, r, "RelayServiceCollect", err)
+			return
+		}
+		if err = client.Send(msg); err == io.EOF {
+			break
+		} else if err != nil {
+			_RelayService_HTTPErrorWriter.WriteRouteError(w, r, "RelayServiceCollect", err)
+			return
+		}
+	}
+	out, err := client.CloseAndRecv()
+	if err != nil {
+		_RelayService_HTTPErrorWriter.WriteRouteError(w, r, "RelayServiceCollect", err)
+		return
+	}
+	ghert.DefaultMessageCodec.WriteResponse(w, r, _RelayService_HTTPErrorWriter, "RelayServiceCollect", respEncoding, http.StatusOK, out)
+}
+
+// serveRelayServiceEchoByPost handles POST request on `relay/echo/{id}`.
+func (hnd *RelayServiceHTTPEndpoint) serveRelayServiceEchoByPost(w http.ResponseWriter, r *http.Request, captureId string) {
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.AllEncodings)
+	if err != nil {
+		_RelayService_HTTPErrorWriter.WriteRouteError(w, r, "RelayServiceEcho", err)
+		return
+	}
+	in := new(RouteRequest)
+	{
+		v, err := ghert.DecodeString(captureId)
+		if err != nil {
+			_RelayService_HTTPErrorWriter.WriteRouteError(w, r, "RelayServiceEcho", ghert.NewDecodeError("id", captureId, err))
+			return
+		}
+		in.Id = v
+	}
+	out, err := hnd.client.Echo(r.Context(), in)
+	if err != nil {
+		_RelayService_HTTPErrorWriter.WriteRouteError(w, r, "RelayServiceEcho", err)
+		return
+	}
+	ghert.DefaultMessageCodec.WriteResponse(w, r, _RelayService_HTTPErrorWriter, "RelayServiceEcho", respEncoding, http.StatusOK, out)
+}
+
+// serveRelayServiceWatchByGet handles GET request on `relay/watch/{id}`.
+func (hnd *RelayServiceHTTPEndpoint) serveRelayServiceWatchByGet(w http.ResponseWriter, r *http.Request, captureId string) {
+	streamFormat, err := ghert.NegotiateStreamFormat(r, ghert.StreamFormatNDJSON)
+	if err != nil {
+		_RelayService_HTTPErrorWriter.WriteRouteError(w, r, "RelayServiceWatch", err)
+		return
+	}
+	in := new(RouteRequest)
+	{
+		v, err := ghert.DecodeString(captureId)
+		if err != nil {
+			_RelayService_HTTPErrorWriter.WriteRouteError(w, r, "RelayServiceWatch", ghert.NewDecodeError("id", captureId, err))
+			return
+		}
+		in.Id = v
+	}
+	stream, err := hnd.client.Watch(r.Context(), in)
+	if err != nil {
+		_RelayService_HTTPErrorWriter.WriteRouteError(w, r, "RelayServiceWatch", err)
+		return
+	}
+	sw := ghert.NewMessageStreamWriter(w, ghert.DefaultMessageCodec, streamFormat, http.StatusOK)
+	for {
+		out, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if sw.Started() {
+				sw.WriteError(err)
+				return
+			}
+			_RelayService_HTTPErrorWriter.WriteRouteError(w, r, "RelayServiceWatch", err)
+			return
+		}
+		if err = sw.WriteMessage(out); err != nil {
+			return
+		}
+	}
+	sw.Finish()
+}
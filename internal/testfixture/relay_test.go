@@ -0,0 +1,295 @@
+package testfixture
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+type relayServer struct {
+	UnimplementedRelayServiceServer
+}
+
+func (relayServer) Echo(ctx context.Context, in *RouteRequest) (*RouteReply, error) {
+	if in.Id == "missing" {
+		return nil, status.Error(codes.NotFound, "missing echo")
+	}
+	return &RouteReply{
+		Method: "Echo",
+		Values: []string{in.Id, in.Name},
+	}, nil
+}
+
+func (relayServer) Watch(in *RouteRequest, stream RelayService_WatchServer) error {
+	for _, v := range []string{"a", "b", "c"} {
+		if err := stream.Send(&RouteReply{Method: "Watch", Values: []string{in.Id, v}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (relayServer) Collect(stream RelayService_CollectServer) error {
+	reply := &RouteReply{
+		Method: "Collect",
+	}
+	for {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(reply)
+		}
+		if err != nil {
+			return err
+		}
+		reply.Values = append(reply.Values, in.Id+":"+in.Name)
+	}
+}
+
+func (relayServer) Chat(stream RelayService_ChatServer) error {
+	for {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err = stream.Send(&RouteReply{Method: "Chat", Values: []string{in.Id, in.Name}}); err != nil {
+			return err
+		}
+	}
+}
+
+// newRelayEndpoint creates client mode handler which forwards requests to
+// relayServer over bufconn.
+func newRelayEndpoint(t *testing.T) *RelayServiceHTTPEndpoint {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	RegisterRelayServiceServer(srv, relayServer{})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	cc, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cc.Close() })
+	return NewRelayServiceHTTPEndpoint(cc)
+}
+
+func checkRouteReply(t *testing.T, buf []byte, method string, values []string) {
+	t.Helper()
+	reply := &RouteReply{}
+	if err := protojson.Unmarshal(buf, reply); err != nil {
+		t.Fatalf("cannot decode reply %q: %v", buf, err)
+	}
+	if (reply.Method != method) || !reflect.DeepEqual(reply.Values, values) {
+		t.Errorf("reply %s %q, want %s %q", reply.Method, reply.Values, method, values)
+	}
+}
+
+func TestRelayUnary(t *testing.T) {
+	hnd := newRelayEndpoint(t)
+	rec := httptest.NewRecorder()
+	hnd.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/relay/echo/e1", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status code %d: %s", rec.Code, rec.Body.String())
+	}
+	checkRouteReply(t, rec.Body.Bytes(), "Echo", []string{"e1", ""})
+}
+
+func TestRelayUnaryStatusError(t *testing.T) {
+	hnd := newRelayEndpoint(t)
+	rec := httptest.NewRecorder()
+	hnd.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/relay/echo/missing", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status code %d, want %d: %s", rec.Code, http.StatusNotFound, rec.Body.String())
+	}
+}
+
+func TestRelayServerStream(t *testing.T) {
+	hnd := newRelayEndpoint(t)
+	rec := httptest.NewRecorder()
+	hnd.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/relay/watch/w1", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status code %d: %s", rec.Code, rec.Body.String())
+	}
+	lines := strings.Split(strings.TrimSuffix(rec.Body.String(), "\n"), "\n")
+	want := []string{"a", "b", "c"}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d: %q", len(lines), len(want), rec.Body.String())
+	}
+	for idx, line := range lines {
+		checkRouteReply(t, []byte(line), "Watch", []string{"w1", want[idx]})
+	}
+}
+
+func TestRelayClientStream(t *testing.T) {
+	hnd := newRelayEndpoint(t)
+	req := httptest.NewRequest(http.MethodPost, "/relay/collect/c1", strings.NewReader("{\"name\":\"a\"}\n{\"name\":\"b\"}\n"))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	rec := httptest.NewRecorder()
+	hnd.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status code %d: %s", rec.Code, rec.Body.String())
+	}
+	checkRouteReply(t, rec.Body.Bytes(), "Collect", []string{"c1:a", "c1:b"})
+}
+
+// writeWebSocketFrame writes a masked frame with small payload.
+func writeWebSocketFrame(conn net.Conn, opcode byte, payload []byte) error {
+	maskKey := [4]byte{0x12, 0x34, 0x56, 0x78}
+	frame := []byte{0x80 | opcode, 0x80 | byte(len(payload))}
+	frame = append(frame, maskKey[:]...)
+	for idx, b := range payload {
+		frame = append(frame, b^maskKey[idx&3])
+	}
+	_, err := conn.Write(frame)
+	return err
+}
+
+// readWebSocketFrame reads an unmasked frame with payload shorter than
+// 64 KiB.
+func readWebSocketFrame(br *bufio.Reader) (opcode byte, payload []byte, err error) {
+	var hdr [2]byte
+	if _, err = io.ReadFull(br, hdr[:]); err != nil {
+		return
+	}
+	length := int(hdr[1] & 0x7F)
+	if length == 126 {
+		if _, err = io.ReadFull(br, hdr[:]); err != nil {
+			return
+		}
+		length = int(binary.BigEndian.Uint16(hdr[:]))
+	}
+	payload = make([]byte, length)
+	_, err = io.ReadFull(br, payload)
+	return hdr[0] & 0x0F, payload, err
+}
+
+// dialWebSocket sends WebSocket handshake request with extra header to
+// server and reads the response.
+func dialWebSocket(t *testing.T, serverURL, path string, header http.Header) (net.Conn, *bufio.Reader, *http.Response) {
+	t.Helper()
+	conn, err := net.Dial("tcp", strings.TrimPrefix(serverURL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	req, _ := http.NewRequest(http.MethodGet, serverURL+path, nil)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	if err = req.Write(conn); err != nil {
+		conn.Close()
+		t.Fatal(err)
+	}
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		conn.Close()
+		t.Fatal(err)
+	}
+	return conn, br, resp
+}
+
+func TestRelayBidiStream(t *testing.T) {
+	ts := httptest.NewServer(newRelayEndpoint(t))
+	defer ts.Close()
+	header := http.Header{"Origin": {ts.URL}}
+	conn, br, resp := dialWebSocket(t, ts.URL, "/relay/chat/b1", header)
+	defer conn.Close()
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("handshake: status code %d", resp.StatusCode)
+	}
+	for _, name := range []string{"x", "y"} {
+		if err := writeWebSocketFrame(conn, 0x1, []byte(`{"name":"`+name+`"}`)); err != nil {
+			t.Fatal(err)
+		}
+		opcode, payload, err := readWebSocketFrame(br)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if opcode != 0x1 {
+			t.Fatalf("opcode %d, want text frame: %q", opcode, payload)
+		}
+		checkRouteReply(t, payload, "Chat", []string{"b1", name})
+	}
+	if err := writeWebSocketFrame(conn, 0x8, []byte{0x03, 0xE8}); err != nil {
+		t.Fatal(err)
+	}
+	opcode, payload, err := readWebSocketFrame(br)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (opcode != 0x8) || (len(payload) < 2) || (binary.BigEndian.Uint16(payload) != 1000) {
+		t.Errorf("close frame: opcode %d payload %q, want normal closure", opcode, payload)
+	}
+}
+
+func TestRelayBidiStreamCrossOrigin(t *testing.T) {
+	ts := httptest.NewServer(newRelayEndpoint(t))
+	defer ts.Close()
+	header := http.Header{"Origin": {"http://other.example"}}
+	conn, _, resp := dialWebSocket(t, ts.URL, "/relay/chat/b1", header)
+	defer conn.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("handshake: status code %d, want %d", resp.StatusCode, http.StatusForbidden)
+	}
+}
+
+func TestRelayBidiStreamInvalidFrame(t *testing.T) {
+	ts := httptest.NewServer(newRelayEndpoint(t))
+	defer ts.Close()
+	tests := []struct {
+		name      string
+		opcode    byte
+		payload   []byte
+		closeCode uint16
+	}{
+		{"invalid UTF-8 text", 0x1, []byte{'"', 0xFF, '"'}, 1007},
+		{"reserved close code", 0x8, []byte{0x03, 0xEC}, 1002},
+		{"truncated close frame", 0x8, []byte{0x03}, 1002},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, br, resp := dialWebSocket(t, ts.URL, "/relay/chat/b1", nil)
+			defer conn.Close()
+			if resp.StatusCode != http.StatusSwitchingProtocols {
+				t.Fatalf("handshake: status code %d", resp.StatusCode)
+			}
+			if err := writeWebSocketFrame(conn, tt.opcode, tt.payload); err != nil {
+				t.Fatal(err)
+			}
+			opcode, payload, err := readWebSocketFrame(br)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (opcode != 0x8) || (len(payload) < 2) || (binary.BigEndian.Uint16(payload) != tt.closeCode) {
+				t.Errorf("close frame: opcode %d payload %q, want close code %d", opcode, payload, tt.closeCode)
+			}
+		})
+	}
+}
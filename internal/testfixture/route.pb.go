@@ -0,0 +1,358 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v5.26.1
+// source: internal/testfixture/route.proto
+
+package testfixture
+
+import (
+	_ "github.com/yinyin/protoc-gen-go-grpc-http-endpoint/ghegen"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// RouteRequest receives values captured from URL path.
+type RouteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Path     string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Name     string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Tag      string `protobuf:"bytes,4,opt,name=tag,proto3" json:"tag,omitempty"`
+	Revision int64  `protobuf:"varint,5,opt,name=revision,proto3" json:"revision,omitempty"`
+	Count    uint32 `protobuf:"varint,6,opt,name=count,proto3" json:"count,omitempty"`
+	Total    uint64 `protobuf:"fixed64,7,opt,name=total,proto3" json:"total,omitempty"`
+}
+
+func (x *RouteRequest) Reset() {
+	*x = RouteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_testfixture_route_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RouteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteRequest) ProtoMessage() {}
+
+func (x *RouteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_testfixture_route_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteRequest.ProtoReflect.Descriptor instead.
+func (*RouteRequest) Descriptor() ([]byte, []int) {
+	return file_internal_testfixture_route_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RouteRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RouteRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *RouteRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RouteRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *RouteRequest) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *RouteRequest) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *RouteRequest) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// RouteReply reports the invoked method and the non-empty fields of
+// request in field order.
+type RouteReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Method string   `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Values []string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *RouteReply) Reset() {
+	*x = RouteReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_testfixture_route_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RouteReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteReply) ProtoMessage() {}
+
+func (x *RouteReply) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_testfixture_route_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteReply.ProtoReflect.Descriptor instead.
+func (*RouteReply) Descriptor() ([]byte, []int) {
+	return file_internal_testfixture_route_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RouteReply) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *RouteReply) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+var File_internal_testfixture_route_proto protoreflect.FileDescriptor
+
+var file_internal_testfixture_route_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x66,
+	0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2f, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x0b, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x1a,
+	0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x11, 0x67,
+	0x68, 0x65, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0xa0, 0x01, 0x0a, 0x0c, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x72,
+	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72,
+	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x06, 0x52, 0x05, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x22, 0x3c, 0x0a, 0x0a, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x73, 0x32, 0x8e, 0x07, 0x0a, 0x0c, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x4f, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x19, 0x2e,
+	0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66,
+	0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x10, 0x92, 0xb5, 0x18, 0x0c, 0x0a, 0x0a, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x2f, 0x7b,
+	0x69, 0x64, 0x7d, 0x12, 0x52, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x49, 0x74, 0x65,
+	0x6d, 0x12, 0x19, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e,
+	0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67,
+	0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x10, 0x92, 0xb5, 0x18, 0x0c, 0x22, 0x0a, 0x69, 0x74, 0x65,
+	0x6d, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x57, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4c, 0x61,
+	0x74, 0x65, 0x73, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x19, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66,
+	0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72,
+	0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x12, 0x92, 0xb5,
+	0x18, 0x0e, 0x0a, 0x0c, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x2f, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74,
+	0x12, 0x5d, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x54, 0x61, 0x67, 0x12, 0x19,
+	0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x52, 0x6f, 0x75,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x68, 0x65, 0x2e,
+	0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0x1b, 0x92, 0xb5, 0x18, 0x17, 0x0a, 0x15, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x2f,
+	0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x74, 0x61, 0x67, 0x73, 0x2f, 0x7b, 0x74, 0x61, 0x67, 0x7d, 0x12,
+	0x5f, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x19, 0x2e, 0x67, 0x68, 0x65,
+	0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74,
+	0x75, 0x72, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x20,
+	0x92, 0xb5, 0x18, 0x1c, 0x0a, 0x1a, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x2f, 0x7b, 0x70, 0x61, 0x74,
+	0x68, 0x3a, 0x20, 0x2e, 0x2a, 0x2c, 0x20, 0x70, 0x61, 0x74, 0x68, 0x7d, 0x2f, 0x72, 0x61, 0x77,
+	0x12, 0x64, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x12,
+	0x19, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x52, 0x6f,
+	0x75, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x68, 0x65,
+	0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x21, 0x92, 0xb5, 0x18, 0x1d, 0x0a, 0x1b, 0x66, 0x69, 0x6c, 0x65, 0x73,
+	0x2f, 0x7b, 0x70, 0x61, 0x74, 0x68, 0x3a, 0x20, 0x2e, 0x2a, 0x2c, 0x20, 0x70, 0x61, 0x74, 0x68,
+	0x7d, 0x2f, 0x6d, 0x65, 0x74, 0x61, 0x12, 0x5f, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x42, 0x6f, 0x6f,
+	0x6b, 0x12, 0x19, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e,
+	0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67,
+	0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x20, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1a, 0x12, 0x18, 0x2f,
+	0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2f, 0x62, 0x6f, 0x6f, 0x6b, 0x73, 0x2f, 0x7b, 0x6e,
+	0x61, 0x6d, 0x65, 0x3d, 0x2a, 0x2a, 0x7d, 0x12, 0x6e, 0x0a, 0x0b, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x42, 0x6f, 0x6f, 0x6b, 0x12, 0x19, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78,
+	0x74, 0x75, 0x72, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x17, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e,
+	0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x2b, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x25, 0x3a, 0x01, 0x2a, 0x22, 0x20, 0x2f, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2f,
+	0x62, 0x6f, 0x6f, 0x6b, 0x73, 0x2f, 0x7b, 0x6e, 0x61, 0x6d, 0x65, 0x3d, 0x2a, 0x2a, 0x7d, 0x3a,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x12, 0x7a, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x53, 0x68,
+	0x65, 0x6c, 0x66, 0x12, 0x19, 0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72,
+	0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
+	0x2e, 0x67, 0x68, 0x65, 0x2e, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x52, 0x6f, 0x75,
+	0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x3a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x34, 0x5a,
+	0x1c, 0x12, 0x1a, 0x2f, 0x76, 0x32, 0x2f, 0x7b, 0x6e, 0x61, 0x6d, 0x65, 0x3d, 0x73, 0x68, 0x65,
+	0x6c, 0x76, 0x65, 0x73, 0x2f, 0x2a, 0x7d, 0x2f, 0x7b, 0x74, 0x61, 0x67, 0x7d, 0x12, 0x14, 0x2f,
+	0x76, 0x31, 0x2f, 0x7b, 0x6e, 0x61, 0x6d, 0x65, 0x3d, 0x73, 0x68, 0x65, 0x6c, 0x76, 0x65, 0x73,
+	0x2f, 0x2a, 0x7d, 0x1a, 0x0d, 0x92, 0xb5, 0x18, 0x09, 0x0a, 0x07, 0x66, 0x69, 0x78, 0x74, 0x75,
+	0x72, 0x65, 0x42, 0x49, 0x5a, 0x47, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x79, 0x69, 0x6e, 0x79, 0x69, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67,
+	0x65, 0x6e, 0x2d, 0x67, 0x6f, 0x2d, 0x67, 0x72, 0x70, 0x63, 0x2d, 0x68, 0x74, 0x74, 0x70, 0x2d,
+	0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61,
+	0x6c, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x66, 0x69, 0x78, 0x74, 0x75, 0x72, 0x65, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_internal_testfixture_route_proto_rawDescOnce sync.Once
+	file_internal_testfixture_route_proto_rawDescData = file_internal_testfixture_route_proto_rawDesc
+)
+
+func file_internal_testfixture_route_proto_rawDescGZIP() []byte {
+	file_internal_testfixture_route_proto_rawDescOnce.Do(func() {
+		file_internal_testfixture_route_proto_rawDescData = protoimpl.X.CompressGZIP(file_internal_testfixture_route_proto_rawDescData)
+	})
+	return file_internal_testfixture_route_proto_rawDescData
+}
+
+var file_internal_testfixture_route_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_internal_testfixture_route_proto_goTypes = []interface{}{
+	(*RouteRequest)(nil), // 0: ghe.fixture.RouteRequest
+	(*RouteReply)(nil),   // 1: ghe.fixture.RouteReply
+}
+var file_internal_testfixture_route_proto_depIdxs = []int32{
+	0, // 0: ghe.fixture.RouteService.GetItem:input_type -> ghe.fixture.RouteRequest
+	0, // 1: ghe.fixture.RouteService.DeleteItem:input_type -> ghe.fixture.RouteRequest
+	0, // 2: ghe.fixture.RouteService.GetLatestItem:input_type -> ghe.fixture.RouteRequest
+	0, // 3: ghe.fixture.RouteService.GetItemTag:input_type -> ghe.fixture.RouteRequest
+	0, // 4: ghe.fixture.RouteService.GetFile:input_type -> ghe.fixture.RouteRequest
+	0, // 5: ghe.fixture.RouteService.GetFileMeta:input_type -> ghe.fixture.RouteRequest
+	0, // 6: ghe.fixture.RouteService.GetBook:input_type -> ghe.fixture.RouteRequest
+	0, // 7: ghe.fixture.RouteService.PublishBook:input_type -> ghe.fixture.RouteRequest
+	0, // 8: ghe.fixture.RouteService.GetShelf:input_type -> ghe.fixture.RouteRequest
+	1, // 9: ghe.fixture.RouteService.GetItem:output_type -> ghe.fixture.RouteReply
+	1, // 10: ghe.fixture.RouteService.DeleteItem:output_type -> ghe.fixture.RouteReply
+	1, // 11: ghe.fixture.RouteService.GetLatestItem:output_type -> ghe.fixture.RouteReply
+	1, // 12: ghe.fixture.RouteService.GetItemTag:output_type -> ghe.fixture.RouteReply
+	1, // 13: ghe.fixture.RouteService.GetFile:output_type -> ghe.fixture.RouteReply
+	1, // 14: ghe.fixture.RouteService.GetFileMeta:output_type -> ghe.fixture.RouteReply
+	1, // 15: ghe.fixture.RouteService.GetBook:output_type -> ghe.fixture.RouteReply
+	1, // 16: ghe.fixture.RouteService.PublishBook:output_type -> ghe.fixture.RouteReply
+	1, // 17: ghe.fixture.RouteService.GetShelf:output_type -> ghe.fixture.RouteReply
+	9, // [9:18] is the sub-list for method output_type
+	0, // [0:9] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_internal_testfixture_route_proto_init() }
+func file_internal_testfixture_route_proto_init() {
+	if File_internal_testfixture_route_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_internal_testfixture_route_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RouteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_testfixture_route_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RouteReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_internal_testfixture_route_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_internal_testfixture_route_proto_goTypes,
+		DependencyIndexes: file_internal_testfixture_route_proto_depIdxs,
+		MessageInfos:      file_internal_testfixture_route_proto_msgTypes,
+	}.Build()
+	File_internal_testfixture_route_proto = out.File
+	file_internal_testfixture_route_proto_rawDesc = nil
+	file_internal_testfixture_route_proto_goTypes = nil
+	file_internal_testfixture_route_proto_depIdxs = nil
+}
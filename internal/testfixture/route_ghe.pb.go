@@ -0,0 +1,727 @@
+// Code generated by protoc-gen-go-grpc-http-endpoint. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc-http-endpoint v0.0.1
+// - protoc                           (unknown)
+// source: internal/testfixture/route.proto
+
+package testfixture
+
+import (
+	ghert "github.com/yinyin/protoc-gen-go-grpc-http-endpoint/ghert"
+	http "net/http"
+	strings "strings"
+)
+
+// RouteServiceHTTPEndpoint serves HTTP endpoints of RouteService.
+type RouteServiceHTTPEndpoint struct {
+	srv RouteServiceServer
+}
+
+// NewRouteServiceHTTPEndpoint creates RouteServiceHTTPEndpoint which invokes methods of srv.
+func NewRouteServiceHTTPEndpoint(srv RouteServiceServer) *RouteServiceHTTPEndpoint {
+	return &RouteServiceHTTPEndpoint{
+		srv: srv,
+	}
+}
+
+// _RouteService_HTTPErrorWriter writes error responses of RouteServiceHTTPEndpoint.
+var _RouteService_HTTPErrorWriter = &ghert.ErrorWriter{}
+
+// ServeHTTP implements http.Handler interface.
+func (hnd *RouteServiceHTTPEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p0 := r.URL.EscapedPath()
+	for (len(p0) != 0) && (p0[0] == '/') {
+		p0 = p0[1:]
+	}
+	if len(p0) != 0 {
+		switch p0[0] {
+		case 'f':
+			// fixture/
+			if strings.HasPrefix(p0, "fixture/") {
+				p1 := p0[8:]
+				if len(p1) != 0 {
+					switch p1[0] {
+					case 'b':
+						// books/
+						if strings.HasPrefix(p1, "books/") {
+							p2 := p1[6:]
+							// {{capture: 0xFBFFFFFE00000000 0x7FFFFFFFFFFFFFFF}}
+							if c0 := p2[:ghert.CaptureLen(p2, 0xfbfffffe00000000, 0x7fffffffffffffff)]; len(c0) != 0 {
+								p3 := p2[len(c0):]
+								if len(p3) == 0 {
+									switch r.Method {
+									case http.MethodGet:
+										hnd.serveRouteServiceGetBookByGet(w, r, c0)
+										return
+									}
+									_RouteService_HTTPErrorWriter.WriteMethodNotAllowed(w, r, "RouteServiceGetBook", "GET")
+									return
+								}
+								// :publish
+								if strings.HasPrefix(p3, ":publish") {
+									p4 := p3[8:]
+									if len(p4) == 0 {
+										switch r.Method {
+										case http.MethodPost:
+											hnd.serveRouteServicePublishBookByPost(w, r, c0)
+											return
+										}
+										_RouteService_HTTPErrorWriter.WriteMethodNotAllowed(w, r, "RouteServicePublishBook", "POST")
+										return
+									}
+								}
+							}
+						}
+					case 'f':
+						// files/
+						if strings.HasPrefix(p1, "files/") {
+							p2 := p1[6:]
+							// {{capture: 0xFFFFFFFF00000000 0x7FFFFFFFFFFFFFFF}}
+							for c0 := p2[:ghert.CaptureLen(p2, 0xffffffff00000000, 0x7fffffffffffffff)]; len(c0) != 0; c0 = c0[:len(c0)-1] {
+								p3 := p2[len(c0):]
+								// /
+								if strings.HasPrefix(p3, "/") {
+									p4 := p3[1:]
+									if len(p4) != 0 {
+										switch p4[0] {
+										case 'm':
+											// meta
+											if strings.HasPrefix(p4, "meta") {
+												p5 := p4[4:]
+												if len(p5) == 0 {
+													switch r.Method {
+													case http.MethodGet:
+														hnd.serveRouteServiceGetFileMetaByGet(w, r, c0)
+														return
+													}
+													_RouteService_HTTPErrorWriter.WriteMethodNotAllowed(w, r, "RouteServiceGetFileMeta", "GET")
+													return
+												}
+											}
+										case 'r':
+											// raw
+											if strings.HasPrefix(p4, "raw") {
+												p5 := p4[3:]
+												if len(p5) == 0 {
+													switch r.Method {
+													case http.MethodGet:
+														hnd.serveRouteServiceGetFileByGet(w, r, c0)
+														return
+													}
+													_RouteService_HTTPErrorWriter.WriteMethodNotAllowed(w, r, "RouteServiceGetFile", "GET")
+													return
+												}
+											}
+										}
+									}
+								}
+							}
+						}
+					case 'i':
+						// items/
+						if strings.HasPrefix(p1, "items/") {
+							p2 := p1[6:]
+							// latest
+							if strings.HasPrefix(p2, "latest") {
+								p3 := p2[6:]
+								if len(p3) == 0 {
+									switch r.Method {
+									case http.MethodGet:
+										hnd.serveRouteServiceGetLatestItemByGet(w, r)
+										return
+									}
+									_RouteService_HTTPErrorWriter.WriteMethodNotAllowed(w, r, "RouteServiceGetLatestItem", "GET")
+									return
+								}
+							}
+							// {{capture: 0xFFFF7FFF00000000 0x7FFFFFFFFFFFFFFF}}
+							if c0 := p2[:ghert.CaptureLen(p2, 0xffff7fff00000000, 0x7fffffffffffffff)]; len(c0) != 0 {
+								p3 := p2[len(c0):]
+								if len(p3) == 0 {
+									switch r.Method {
+									case http.MethodGet:
+										hnd.serveRouteServiceGetItemByGet(w, r, c0)
+										return
+									case http.MethodDelete:
+										hnd.serveRouteServiceDeleteItemByDelete(w, r, c0)
+										return
+									}
+									_RouteService_HTTPErrorWriter.WriteMethodNotAllowed(w, r, "", "GET, DELETE")
+									return
+								}
+								// /tags/
+								if strings.HasPrefix(p3, "/tags/") {
+									p4 := p3[6:]
+									// {{capture: 0xFFFF7FFF00000000 0x7FFFFFFFFFFFFFFF}}
+									if c1 := p4[:ghert.CaptureLen(p4, 0xffff7fff00000000, 0x7fffffffffffffff)]; len(c1) != 0 {
+										p5 := p4[len(c1):]
+										if len(p5) == 0 {
+											switch r.Method {
+											case http.MethodGet:
+												hnd.serveRouteServiceGetItemTagByGet(w, r, c0, c1)
+												return
+											}
+											_RouteService_HTTPErrorWriter.WriteMethodNotAllowed(w, r, "RouteServiceGetItemTag", "GET")
+											return
+										}
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		case 'v':
+			// v
+			if strings.HasPrefix(p0, "v") {
+				p1 := p0[1:]
+				if len(p1) != 0 {
+					switch p1[0] {
+					case '1':
+						// 1/
+						if strings.HasPrefix(p1, "1/") {
+							p2 := p1[2:]
+							// {{capture: 0xFBFFFFFE00000000 0x7FFFFFFFFFFFFFFF}}
+							if c0 := p2[:ghert.CaptureLen(p2, 0xfbfffffe00000000, 0x7fffffffffffffff)]; len(c0) != 0 {
+								p3 := p2[len(c0):]
+								if len(p3) == 0 {
+									switch r.Method {
+									case http.MethodGet:
+										hnd.serveRouteServiceGetShelfByGet(w, r, c0)
+										return
+									}
+									_RouteService_HTTPErrorWriter.WriteMethodNotAllowed(w, r, "RouteServiceGetShelf", "GET")
+									return
+								}
+							}
+						}
+					case '2':
+						// 2/
+						if strings.HasPrefix(p1, "2/") {
+							p2 := p1[2:]
+							// {{capture: 0xFBFFFFFE00000000 0x7FFFFFFFFFFFFFFF}}
+							for c0 := p2[:ghert.CaptureLen(p2, 0xfbfffffe00000000, 0x7fffffffffffffff)]; len(c0) != 0; c0 = c0[:len(c0)-1] {
+								p3 := p2[len(c0):]
+								// /
+								if strings.HasPrefix(p3, "/") {
+									p4 := p3[1:]
+									// {{capture: 0xFBFF7FFE00000000 0x7FFFFFFFFFFFFFFF}}
+									if c1 := p4[:ghert.CaptureLen(p4, 0xfbff7ffe00000000, 0x7fffffffffffffff)]; len(c1) != 0 {
+										p5 := p4[len(c1):]
+										if len(p5) == 0 {
+											switch r.Method {
+											case http.MethodGet:
+												hnd.serveRouteServiceGetShelfBinding1ByGet(w, r, c0, c1)
+												return
+											}
+											_RouteService_HTTPErrorWriter.WriteMethodNotAllowed(w, r, "RouteServiceGetShelfBinding1", "GET")
+											return
+										}
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	_RouteService_HTTPErrorWriter.WriteRouteNotFound(w, r)
+}
+
+// serveRouteServicePublishBookByPost handles POST request on `fixture/books/{!-9;-~, name}:publish`.
+func (hnd *RouteServiceHTTPEndpoint) serveRouteServicePublishBookByPost(w http.ResponseWriter, r *http.Request, captureName string) {
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.AllEncodings)
+	if err != nil {
+		_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServicePublishBook", err)
+		return
+	}
+	in := new(RouteRequest)
+	if err := ghert.DefaultMessageCodec.DecodeRequest(r, ghert.AllEncodings, in); err != nil {
+		_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServicePublishBook", err)
+		return
+	}
+	{
+		v, err := ghert.DecodeString(captureName)
+		if err != nil {
+			_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServicePublishBook", ghert.NewDecodeError("name", captureName, err))
+			return
+		}
+		in.Name = v
+	}
+	out, err := hnd.srv.PublishBook(r.Context(), in)
+	if err != nil {
+		_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServicePublishBook", err)
+		return
+	}
+	ghert.DefaultMessageCodec.WriteResponse(w, r, _RouteService_HTTPErrorWriter, "RouteServicePublishBook", respEncoding, http.StatusOK, out)
+}
+
+// serveRouteServiceGetBookByGet handles GET request on `fixture/books/{!-9;-~, name}`.
+func (hnd *RouteServiceHTTPEndpoint) serveRouteServiceGetBookByGet(w http.ResponseWriter, r *http.Request, captureName string) {
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.AllEncodings)
+	if err != nil {
+		_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetBook", err)
+		return
+	}
+	in := new(RouteRequest)
+	{
+		query, err := ghert.ParseQuery(r)
+		if err != nil {
+			_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetBook", err)
+			return
+		}
+		for key, values := range query {
+			switch key {
+			case "id":
+				if len(values) > 1 {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetBook", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeQueryString(value)
+				if err != nil {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetBook", ghert.NewDecodeError("id", value, err))
+					return
+				}
+				in.Id = v
+			case "path":
+				if len(values) > 1 {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetBook", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeQueryString(value)
+				if err != nil {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetBook", ghert.NewDecodeError("path", value, err))
+					return
+				}
+				in.Path = v
+			case "tag":
+				if len(values) > 1 {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetBook", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeQueryString(value)
+				if err != nil {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetBook", ghert.NewDecodeError("tag", value, err))
+					return
+				}
+				in.Tag = v
+			case "revision":
+				if len(values) > 1 {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetBook", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeInt64(value)
+				if err != nil {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetBook", ghert.NewDecodeError("revision", value, err))
+					return
+				}
+				in.Revision = v
+			case "count":
+				if len(values) > 1 {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetBook", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeUint32(value)
+				if err != nil {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetBook", ghert.NewDecodeError("count", value, err))
+					return
+				}
+				in.Count = v
+			case "total":
+				if len(values) > 1 {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetBook", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeUint64(value)
+				if err != nil {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetBook", ghert.NewDecodeError("total", value, err))
+					return
+				}
+				in.Total = v
+			}
+		}
+	}
+	{
+		v, err := ghert.DecodeString(captureName)
+		if err != nil {
+			_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetBook", ghert.NewDecodeError("name", captureName, err))
+			return
+		}
+		in.Name = v
+	}
+	out, err := hnd.srv.GetBook(r.Context(), in)
+	if err != nil {
+		_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetBook", err)
+		return
+	}
+	ghert.DefaultMessageCodec.WriteResponse(w, r, _RouteService_HTTPErrorWriter, "RouteServiceGetBook", respEncoding, http.StatusOK, out)
+}
+
+// serveRouteServiceGetFileMetaByGet handles GET request on `fixture/files/{path: .*, path}/meta`.
+func (hnd *RouteServiceHTTPEndpoint) serveRouteServiceGetFileMetaByGet(w http.ResponseWriter, r *http.Request, capturePath string) {
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.AllEncodings)
+	if err != nil {
+		_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetFileMeta", err)
+		return
+	}
+	in := new(RouteRequest)
+	{
+		v, err := ghert.DecodeString(capturePath)
+		if err != nil {
+			_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetFileMeta", ghert.NewDecodeError("path", capturePath, err))
+			return
+		}
+		in.Path = v
+	}
+	out, err := hnd.srv.GetFileMeta(r.Context(), in)
+	if err != nil {
+		_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetFileMeta", err)
+		return
+	}
+	ghert.DefaultMessageCodec.WriteResponse(w, r, _RouteService_HTTPErrorWriter, "RouteServiceGetFileMeta", respEncoding, http.StatusOK, out)
+}
+
+// serveRouteServiceGetFileByGet handles GET request on `fixture/files/{path: .*, path}/raw`.
+func (hnd *RouteServiceHTTPEndpoint) serveRouteServiceGetFileByGet(w http.ResponseWriter, r *http.Request, capturePath string) {
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.AllEncodings)
+	if err != nil {
+		_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetFile", err)
+		return
+	}
+	in := new(RouteRequest)
+	{
+		v, err := ghert.DecodeString(capturePath)
+		if err != nil {
+			_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetFile", ghert.NewDecodeError("path", capturePath, err))
+			return
+		}
+		in.Path = v
+	}
+	out, err := hnd.srv.GetFile(r.Context(), in)
+	if err != nil {
+		_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetFile", err)
+		return
+	}
+	ghert.DefaultMessageCodec.WriteResponse(w, r, _RouteService_HTTPErrorWriter, "RouteServiceGetFile", respEncoding, http.StatusOK, out)
+}
+
+// serveRouteServiceGetLatestItemByGet handles GET request on `fixture/items/latest`.
+func (hnd *RouteServiceHTTPEndpoint) serveRouteServiceGetLatestItemByGet(w http.ResponseWriter, r *http.Request) {
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.AllEncodings)
+	if err != nil {
+		_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetLatestItem", err)
+		return
+	}
+	in := new(RouteRequest)
+	out, err := hnd.srv.GetLatestItem(r.Context(), in)
+	if err != nil {
+		_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetLatestItem", err)
+		return
+	}
+	ghert.DefaultMessageCodec.WriteResponse(w, r, _RouteService_HTTPErrorWriter, "RouteServiceGetLatestItem", respEncoding, http.StatusOK, out)
+}
+
+// serveRouteServiceGetItemTagByGet handles GET request on `fixture/items/{id}/tags/{tag}`.
+func (hnd *RouteServiceHTTPEndpoint) serveRouteServiceGetItemTagByGet(w http.ResponseWriter, r *http.Request, captureId, captureTag string) {
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.AllEncodings)
+	if err != nil {
+		_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetItemTag", err)
+		return
+	}
+	in := new(RouteRequest)
+	{
+		v, err := ghert.DecodeString(captureId)
+		if err != nil {
+			_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetItemTag", ghert.NewDecodeError("id", captureId, err))
+			return
+		}
+		in.Id = v
+	}
+	{
+		v, err := ghert.DecodeString(captureTag)
+		if err != nil {
+			_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetItemTag", ghert.NewDecodeError("tag", captureTag, err))
+			return
+		}
+		in.Tag = v
+	}
+	out, err := hnd.srv.GetItemTag(r.Context(), in)
+	if err != nil {
+		_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetItemTag", err)
+		return
+	}
+	ghert.DefaultMessageCodec.WriteResponse(w, r, _RouteService_HTTPErrorWriter, "RouteServiceGetItemTag", respEncoding, http.StatusOK, out)
+}
+
+// serveRouteServiceGetItemByGet handles GET request on `fixture/items/{id}`.
+func (hnd *RouteServiceHTTPEndpoint) serveRouteServiceGetItemByGet(w http.ResponseWriter, r *http.Request, captureId string) {
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.AllEncodings)
+	if err != nil {
+		_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetItem", err)
+		return
+	}
+	in := new(RouteRequest)
+	{
+		v, err := ghert.DecodeString(captureId)
+		if err != nil {
+			_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetItem", ghert.NewDecodeError("id", captureId, err))
+			return
+		}
+		in.Id = v
+	}
+	out, err := hnd.srv.GetItem(r.Context(), in)
+	if err != nil {
+		_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetItem", err)
+		return
+	}
+	ghert.DefaultMessageCodec.WriteResponse(w, r, _RouteService_HTTPErrorWriter, "RouteServiceGetItem", respEncoding, http.StatusOK, out)
+}
+
+// serveRouteServiceDeleteItemByDelete handles DELETE request on `fixture/items/{id}`.
+func (hnd *RouteServiceHTTPEndpoint) serveRouteServiceDeleteItemByDelete(w http.ResponseWriter, r *http.Request, captureId string) {
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.AllEncodings)
+	if err != nil {
+		_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceDeleteItem", err)
+		return
+	}
+	in := new(RouteRequest)
+	{
+		v, err := ghert.DecodeString(captureId)
+		if err != nil {
+			_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceDeleteItem", ghert.NewDecodeError("id", captureId, err))
+			return
+		}
+		in.Id = v
+	}
+	out, err := hnd.srv.DeleteItem(r.Context(), in)
+	if err != nil {
+		_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceDeleteItem", err)
+		return
+	}
+	ghert.DefaultMessageCodec.WriteResponse(w, r, _RouteService_HTTPErrorWriter, "RouteServiceDeleteItem", respEncoding, http.StatusOK, out)
+}
+
+// serveRouteServiceGetShelfByGet handles GET request on `v1/{!-9;-~, name}`.
+func (hnd *RouteServiceHTTPEndpoint) serveRouteServiceGetShelfByGet(w http.ResponseWriter, r *http.Request, captureName string) {
+	if !ghert.MatchPathTemplate(captureName, "shelves/*") {
+		_RouteService_HTTPErrorWriter.WriteRouteNotFound(w, r)
+		return
+	}
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.AllEncodings)
+	if err != nil {
+		_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelf", err)
+		return
+	}
+	in := new(RouteRequest)
+	{
+		query, err := ghert.ParseQuery(r)
+		if err != nil {
+			_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelf", err)
+			return
+		}
+		for key, values := range query {
+			switch key {
+			case "id":
+				if len(values) > 1 {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelf", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeQueryString(value)
+				if err != nil {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelf", ghert.NewDecodeError("id", value, err))
+					return
+				}
+				in.Id = v
+			case "path":
+				if len(values) > 1 {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelf", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeQueryString(value)
+				if err != nil {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelf", ghert.NewDecodeError("path", value, err))
+					return
+				}
+				in.Path = v
+			case "tag":
+				if len(values) > 1 {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelf", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeQueryString(value)
+				if err != nil {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelf", ghert.NewDecodeError("tag", value, err))
+					return
+				}
+				in.Tag = v
+			case "revision":
+				if len(values) > 1 {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelf", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeInt64(value)
+				if err != nil {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelf", ghert.NewDecodeError("revision", value, err))
+					return
+				}
+				in.Revision = v
+			case "count":
+				if len(values) > 1 {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelf", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeUint32(value)
+				if err != nil {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelf", ghert.NewDecodeError("count", value, err))
+					return
+				}
+				in.Count = v
+			case "total":
+				if len(values) > 1 {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelf", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeUint64(value)
+				if err != nil {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelf", ghert.NewDecodeError("total", value, err))
+					return
+				}
+				in.Total = v
+			}
+		}
+	}
+	{
+		v, err := ghert.DecodeString(captureName)
+		if err != nil {
+			_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelf", ghert.NewDecodeError("name", captureName, err))
+			return
+		}
+		in.Name = v
+	}
+	out, err := hnd.srv.GetShelf(r.Context(), in)
+	if err != nil {
+		_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelf", err)
+		return
+	}
+	ghert.DefaultMessageCodec.WriteResponse(w, r, _RouteService_HTTPErrorWriter, "RouteServiceGetShelf", respEncoding, http.StatusOK, out)
+}
+
+// serveRouteServiceGetShelfBinding1ByGet handles GET request on `v2/{!-9;-~, name}/{!-.0-9;-~, tag}`.
+func (hnd *RouteServiceHTTPEndpoint) serveRouteServiceGetShelfBinding1ByGet(w http.ResponseWriter, r *http.Request, captureName, captureTag string) {
+	if !ghert.MatchPathTemplate(captureName, "shelves/*") {
+		_RouteService_HTTPErrorWriter.WriteRouteNotFound(w, r)
+		return
+	}
+	respEncoding, err := ghert.NegotiateEncoding(r, ghert.AllEncodings)
+	if err != nil {
+		_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelfBinding1", err)
+		return
+	}
+	in := new(RouteRequest)
+	{
+		query, err := ghert.ParseQuery(r)
+		if err != nil {
+			_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelfBinding1", err)
+			return
+		}
+		for key, values := range query {
+			switch key {
+			case "id":
+				if len(values) > 1 {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelfBinding1", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeQueryString(value)
+				if err != nil {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelfBinding1", ghert.NewDecodeError("id", value, err))
+					return
+				}
+				in.Id = v
+			case "path":
+				if len(values) > 1 {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelfBinding1", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeQueryString(value)
+				if err != nil {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelfBinding1", ghert.NewDecodeError("path", value, err))
+					return
+				}
+				in.Path = v
+			case "revision":
+				if len(values) > 1 {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelfBinding1", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeInt64(value)
+				if err != nil {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelfBinding1", ghert.NewDecodeError("revision", value, err))
+					return
+				}
+				in.Revision = v
+			case "count":
+				if len(values) > 1 {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelfBinding1", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeUint32(value)
+				if err != nil {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelfBinding1", ghert.NewDecodeError("count", value, err))
+					return
+				}
+				in.Count = v
+			case "total":
+				if len(values) > 1 {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelfBinding1", ghert.NewDecodeError(key, values[1], ghert.ErrMultipleQueryValues))
+					return
+				}
+				value := values[0]
+				v, err := ghert.DecodeUint64(value)
+				if err != nil {
+					_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelfBinding1", ghert.NewDecodeError("total", value, err))
+					return
+				}
+				in.Total = v
+			}
+		}
+	}
+	{
+		v, err := ghert.DecodeString(captureName)
+		if err != nil {
+			_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelfBinding1", ghert.NewDecodeError("name", captureName, err))
+			return
+		}
+		in.Name = v
+	}
+	{
+		v, err := ghert.DecodeString(captureTag)
+		if err != nil {
+			_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelfBinding1", ghert.NewDecodeError("tag", captureTag, err))
+			return
+		}
+		in.Tag = v
+	}
+	out, err := hnd.srv.GetShelf(r.Context(), in)
+	if err != nil {
+		_RouteService_HTTPErrorWriter.WriteRouteError(w, r, "RouteServiceGetShelfBinding1", err)
+		return
+	}
+	ghert.DefaultMessageCodec.WriteResponse(w, r, _RouteService_HTTPErrorWriter, "RouteServiceGetShelfBinding1", respEncoding, http.StatusOK, out)
+}
@@ -0,0 +1,425 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.26.1
+// source: internal/testfixture/route.proto
+
+package testfixture
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RouteService_GetItem_FullMethodName       = "/ghe.fixture.RouteService/GetItem"
+	RouteService_DeleteItem_FullMethodName    = "/ghe.fixture.RouteService/DeleteItem"
+	RouteService_GetLatestItem_FullMethodName = "/ghe.fixture.RouteService/GetLatestItem"
+	RouteService_GetItemTag_FullMethodName    = "/ghe.fixture.RouteService/GetItemTag"
+	RouteService_GetFile_FullMethodName       = "/ghe.fixture.RouteService/GetFile"
+	RouteService_GetFileMeta_FullMethodName   = "/ghe.fixture.RouteService/GetFileMeta"
+	RouteService_GetBook_FullMethodName       = "/ghe.fixture.RouteService/GetBook"
+	RouteService_PublishBook_FullMethodName   = "/ghe.fixture.RouteService/PublishBook"
+	RouteService_GetShelf_FullMethodName      = "/ghe.fixture.RouteService/GetShelf"
+)
+
+// RouteServiceClient is the client API for RouteService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RouteServiceClient interface {
+	GetItem(ctx context.Context, in *RouteRequest, opts ...grpc.CallOption) (*RouteReply, error)
+	DeleteItem(ctx context.Context, in *RouteRequest, opts ...grpc.CallOption) (*RouteReply, error)
+	GetLatestItem(ctx context.Context, in *RouteRequest, opts ...grpc.CallOption) (*RouteReply, error)
+	GetItemTag(ctx context.Context, in *RouteRequest, opts ...grpc.CallOption) (*RouteReply, error)
+	GetFile(ctx context.Context, in *RouteRequest, opts ...grpc.CallOption) (*RouteReply, error)
+	GetFileMeta(ctx context.Context, in *RouteRequest, opts ...grpc.CallOption) (*RouteReply, error)
+	GetBook(ctx context.Context, in *RouteRequest, opts ...grpc.CallOption) (*RouteReply, error)
+	PublishBook(ctx context.Context, in *RouteRequest, opts ...grpc.CallOption) (*RouteReply, error)
+	GetShelf(ctx context.Context, in *RouteRequest, opts ...grpc.CallOption) (*RouteReply, error)
+}
+
+type routeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRouteServiceClient(cc grpc.ClientConnInterface) RouteServiceClient {
+	return &routeServiceClient{cc}
+}
+
+func (c *routeServiceClient) GetItem(ctx context.Context, in *RouteRequest, opts ...grpc.CallOption) (*RouteReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RouteReply)
+	err := c.cc.Invoke(ctx, RouteService_GetItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeServiceClient) DeleteItem(ctx context.Context, in *RouteRequest, opts ...grpc.CallOption) (*RouteReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RouteReply)
+	err := c.cc.Invoke(ctx, RouteService_DeleteItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeServiceClient) GetLatestItem(ctx context.Context, in *RouteRequest, opts ...grpc.CallOption) (*RouteReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RouteReply)
+	err := c.cc.Invoke(ctx, RouteService_GetLatestItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeServiceClient) GetItemTag(ctx context.Context, in *RouteRequest, opts ...grpc.CallOption) (*RouteReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RouteReply)
+	err := c.cc.Invoke(ctx, RouteService_GetItemTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeServiceClient) GetFile(ctx context.Context, in *RouteRequest, opts ...grpc.CallOption) (*RouteReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RouteReply)
+	err := c.cc.Invoke(ctx, RouteService_GetFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeServiceClient) GetFileMeta(ctx context.Context, in *RouteRequest, opts ...grpc.CallOption) (*RouteReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RouteReply)
+	err := c.cc.Invoke(ctx, RouteService_GetFileMeta_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeServiceClient) GetBook(ctx context.Context, in *RouteRequest, opts ...grpc.CallOption) (*RouteReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RouteReply)
+	err := c.cc.Invoke(ctx, RouteService_GetBook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeServiceClient) PublishBook(ctx context.Context, in *RouteRequest, opts ...grpc.CallOption) (*RouteReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RouteReply)
+	err := c.cc.Invoke(ctx, RouteService_PublishBook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeServiceClient) GetShelf(ctx context.Context, in *RouteRequest, opts ...grpc.CallOption) (*RouteReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RouteReply)
+	err := c.cc.Invoke(ctx, RouteService_GetShelf_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RouteServiceServer is the server API for RouteService service.
+// All implementations must embed UnimplementedRouteServiceServer
+// for forward compatibility.
+type RouteServiceServer interface {
+	GetItem(context.Context, *RouteRequest) (*RouteReply, error)
+	DeleteItem(context.Context, *RouteRequest) (*RouteReply, error)
+	GetLatestItem(context.Context, *RouteRequest) (*RouteReply, error)
+	GetItemTag(context.Context, *RouteRequest) (*RouteReply, error)
+	GetFile(context.Context, *RouteRequest) (*RouteReply, error)
+	GetFileMeta(context.Context, *RouteRequest) (*RouteReply, error)
+	GetBook(context.Context, *RouteRequest) (*RouteReply, error)
+	PublishBook(context.Context, *RouteRequest) (*RouteReply, error)
+	GetShelf(context.Context, *RouteRequest) (*RouteReply, error)
+	mustEmbedUnimplementedRouteServiceServer()
+}
+
+// UnimplementedRouteServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRouteServiceServer struct{}
+
+func (UnimplementedRouteServiceServer) GetItem(context.Context, *RouteRequest) (*RouteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetItem not implemented")
+}
+func (UnimplementedRouteServiceServer) DeleteItem(context.Context, *RouteRequest) (*RouteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteItem not implemented")
+}
+func (UnimplementedRouteServiceServer) GetLatestItem(context.Context, *RouteRequest) (*RouteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLatestItem not implemented")
+}
+func (UnimplementedRouteServiceServer) GetItemTag(context.Context, *RouteRequest) (*RouteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetItemTag not implemented")
+}
+func (UnimplementedRouteServiceServer) GetFile(context.Context, *RouteRequest) (*RouteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFile not implemented")
+}
+func (UnimplementedRouteServiceServer) GetFileMeta(context.Context, *RouteRequest) (*RouteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFileMeta not implemented")
+}
+func (UnimplementedRouteServiceServer) GetBook(context.Context, *RouteRequest) (*RouteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBook not implemented")
+}
+func (UnimplementedRouteServiceServer) PublishBook(context.Context, *RouteRequest) (*RouteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishBook not implemented")
+}
+func (UnimplementedRouteServiceServer) GetShelf(context.Context, *RouteRequest) (*RouteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShelf not implemented")
+}
+func (UnimplementedRouteServiceServer) mustEmbedUnimplementedRouteServiceServer() {}
+func (UnimplementedRouteServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeRouteServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RouteServiceServer will
+// result in compilation errors.
+type UnsafeRouteServiceServer interface {
+	mustEmbedUnimplementedRouteServiceServer()
+}
+
+func RegisterRouteServiceServer(s grpc.ServiceRegistrar, srv RouteServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRouteServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RouteService_ServiceDesc, srv)
+}
+
+func _RouteService_GetItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteServiceServer).GetItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteService_GetItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteServiceServer).GetItem(ctx, req.(*RouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteService_DeleteItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteServiceServer).DeleteItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteService_DeleteItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteServiceServer).DeleteItem(ctx, req.(*RouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteService_GetLatestItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteServiceServer).GetLatestItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteService_GetLatestItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteServiceServer).GetLatestItem(ctx, req.(*RouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteService_GetItemTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteServiceServer).GetItemTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteService_GetItemTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteServiceServer).GetItemTag(ctx, req.(*RouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteService_GetFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteServiceServer).GetFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteService_GetFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteServiceServer).GetFile(ctx, req.(*RouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteService_GetFileMeta_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteServiceServer).GetFileMeta(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteService_GetFileMeta_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteServiceServer).GetFileMeta(ctx, req.(*RouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteService_GetBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteServiceServer).GetBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteService_GetBook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteServiceServer).GetBook(ctx, req.(*RouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteService_PublishBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteServiceServer).PublishBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteService_PublishBook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteServiceServer).PublishBook(ctx, req.(*RouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteService_GetShelf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteServiceServer).GetShelf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteService_GetShelf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteServiceServer).GetShelf(ctx, req.(*RouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RouteService_ServiceDesc is the grpc.ServiceDesc for RouteService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RouteService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ghe.fixture.RouteService",
+	HandlerType: (*RouteServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetItem",
+			Handler:    _RouteService_GetItem_Handler,
+		},
+		{
+			MethodName: "DeleteItem",
+			Handler:    _RouteService_DeleteItem_Handler,
+		},
+		{
+			MethodName: "GetLatestItem",
+			Handler:    _RouteService_GetLatestItem_Handler,
+		},
+		{
+			MethodName: "GetItemTag",
+			Handler:    _RouteService_GetItemTag_Handler,
+		},
+		{
+			MethodName: "GetFile",
+			Handler:    _RouteService_GetFile_Handler,
+		},
+		{
+			MethodName: "GetFileMeta",
+			Handler:    _RouteService_GetFileMeta_Handler,
+		},
+		{
+			MethodName: "GetBook",
+			Handler:    _RouteService_GetBook_Handler,
+		},
+		{
+			MethodName: "PublishBook",
+			Handler:    _RouteService_PublishBook_Handler,
+		},
+		{
+			MethodName: "GetShelf",
+			Handler:    _RouteService_GetShelf_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/testfixture/route.proto",
+}
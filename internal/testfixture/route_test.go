@@ -0,0 +1,135 @@
+package testfixture
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+type routeServer struct {
+	UnimplementedRouteServiceServer
+}
+
+func routeReply(method string, in *RouteRequest) (*RouteReply, error) {
+	reply := &RouteReply{
+		Method: method,
+	}
+	for _, v := range []string{in.Id, in.Path, in.Name, in.Tag} {
+		if v != "" {
+			reply.Values = append(reply.Values, v)
+		}
+	}
+	return reply, nil
+}
+
+func (routeServer) GetItem(ctx context.Context, in *RouteRequest) (*RouteReply, error) {
+	if in.Id == "private" {
+		return nil, status.Error(codes.PermissionDenied, "private item")
+	}
+	return routeReply("GetItem", in)
+}
+
+func (routeServer) DeleteItem(ctx context.Context, in *RouteRequest) (*RouteReply, error) {
+	return routeReply("DeleteItem", in)
+}
+
+func (routeServer) GetLatestItem(ctx context.Context, in *RouteRequest) (*RouteReply, error) {
+	return routeReply("GetLatestItem", in)
+}
+
+func (routeServer) GetItemTag(ctx context.Context, in *RouteRequest) (*RouteReply, error) {
+	return routeReply("GetItemTag", in)
+}
+
+func (routeServer) GetFile(ctx context.Context, in *RouteRequest) (*RouteReply, error) {
+	return routeReply("GetFile", in)
+}
+
+func (routeServer) GetFileMeta(ctx context.Context, in *RouteRequest) (*RouteReply, error) {
+	return routeReply("GetFileMeta", in)
+}
+
+func (routeServer) GetBook(ctx context.Context, in *RouteRequest) (*RouteReply, error) {
+	return routeReply("GetBook", in)
+}
+
+func (routeServer) PublishBook(ctx context.Context, in *RouteRequest) (*RouteReply, error) {
+	return routeReply("PublishBook", in)
+}
+
+func (routeServer) GetShelf(ctx context.Context, in *RouteRequest) (*RouteReply, error) {
+	return routeReply("GetShelf", in)
+}
+
+func TestRouteServiceRoutes(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		statusCode int
+		rpcName    string
+		values     []string
+		allow      string
+	}{
+		{"capture", http.MethodGet, "/fixture/items/a1", http.StatusOK, "GetItem", []string{"a1"}, ""},
+		{"status error", http.MethodGet, "/fixture/items/private", http.StatusForbidden, "", nil, ""},
+		{"capture other method", http.MethodDelete, "/fixture/items/a1", http.StatusOK, "DeleteItem", []string{"a1"}, ""},
+		{"capture method not allowed", http.MethodPut, "/fixture/items/a1", http.StatusMethodNotAllowed, "", nil, "GET, DELETE"},
+		{"empty capture", http.MethodGet, "/fixture/items/", http.StatusNotFound, "", nil, ""},
+		{"fixed before capture", http.MethodGet, "/fixture/items/latest", http.StatusOK, "GetLatestItem", nil, ""},
+		{"fixed leaf shadows capture", http.MethodDelete, "/fixture/items/latest", http.StatusMethodNotAllowed, "", nil, "GET"},
+		{"fixed falls back to capture", http.MethodGet, "/fixture/items/latest/tags/t1", http.StatusOK, "GetItemTag", []string{"latest", "t1"}, ""},
+		{"two captures", http.MethodGet, "/fixture/items/a1/tags/t1", http.StatusOK, "GetItemTag", []string{"a1", "t1"}, ""},
+		{"escaped capture", http.MethodGet, "/fixture/items/a%2F1", http.StatusOK, "GetItem", []string{"a/1"}, ""},
+		{"longest capture", http.MethodGet, "/fixture/files/a/b/raw", http.StatusOK, "GetFile", []string{"a/b"}, ""},
+		{"verb", http.MethodPost, "/fixture/books/a/b:publish", http.StatusOK, "PublishBook", []string{"a/b"}, ""},
+		{"verb method not allowed", http.MethodGet, "/fixture/books/a/b:publish", http.StatusMethodNotAllowed, "", nil, "POST"},
+		{"unknown verb", http.MethodPost, "/fixture/books/a/b:remove", http.StatusNotFound, "", nil, ""},
+		{"backtrack capture", http.MethodGet, "/fixture/files/a/raw/meta", http.StatusOK, "GetFileMeta", []string{"a/raw"}, ""},
+		{"backtrack to fixed child", http.MethodGet, "/fixture/files/raw/raw", http.StatusOK, "GetFile", []string{"raw"}, ""},
+		{"backtrack exhausted", http.MethodGet, "/fixture/files/a/raw/x", http.StatusNotFound, "", nil, ""},
+		{"multi-segment capture", http.MethodGet, "/fixture/books/a/b", http.StatusOK, "GetBook", []string{"a/b"}, ""},
+		{"rule query", http.MethodGet, "/fixture/books/a/b?tag=t1", http.StatusOK, "GetBook", []string{"a/b", "t1"}, ""},
+		{"rule body without query", http.MethodPost, "/fixture/books/a/b:publish?tag=t1", http.StatusOK, "PublishBook", []string{"a/b"}, ""},
+		{"rule template", http.MethodGet, "/v1/shelves/s1", http.StatusOK, "GetShelf", []string{"shelves/s1"}, ""},
+		{"rule template other root", http.MethodGet, "/v2/shelves/s1/t1", http.StatusOK, "GetShelf", []string{"shelves/s1", "t1"}, ""},
+		{"rule template literal mismatch", http.MethodGet, "/v1/authors/a1", http.StatusNotFound, "", nil, ""},
+		{"rule template extra segment", http.MethodGet, "/v1/shelves/s1/books", http.StatusNotFound, "", nil, ""},
+		{"rule template escaped slash", http.MethodGet, "/v1/shelves%2Fs1", http.StatusNotFound, "", nil, ""},
+		{"leading slashes", http.MethodGet, "//fixture/items/a1", http.StatusOK, "GetItem", []string{"a1"}, ""},
+		{"no leaf", http.MethodGet, "/fixture/items", http.StatusNotFound, "", nil, ""},
+		{"unknown prefix", http.MethodGet, "/other/items/a1", http.StatusNotFound, "", nil, ""},
+	}
+	hnd := NewRouteServiceHTTPEndpoint(routeServer{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			hnd.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+			if rec.Code != tt.statusCode {
+				t.Fatalf("status code %d, want %d: %s", rec.Code, tt.statusCode, rec.Body.String())
+			}
+			if allow := rec.Header().Get("Allow"); allow != tt.allow {
+				t.Errorf("Allow %q, want %q", allow, tt.allow)
+			}
+			if tt.statusCode != http.StatusOK {
+				return
+			}
+			reply := &RouteReply{}
+			if err := protojson.Unmarshal(rec.Body.Bytes(), reply); err != nil {
+				t.Fatal(err)
+			}
+			if reply.Method != tt.rpcName {
+				t.Errorf("method %s, want %s", reply.Method, tt.rpcName)
+			}
+			if !reflect.DeepEqual(reply.Values, tt.values) {
+				t.Errorf("values %q, want %q", reply.Values, tt.values)
+			}
+		})
+	}
+}
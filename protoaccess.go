@@ -2,6 +2,10 @@ package protocgenghe
 
 import (
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/yinyin/protoc-gen-go-grpc-http-endpoint/ghegen"
 )
 
 func FindFieldInMessageByName(m *protogen.Message, fieldName string) *protogen.Field {
@@ -12,3 +16,34 @@ func FindFieldInMessageByName(m *protogen.Message, fieldName string) *protogen.F
 	}
 	return nil
 }
+
+func getExtensionMessage(opts protoreflect.ProtoMessage, xt protoreflect.ExtensionType) protoreflect.ProtoMessage {
+	if (opts == nil) || !opts.ProtoReflect().IsValid() || !proto.HasExtension(opts, xt) {
+		return nil
+	}
+	return proto.GetExtension(opts, xt).(protoreflect.ProtoMessage)
+}
+
+// GetGHEFileOptions returns GHE options of given file or nil if not defined.
+func GetGHEFileOptions(desc protoreflect.FileDescriptor) *ghegen.GHEFileOptions {
+	if m := getExtensionMessage(desc.Options(), ghegen.E_Opts); m != nil {
+		return m.(*ghegen.GHEFileOptions)
+	}
+	return nil
+}
+
+// GetGHEServiceOptions returns GHE options of given service or nil if not defined.
+func GetGHEServiceOptions(desc protoreflect.ServiceDescriptor) *ghegen.GHEServiceOptions {
+	if m := getExtensionMessage(desc.Options(), ghegen.E_Base); m != nil {
+		return m.(*ghegen.GHEServiceOptions)
+	}
+	return nil
+}
+
+// GetGHEMethodOptions returns GHE options of given method or nil if not defined.
+func GetGHEMethodOptions(desc protoreflect.MethodDescriptor) *ghegen.GHEMethodOptions {
+	if m := getExtensionMessage(desc.Options(), ghegen.E_Endpoint); m != nil {
+		return m.(*ghegen.GHEMethodOptions)
+	}
+	return nil
+}
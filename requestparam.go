@@ -0,0 +1,168 @@
+package protocgenghe
+
+import (
+	"errors"
+	"fmt"
+	"net/textproto"
+	"strings"
+)
+
+// support binding forms:
+// * X-Tenant-Id: {tenant_id}
+// * X-Trace-Id: {setTraceId(string)}
+// * X-Request-Count: {count int32}
+// * session: {arg_open_api: session_token}
+// * avatar: {saveAvatar(io.Reader)}
+//
+// `Name`: {(`CaptureName`:)? `DestFieldName | DestSetterFn | HandlerParam`}
+//
+// Right hand side shares grammar of URL path capture. Capture pattern is
+// accepted but not used for request parameters.
+
+type RequestParamSource int
+
+const (
+	RequestParamUnknown RequestParamSource = iota
+	RequestParamHeader
+	RequestParamCookie
+	RequestParamFormFile
+)
+
+func (s RequestParamSource) String() string {
+	switch s {
+	case RequestParamHeader:
+		return "header"
+	case RequestParamCookie:
+		return "cookie"
+	case RequestParamFormFile:
+		return "form file"
+	}
+	return "unknown"
+}
+
+type RequestParamBinding struct {
+	Source RequestParamSource
+
+	// Canonical header name or cookie name.
+	Name string
+
+	RawBinding string
+
+	// Destination of the value. Always a URLPathPartCapture part.
+	Part *URLPathPart
+}
+
+// DisplayName returns name of the binding for diagnostic messages.
+func (b *RequestParamBinding) DisplayName() string {
+	if b.Part.CaptureName != "" {
+		return b.Part.CaptureName
+	}
+	return b.Source.String() + " " + b.Name
+}
+
+func ParseRequestParamBinding(source RequestParamSource, binding string) (*RequestParamBinding, error) {
+	sepIndex := strings.IndexByte(binding, ':')
+	if sepIndex < 0 {
+		return nil, fmt.Errorf("invalid %s binding: missing `:` [%s]", source, binding)
+	}
+	name := strings.TrimSpace(binding[:sepIndex])
+	if name == "" {
+		return nil, fmt.Errorf("invalid %s binding: empty name [%s]", source, binding)
+	}
+	if source == RequestParamHeader {
+		name = textproto.CanonicalMIMEHeaderKey(name)
+	}
+	capture := strings.TrimSpace(binding[sepIndex+1:])
+	if (len(capture) < 2) || (capture[0] != '{') || (capture[len(capture)-1] != '}') {
+		return nil, fmt.Errorf("invalid %s binding: destination must be a capture [%s]", source, binding)
+	}
+	parsed, err := ParseURLPath(capture)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s binding: %w [%s]", source, err, binding)
+	}
+	if (len(parsed.Parts) != 1) || (parsed.Parts[0].PartType != URLPathPartCapture) {
+		return nil, fmt.Errorf("invalid %s binding: cannot parse destination [%s]", source, binding)
+	}
+	return &RequestParamBinding{
+		Source:     source,
+		Name:       name,
+		RawBinding: binding,
+		Part:       parsed.Parts[0],
+	}, nil
+}
+
+// resolveParamBindings parses header and cookie bindings in options and
+// resolves field destinations against input message.
+func (em *EndpointMethod) resolveParamBindings() (err error) {
+	em.ParamBindings = nil
+	bindingKeys := make(map[string]struct{})
+	appendBindings := func(source RequestParamSource, bindings []string) error {
+		for _, rawBinding := range bindings {
+			binding, err := ParseRequestParamBinding(source, rawBinding)
+			if err != nil {
+				return err
+			}
+			bindingKey := source.String() + ":" + binding.Name
+			if _, ok := bindingKeys[bindingKey]; ok {
+				return fmt.Errorf("duplicated %s binding: [%s]", source, binding.Name)
+			}
+			bindingKeys[bindingKey] = struct{}{}
+			if binding.Part.DestFieldName != "" {
+				if binding.Part.DestFieldRef, err = em.FindInputFieldRef(binding.Part.DestFieldName); err != nil {
+					return err
+				}
+			} else if em.IsExtraEndpoint && (binding.Part.DestHandlerParamName == "") {
+				return errors.New("only handler parameter binding is supported for extra endpoint: [" + rawBinding + "]")
+			}
+			em.ParamBindings = append(em.ParamBindings, binding)
+		}
+		return nil
+	}
+	if err = appendBindings(RequestParamHeader, em.Options.Headers); err != nil {
+		return
+	}
+	err = appendBindings(RequestParamCookie, em.Options.Cookies)
+	return
+}
+
+// resolveFormFileBindings parses form file bindings in options.
+// Destination must be a bytes field within body field or a setter function
+// accepting io.Reader.
+func (em *EndpointMethod) resolveFormFileBindings() error {
+	em.FormFileBindings = nil
+	if (len(em.Options.FormFiles) != 0) && !em.Options.AcceptForm {
+		return errors.New("form_files requires accept_form")
+	}
+	partNames := make(map[string]struct{})
+	for _, rawBinding := range em.Options.FormFiles {
+		binding, err := ParseRequestParamBinding(RequestParamFormFile, rawBinding)
+		if err != nil {
+			return err
+		}
+		if _, ok := partNames[binding.Name]; ok {
+			return fmt.Errorf("duplicated form file binding: [%s]", binding.Name)
+		}
+		partNames[binding.Name] = struct{}{}
+		part := binding.Part
+		switch {
+		case part.DestFieldName != "":
+			if part.DestFieldRef, err = em.FindInputFieldRef(part.DestFieldName); err != nil {
+				return err
+			}
+			if fieldElementGoType(part.DestFieldRef) != "[]byte" {
+				return errors.New("form file field must be bytes: [" + rawBinding + "]")
+			}
+			if (em.Options.Body != "*") && !isFieldPathOverlapped(part.DestFieldName, em.Options.Body) {
+				return errors.New("form file field must be within body field: [" + rawBinding + "]")
+			}
+		case part.DestSetterFuncName != "":
+			if part.DestSetterArg0Type != "io.Reader" {
+				return errors.New("form file setter must accept io.Reader: [" + rawBinding + "]")
+			}
+		default:
+			return errors.New("form file must be bound to field or setter: [" + rawBinding + "]")
+		}
+		em.FormFileBindings = append(em.FormFileBindings, binding)
+	}
+	return nil
+}
@@ -8,6 +8,11 @@ var DefaultURLPartFloatPattern = []byte("0-9+\\-\\.eE")
 
 var DefaultURLPartTextPattern = []byte("^/")
 
+var DefaultURLPartEnumPattern = []byte("0-9A-Za-z_+\\-")
+
+// URLPartEnumTypeName is the key in DefaultURLPartTypePatterns for enum typed fields.
+const URLPartEnumTypeName = "enum"
+
 var DefaultURLPartTypePatterns = map[string][]byte{
 	"bool":    []byte("truefalseTRUEFALSE01"),
 	"int32":   DefaultURLPartIntPattern,
@@ -18,4 +23,6 @@ var DefaultURLPartTypePatterns = map[string][]byte{
 	"float64": DefaultURLPartFloatPattern,
 	"string":  DefaultURLPartTextPattern,
 	"[]byte":  DefaultURLPartTextPattern,
+
+	URLPartEnumTypeName: DefaultURLPartEnumPattern,
 }
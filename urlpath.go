@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 
 	"google.golang.org/protobuf/compiler/protogen"
@@ -29,6 +30,11 @@ import (
 // * /path/to/endpoint/entity/{arg_open_api: setterFn(int32)}/remain/parts
 //
 // {(`CaptureName`:)? (`Pattern`,)? `DestFieldName | DestSetterFn`}
+//
+// Setter function is invoked as `setterFn(inputMessage, capturedValue, args...)`
+// and must return an error.
+// Handler parameters are passed to custom handler functions in the order of
+// appearance after the `http.ResponseWriter` and `*http.Request` arguments.
 
 type URLPathPartType int
 
@@ -43,6 +49,9 @@ type CaptureDestFieldRef struct {
 	GoType            string
 	IsPresencePointer bool
 	DescRef           *protogen.Field
+
+	// Field descriptors along the field path. The last one is DescRef.
+	PathDescRefs []*protogen.Field
 }
 
 type URLBarePathPart struct {
@@ -132,6 +141,20 @@ type URLPathPart struct {
 	DestHandlerParamType string
 }
 
+// ParamName returns canonical name of the captured parameter. The capture
+// name is used when given, otherwise the name of destination field or
+// handler parameter. Returns empty string for setter function destination
+// without capture name.
+func (part *URLPathPart) ParamName() string {
+	switch {
+	case part.CaptureName != "":
+		return part.CaptureName
+	case part.DestFieldName != "":
+		return part.DestFieldName
+	}
+	return part.DestHandlerParamName
+}
+
 type URLPath struct {
 	RawPath []byte
 	Parts   []*URLPathPart
@@ -155,6 +178,22 @@ func (u *URLPath) BarePath() *URLBarePath {
 	}
 }
 
+// ParamNames returns parameter names of capture parts. Unnamed captures
+// are shown as `#` followed by index of capture.
+func (u *URLPath) ParamNames() (result []string) {
+	for _, part := range u.Parts {
+		if part.PartType != URLPathPartCapture {
+			continue
+		}
+		paramName := part.ParamName()
+		if paramName == "" {
+			paramName = "#" + strconv.FormatInt(int64(len(result)), 10)
+		}
+		result = append(result, paramName)
+	}
+	return
+}
+
 type urlPathPartParser interface {
 	Feed(result *URLPath, idx int, ch byte) (nextParser urlPathPartParser, err error)
 	Finish(result *URLPath) (err error)
@@ -328,6 +367,8 @@ func (p *captureURLPathPartParser) parseFieldNameOrHandlerParam(result *URLPath,
 	return
 }
 
+var captureNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
 func (p *captureURLPathPartParser) doParse(result *URLPath, endIndex int) (err error) {
 	idx := endIndex - 1
 	for (result.RawPath[idx] == ' ') || (result.RawPath[idx] == '\t') {
@@ -348,7 +389,10 @@ func (p *captureURLPathPartParser) doParse(result *URLPath, endIndex int) (err e
 	}
 	var patternByteMapper ByteMapper
 	if result.RawPath[idx] == ',' {
-		patternStartIndex := p.firstColonIndex + 1
+		patternStartIndex := p.startIndex + 1
+		if p.firstColonIndex > p.startIndex {
+			patternStartIndex = p.firstColonIndex + 1
+		}
 		for result.RawPath[patternStartIndex] == ' ' {
 			patternStartIndex++
 		}
@@ -362,6 +406,10 @@ func (p *captureURLPathPartParser) doParse(result *URLPath, endIndex int) (err e
 	var captureName string
 	if captureNameStartIndex := p.startIndex + 1; (p.firstColonIndex <= idx) && (p.firstColonIndex > captureNameStartIndex) {
 		captureName = sanitizer.TrimCapturedSymbol(result.RawPath[p.startIndex+1 : p.firstColonIndex])
+		if !captureNameRegexp.MatchString(captureName) {
+			err = fmt.Errorf("invalid capture name: [%s]", captureName)
+			return
+		}
 	}
 	result.Parts = append(result.Parts, &URLPathPart{
 		URLBarePathPart: URLBarePathPart{
@@ -394,7 +442,9 @@ func (p *captureURLPathPartParser) Feed(result *URLPath, idx int, ch byte) (urlP
 		return p, nil
 	}
 	if ch == '}' { // end of capture
-		p.doParse(result, idx)
+		if err := p.doParse(result, idx); err != nil {
+			return nil, err
+		}
 		return &fixedURLPathPartParser{
 			startIndex: idx + 1,
 		}, nil
@@ -101,27 +101,36 @@ func (n *URLRouteRadixNode) appendChildPart(childPart *URLBarePathPart, remainPa
 	lastNode.Leaf = endpointPath
 }
 
+func (n *URLRouteRadixNode) setLeaf(endpointPath *EndpointPath) error {
+	if n.Leaf != nil {
+		return errors.New("duplicate endpoint path at " + n.String())
+	}
+	n.Leaf = endpointPath
+	return nil
+}
+
 func (n *URLRouteRadixNode) insertChildPartWithURLPathPartFixed(childPart *URLBarePathPart, remainParts []*URLBarePathPart, endpointPath *EndpointPath) error {
 	for _, childNode := range n.Children {
-		if commPrefixLen := childNode.commonPrefixLen(childPart); commPrefixLen != 0 {
-			if commPrefixLen == len(childPart.FixedPath) {
-				if len(remainParts) == 0 {
-					if n.Leaf != nil {
-						return errors.New("duplicate endpoint path at " + childNode.String())
-					}
-					n.Leaf = endpointPath
-					return nil
-				}
-				return childNode.insertChildPart(remainParts[0], remainParts[1:], endpointPath)
+		commPrefixLen := childNode.commonPrefixLen(childPart)
+		if commPrefixLen == 0 {
+			continue
+		}
+		if commPrefixLen < len(childNode.Part.FixedPath) {
+			if err := childNode.splitNode(commPrefixLen); err != nil {
+				return err
 			}
-			splitedChildPart := URLBarePathPart{
-				PartType:  URLPathPartFixed,
-				FixedPath: childPart.FixedPath[commPrefixLen:],
+		}
+		if commPrefixLen == len(childPart.FixedPath) {
+			if len(remainParts) == 0 {
+				return childNode.setLeaf(endpointPath)
 			}
-			childNode.splitNode(commPrefixLen)
-			childNode.appendChildPart(&splitedChildPart, remainParts, endpointPath)
-			return nil
+			return childNode.insertChildPart(remainParts[0], remainParts[1:], endpointPath)
+		}
+		splitedChildPart := URLBarePathPart{
+			PartType:  URLPathPartFixed,
+			FixedPath: childPart.FixedPath[commPrefixLen:],
 		}
+		return childNode.insertChildPartWithURLPathPartFixed(&splitedChildPart, remainParts, endpointPath)
 	}
 	n.appendChildPart(childPart, remainParts, endpointPath)
 	return nil
@@ -132,11 +141,7 @@ func (n *URLRouteRadixNode) insertChildPartWithURLPathPartCapture(childPart *URL
 		haveIntersection, equalPattern := childNode.checkPatternOverlap(childPart)
 		if equalPattern {
 			if len(remainParts) == 0 {
-				if n.Leaf != nil {
-					return errors.New("duplicate endpoint path at " + childNode.String())
-				}
-				n.Leaf = endpointPath
-				return nil
+				return childNode.setLeaf(endpointPath)
 			}
 			return childNode.insertChildPart(remainParts[0], remainParts[1:], endpointPath)
 		}
@@ -174,7 +179,6 @@ func (n *URLRouteRadixNode) ImportEndpointPaths(paths []*EndpointPath) error {
 		}
 	}
 	return nil
-
 }
 
 func NewURLRouteRadixRoot() *URLRouteRadixNode {
@@ -182,3 +186,20 @@ func NewURLRouteRadixRoot() *URLRouteRadixNode {
 		Depth: 0,
 	}
 }
+
+// OrderedChildren returns children nodes in the order of matching attempts:
+// nodes of fixed part come first and then nodes of capture part.
+func (n *URLRouteRadixNode) OrderedChildren() []*URLRouteRadixNode {
+	result := make([]*URLRouteRadixNode, 0, len(n.Children))
+	for _, childNode := range n.Children {
+		if childNode.Part.PartType == URLPathPartFixed {
+			result = append(result, childNode)
+		}
+	}
+	for _, childNode := range n.Children {
+		if childNode.Part.PartType != URLPathPartFixed {
+			result = append(result, childNode)
+		}
+	}
+	return result
+}
@@ -0,0 +1,195 @@
+package protocgenghe
+
+import (
+	"bytes"
+)
+
+// URLRouteCapture is the byte range of path captured by a capture node.
+type URLRouteCapture struct {
+	Node *URLRouteRadixNode
+
+	// Part is the capture part in URL path of matched method, or of the
+	// first defined method when HTTP method is not allowed.
+	Part *URLPathPart
+
+	Start int
+	End   int
+}
+
+// URLRouteTrace is the result of walking route tree with a concrete path.
+type URLRouteTrace struct {
+	Method string
+
+	// Path without leading slashes. Offsets of captures are relative to it.
+	Path []byte
+
+	// EndpointPath of the leaf matched by Path. Nil when nothing matched.
+	EndpointPath *EndpointPath
+
+	// URLPathMethod of EndpointPath which serves Method. Nil when method
+	// is not allowed.
+	URLPathMethod *EndpointURLPathMethod
+
+	Captures []*URLRouteCapture
+
+	// NearestNode is the deepest node reached by the walk and NearestOffset
+	// is the length of path consumed by the node and its ancestors.
+	NearestNode   *URLRouteRadixNode
+	NearestOffset int
+}
+
+// urlRouteWalker walks route tree in the same order as generated ServeHTTP
+// function: fixed children before capture children, and the longest
+// capture first with shorter captures tried when backtracking is needed.
+// The walk stops at the first leaf consuming the whole path.
+type urlRouteWalker struct {
+	path     []byte
+	captures []URLRouteCapture
+
+	nearestNode   *URLRouteRadixNode
+	nearestOffset int
+}
+
+func (w *urlRouteWalker) walkNode(node *URLRouteRadixNode, offset int) *URLRouteRadixNode {
+	switch node.Part.PartType {
+	case URLPathPartFixed:
+		if !bytes.HasPrefix(w.path[offset:], node.Part.FixedPath) {
+			return nil
+		}
+		return w.walkBody(node, offset+len(node.Part.FixedPath))
+	case URLPathPartCapture:
+		captureLen := node.Part.PatternByteMapper.AcceptLen(w.path[offset:])
+		minCaptureLen := captureLen
+		if captureNeedBacktrack(node) {
+			minCaptureLen = 1
+		}
+		for ; (captureLen >= minCaptureLen) && (captureLen != 0); captureLen-- {
+			w.captures = append(w.captures, URLRouteCapture{
+				Node:  node,
+				Start: offset,
+				End:   offset + captureLen,
+			})
+			if leafNode := w.walkBody(node, offset+captureLen); leafNode != nil {
+				return leafNode
+			}
+			w.captures = w.captures[:len(w.captures)-1]
+		}
+	}
+	return nil
+}
+
+func (w *urlRouteWalker) walkBody(node *URLRouteRadixNode, offset int) *URLRouteRadixNode {
+	if (w.nearestNode == nil) || (offset > w.nearestOffset) {
+		w.nearestNode = node
+		w.nearestOffset = offset
+	}
+	if (node.Leaf != nil) && (offset == len(w.path)) {
+		return node
+	}
+	return w.walkChildren(node, offset)
+}
+
+func (w *urlRouteWalker) walkChildren(node *URLRouteRadixNode, offset int) *URLRouteRadixNode {
+	orderedChildren := node.OrderedChildren()
+	fixedChildCount := 0
+	for _, childNode := range orderedChildren {
+		if childNode.Part.PartType == URLPathPartFixed {
+			fixedChildCount++
+		}
+	}
+	for _, childNode := range orderedChildren {
+		if (fixedChildCount > 1) && (childNode.Part.PartType == URLPathPartFixed) && (len(childNode.Part.FixedPath) == 0) {
+			continue
+		}
+		if leafNode := w.walkNode(childNode, offset); leafNode != nil {
+			return leafNode
+		}
+	}
+	return nil
+}
+
+// TraceRoute walks the route tree from root node n with given HTTP method
+// and escaped URL path the same way as generated handler does. Leading
+// slashes of path are ignored.
+func (n *URLRouteRadixNode) TraceRoute(method string, path []byte) *URLRouteTrace {
+	for (len(path) != 0) && (path[0] == '/') {
+		path = path[1:]
+	}
+	w := &urlRouteWalker{
+		path:        path,
+		nearestNode: n,
+	}
+	leafNode := w.walkChildren(n, 0)
+	t := &URLRouteTrace{
+		Method: method,
+		Path:   path,
+	}
+	if leafNode == nil {
+		t.NearestNode = w.nearestNode
+		t.NearestOffset = w.nearestOffset
+		return t
+	}
+	t.EndpointPath = leafNode.Leaf
+	t.Captures = make([]*URLRouteCapture, len(w.captures))
+	for idx := range w.captures {
+		t.Captures[idx] = &w.captures[idx]
+	}
+	for _, ref := range leafNode.Leaf.URLPathMethods() {
+		if ref.HTTPMethod == method {
+			t.URLPathMethod = ref
+			break
+		}
+	}
+	partsRef := t.URLPathMethod
+	if partsRef == nil {
+		partsRef = leafNode.Leaf.URLPathMethods()[0]
+	}
+	captureIndex := 0
+	for _, part := range partsRef.URLPath.Parts {
+		if part.PartType != URLPathPartCapture {
+			continue
+		}
+		if captureIndex < len(t.Captures) {
+			t.Captures[captureIndex].Part = part
+		}
+		captureIndex++
+	}
+	return t
+}
+
+// URLRouteMatch is the result of matching a request against route tree.
+type URLRouteMatch struct {
+	EndpointPath *EndpointPath
+
+	// URLPathMethod of EndpointPath which serves the requested method.
+	// Nil when method is not allowed.
+	URLPathMethod *EndpointURLPathMethod
+
+	// Captures are the captured values in the order of capture parts in
+	// path. The values are slices of given path and are not unescaped.
+	Captures [][]byte
+}
+
+// Match finds the endpoint path for given HTTP method and escaped URL path
+// from root node n. Leading slashes of path are ignored.
+// Children are tried in the same order as generated handler: fixed children
+// before capture children and the longest capture first. When a child does
+// not lead to a leaf consuming the whole path, the walk backtracks to the
+// next child or to a shorter capture.
+// Returns nil when no endpoint path matches. Result with nil URLPathMethod
+// is returned when the path matches but method is not allowed.
+func (n *URLRouteRadixNode) Match(method, path []byte) *URLRouteMatch {
+	t := n.TraceRoute(string(method), path)
+	if t.EndpointPath == nil {
+		return nil
+	}
+	m := &URLRouteMatch{
+		EndpointPath:  t.EndpointPath,
+		URLPathMethod: t.URLPathMethod,
+		Captures:      make([][]byte, len(t.Captures)),
+	}
+	for idx, capture := range t.Captures {
+		m.Captures[idx] = t.Path[capture.Start:capture.End]
+	}
+	return m
+}
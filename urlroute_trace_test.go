@@ -0,0 +1,174 @@
+package protocgenghe
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/yinyin/protoc-gen-go-grpc-http-endpoint/internal/testfixture"
+)
+
+type fixtureRouteServer struct {
+	testfixture.UnimplementedRouteServiceServer
+}
+
+func fixtureRouteReply(method string, in *testfixture.RouteRequest) (*testfixture.RouteReply, error) {
+	reply := &testfixture.RouteReply{
+		Method: method,
+	}
+	for _, v := range []string{in.Id, in.Path, in.Name, in.Tag} {
+		if v != "" {
+			reply.Values = append(reply.Values, v)
+		}
+	}
+	return reply, nil
+}
+
+func (fixtureRouteServer) GetItem(ctx context.Context, in *testfixture.RouteRequest) (*testfixture.RouteReply, error) {
+	return fixtureRouteReply("GetItem", in)
+}
+
+func (fixtureRouteServer) DeleteItem(ctx context.Context, in *testfixture.RouteRequest) (*testfixture.RouteReply, error) {
+	return fixtureRouteReply("DeleteItem", in)
+}
+
+func (fixtureRouteServer) GetLatestItem(ctx context.Context, in *testfixture.RouteRequest) (*testfixture.RouteReply, error) {
+	return fixtureRouteReply("GetLatestItem", in)
+}
+
+func (fixtureRouteServer) GetItemTag(ctx context.Context, in *testfixture.RouteRequest) (*testfixture.RouteReply, error) {
+	return fixtureRouteReply("GetItemTag", in)
+}
+
+func (fixtureRouteServer) GetFile(ctx context.Context, in *testfixture.RouteRequest) (*testfixture.RouteReply, error) {
+	return fixtureRouteReply("GetFile", in)
+}
+
+func (fixtureRouteServer) GetFileMeta(ctx context.Context, in *testfixture.RouteRequest) (*testfixture.RouteReply, error) {
+	return fixtureRouteReply("GetFileMeta", in)
+}
+
+func (fixtureRouteServer) GetBook(ctx context.Context, in *testfixture.RouteRequest) (*testfixture.RouteReply, error) {
+	return fixtureRouteReply("GetBook", in)
+}
+
+func (fixtureRouteServer) PublishBook(ctx context.Context, in *testfixture.RouteRequest) (*testfixture.RouteReply, error) {
+	return fixtureRouteReply("PublishBook", in)
+}
+
+func (fixtureRouteServer) GetShelf(ctx context.Context, in *testfixture.RouteRequest) (*testfixture.RouteReply, error) {
+	return fixtureRouteReply("GetShelf", in)
+}
+
+// fixtureRouteRoot builds route tree of RouteService in fixture.
+func fixtureRouteRoot(t *testing.T) *URLRouteRadixNode {
+	fileName := fixtureDir + "/route.proto"
+	gen, err := NewPluginWithFileDescriptorSet(fixtureFileDescriptorSet(), []string{fileName})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ef := LoadEndpointFile(gen.FilesByPath[fileName])
+	pathContainer := NewEndpointPathContainer()
+	ef.Services[0].ExportEndpointPaths(pathContainer)
+	if err = pathContainer.Err(); err != nil {
+		t.Fatal(err)
+	}
+	routeRoot := NewURLRouteRadixRoot()
+	if err = routeRoot.ImportEndpointPaths(pathContainer.SortedEndpointPaths()); err != nil {
+		t.Fatal(err)
+	}
+	return routeRoot
+}
+
+// TestMatchWithGeneratedHandler checks Match against ServeHTTP generated
+// from the same route tree.
+func TestMatchWithGeneratedHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		statusCode int
+		rpcName    string
+		captures   []string
+	}{
+		{"capture", http.MethodGet, "/fixture/items/a1", http.StatusOK, "GetItem", []string{"a1"}},
+		{"capture other method", http.MethodDelete, "/fixture/items/a1", http.StatusOK, "DeleteItem", []string{"a1"}},
+		{"capture method not allowed", http.MethodPut, "/fixture/items/a1", http.StatusMethodNotAllowed, "", []string{"a1"}},
+		{"empty capture", http.MethodGet, "/fixture/items/", http.StatusNotFound, "", nil},
+		{"fixed before capture", http.MethodGet, "/fixture/items/latest", http.StatusOK, "GetLatestItem", nil},
+		{"fixed leaf shadows capture", http.MethodDelete, "/fixture/items/latest", http.StatusMethodNotAllowed, "", nil},
+		{"fixed falls back to capture", http.MethodGet, "/fixture/items/latest/tags/t1", http.StatusOK, "GetItemTag", []string{"latest", "t1"}},
+		{"two captures", http.MethodGet, "/fixture/items/a1/tags/t1", http.StatusOK, "GetItemTag", []string{"a1", "t1"}},
+		{"longest capture", http.MethodGet, "/fixture/files/a/b/raw", http.StatusOK, "GetFile", []string{"a/b"}},
+		{"backtrack capture", http.MethodGet, "/fixture/files/a/raw/meta", http.StatusOK, "GetFileMeta", []string{"a/raw"}},
+		{"backtrack to fixed child", http.MethodGet, "/fixture/files/raw/raw", http.StatusOK, "GetFile", []string{"raw"}},
+		{"backtrack exhausted", http.MethodGet, "/fixture/files/a/raw/x", http.StatusNotFound, "", nil},
+		{"multi-segment capture", http.MethodGet, "/fixture/books/a/b", http.StatusOK, "GetBook", []string{"a/b"}},
+		{"verb", http.MethodPost, "/fixture/books/a/b:publish", http.StatusOK, "PublishBook", []string{"a/b"}},
+		{"verb method not allowed", http.MethodGet, "/fixture/books/a/b:publish", http.StatusMethodNotAllowed, "", []string{"a/b"}},
+		{"without verb method not allowed", http.MethodPost, "/fixture/books/a/b", http.StatusMethodNotAllowed, "", []string{"a/b"}},
+		{"unknown verb", http.MethodPost, "/fixture/books/a/b:remove", http.StatusNotFound, "", nil},
+		{"absolute rule path", http.MethodGet, "/v1/shelves/s1", http.StatusOK, "GetShelf", []string{"shelves/s1"}},
+		{"absolute rule path other root", http.MethodGet, "/v2/shelves/s1/t1", http.StatusOK, "GetShelf", []string{"shelves/s1", "t1"}},
+		{"leading slashes", http.MethodGet, "//fixture/items/a1", http.StatusOK, "GetItem", []string{"a1"}},
+		{"no leaf", http.MethodGet, "/fixture/items", http.StatusNotFound, "", nil},
+		{"unknown prefix", http.MethodGet, "/other/items/a1", http.StatusNotFound, "", nil},
+	}
+	routeRoot := fixtureRouteRoot(t)
+	hnd := testfixture.NewRouteServiceHTTPEndpoint(fixtureRouteServer{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := routeRoot.Match([]byte(tt.method), []byte(tt.path))
+			rec := httptest.NewRecorder()
+			hnd.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+			if rec.Code != tt.statusCode {
+				t.Errorf("ServeHTTP: status code %d, want %d: %s", rec.Code, tt.statusCode, rec.Body.String())
+			}
+			if tt.statusCode == http.StatusNotFound {
+				if m != nil {
+					t.Errorf("Match: matched %s, want nil", m.EndpointPath.URLBarePath.CanonicalPath())
+				}
+				return
+			}
+			if m == nil {
+				t.Fatal("Match: got nil")
+			}
+			var captures []string
+			for _, capture := range m.Captures {
+				captures = append(captures, string(capture))
+			}
+			if !reflect.DeepEqual(captures, tt.captures) {
+				t.Errorf("Match: captures %q, want %q", captures, tt.captures)
+			}
+			if tt.statusCode == http.StatusMethodNotAllowed {
+				if m.URLPathMethod != nil {
+					t.Errorf("Match: got method %s, want nil", m.URLPathMethod.MethodRef.DescRef.GoName)
+				}
+				if allow := rec.Header().Get("Allow"); allow != m.EndpointPath.AllowMethods() {
+					t.Errorf("ServeHTTP: Allow %q, Match: %q", allow, m.EndpointPath.AllowMethods())
+				}
+				return
+			}
+			if m.URLPathMethod == nil {
+				t.Fatal("Match: got nil method")
+			}
+			if rpcName := m.URLPathMethod.MethodRef.DescRef.GoName; rpcName != tt.rpcName {
+				t.Errorf("Match: method %s, want %s", rpcName, tt.rpcName)
+			}
+			reply := &testfixture.RouteReply{}
+			if err := protojson.Unmarshal(rec.Body.Bytes(), reply); err != nil {
+				t.Fatal(err)
+			}
+			if reply.Method != tt.rpcName {
+				t.Errorf("ServeHTTP: method %s, want %s", reply.Method, tt.rpcName)
+			}
+			if !reflect.DeepEqual(reply.Values, tt.captures) {
+				t.Errorf("ServeHTTP: values %q, want %q", reply.Values, tt.captures)
+			}
+		})
+	}
+}
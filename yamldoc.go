@@ -0,0 +1,147 @@
+package protocgenghe
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// yamlMap is mapping of YAML document which keeps insertion order of keys.
+// Values are yamlMap, []any, string, int, int32, int64 or bool.
+type yamlMap struct {
+	keys   []string
+	values map[string]any
+}
+
+func newYAMLMap() *yamlMap {
+	return &yamlMap{
+		values: make(map[string]any),
+	}
+}
+
+// Set sets value of key. Keys set for the first time are appended.
+func (m *yamlMap) Set(key string, value any) *yamlMap {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+	return m
+}
+
+// SetNonEmpty sets value of key if value is not empty string.
+func (m *yamlMap) SetNonEmpty(key, value string) *yamlMap {
+	if value != "" {
+		m.Set(key, value)
+	}
+	return m
+}
+
+func (m *yamlMap) Get(key string) any {
+	return m.values[key]
+}
+
+func (m *yamlMap) Len() int {
+	return len(m.keys)
+}
+
+var yamlPlainScalarRegexp = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_./$-]*$`)
+
+func yamlScalarText(s string) string {
+	if yamlPlainScalarRegexp.MatchString(s) {
+		switch strings.ToLower(s) {
+		case "true", "false", "null", "yes", "no", "on", "off", "y", "n":
+		default:
+			return s
+		}
+	}
+	return strconv.Quote(s)
+}
+
+// writeYAMLString writes s as value after `key:` in block style when it
+// contains multiple lines.
+func writeYAMLString(b *strings.Builder, indent int, s string) {
+	if !strings.Contains(s, "\n") || strings.HasPrefix(s, " ") || strings.ContainsAny(s, "\r\t") {
+		b.WriteString(" " + yamlScalarText(s) + "\n")
+		return
+	}
+	b.WriteString(" |-\n")
+	for _, line := range strings.Split(s, "\n") {
+		if line != "" {
+			b.WriteString(strings.Repeat(" ", indent+2) + line)
+		}
+		b.WriteByte('\n')
+	}
+}
+
+func writeYAMLValue(b *strings.Builder, indent int, value any) {
+	switch v := value.(type) {
+	case *yamlMap:
+		if v.Len() == 0 {
+			b.WriteString(" {}\n")
+			return
+		}
+		b.WriteByte('\n')
+		writeYAMLMap(b, indent+2, v)
+	case []any:
+		if len(v) == 0 {
+			b.WriteString(" []\n")
+			return
+		}
+		b.WriteByte('\n')
+		writeYAMLList(b, indent, v)
+	case string:
+		writeYAMLString(b, indent, v)
+	case int:
+		b.WriteString(" " + strconv.FormatInt(int64(v), 10) + "\n")
+	case int32:
+		b.WriteString(" " + strconv.FormatInt(int64(v), 10) + "\n")
+	case int64:
+		b.WriteString(" " + strconv.FormatInt(v, 10) + "\n")
+	case bool:
+		b.WriteString(" " + strconv.FormatBool(v) + "\n")
+	default:
+		b.WriteString(" null\n")
+	}
+}
+
+func writeYAMLMap(b *strings.Builder, indent int, m *yamlMap) {
+	for _, key := range m.keys {
+		b.WriteString(strings.Repeat(" ", indent) + yamlScalarText(key) + ":")
+		writeYAMLValue(b, indent, m.values[key])
+	}
+}
+
+// writeYAMLList writes items of list. Each item is rendered as if it is
+// indented one more level then the leading spaces are replaced with `- `.
+func writeYAMLList(b *strings.Builder, indent int, items []any) {
+	for _, item := range items {
+		var itemBuf strings.Builder
+		switch v := item.(type) {
+		case *yamlMap:
+			if v.Len() == 0 {
+				b.WriteString(strings.Repeat(" ", indent) + "- {}\n")
+				continue
+			}
+			writeYAMLMap(&itemBuf, indent+2, v)
+		case []any:
+			if len(v) == 0 {
+				b.WriteString(strings.Repeat(" ", indent) + "- []\n")
+				continue
+			}
+			writeYAMLList(&itemBuf, indent+2, v)
+		default:
+			itemBuf.WriteString(strings.Repeat(" ", indent+2))
+			var valueBuf strings.Builder
+			writeYAMLValue(&valueBuf, indent+2, v)
+			itemBuf.WriteString(valueBuf.String()[1:])
+		}
+		b.WriteString(strings.Repeat(" ", indent) + "- " + itemBuf.String()[indent+2:])
+	}
+}
+
+// MarshalYAML renders m as YAML document.
+func (m *yamlMap) MarshalYAML() []byte {
+	var b strings.Builder
+	writeYAMLMap(&b, 0, m)
+	return []byte(b.String())
+}